@@ -4,6 +4,9 @@ import (
 	"context"
 	"e-klinik/config"
 	"e-klinik/pkg"
+	"net/http"
+	"path"
+	"strings"
 	"time"
 
 	"e-klinik/internal/domain/request"
@@ -16,6 +19,9 @@ import (
 type AuthHandler interface {
 	Login(c *gin.Context)
 	Refresh(c *gin.Context)
+	OidcLogin(c *gin.Context)
+	OidcCallback(c *gin.Context)
+	ResetPassword(c *gin.Context)
 }
 
 type AuthHandlerImpl struct {
@@ -31,6 +37,14 @@ func NewAuthHandler(Uu *usecase.UserUsecaseImpl, cfg *config.Config) *AuthHandle
 	}
 }
 
+// perangkat mengambil identitas perangkat yang dicatat pada sesi login.
+func perangkat(c *gin.Context) request.Perangkat {
+	return request.Perangkat{
+		UserAgent: c.Request.UserAgent(),
+		IpAddress: c.ClientIP(),
+	}
+}
+
 func (lc *AuthHandlerImpl) Login(c *gin.Context) {
 	var req request.Login
 
@@ -43,8 +57,12 @@ func (lc *AuthHandlerImpl) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := lc.Uu.LoginWithPassword(c, req.Username, req.Password)
+	user, err := lc.Uu.LoginWithPassword(c, req.Username, req.Password, perangkat(c))
 	if err != nil {
+		if appErr, ok := pkg.AsAppError(err); ok && appErr.Code == pkg.ErrorCodeLocked {
+			resp.HandleErrorResponse(c, "akun terkunci", appErr)
+			return
+		}
 		resp.HandleErrorResponse(
 			c,
 			"invalid username or password",
@@ -75,8 +93,12 @@ func (lc *AuthHandlerImpl) Refresh(c *gin.Context) {
 		return
 	}
 
-	user, err := lc.Uu.Refresh(ctx, req.RefreshToken)
+	user, err := lc.Uu.Refresh(ctx, req.RefreshToken, perangkat(c))
 	if err != nil {
+		if appErr, ok := pkg.AsAppError(err); ok && appErr.Expose && appErr.Code == pkg.ErrorCodeUnauthorized {
+			resp.HandleErrorResponse(c, "failed to refresh access token", appErr)
+			return
+		}
 		resp.HandleErrorResponse(
 			c,
 			"failed to refresh access token",
@@ -87,3 +109,71 @@ func (lc *AuthHandlerImpl) Refresh(c *gin.Context) {
 
 	resp.HandleSuccessResponse(c, "refresh token berhasil", user)
 }
+
+// OidcLogin mengarahkan pengguna ke halaman login identity provider kampus.
+func (lc *AuthHandlerImpl) OidcLogin(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	url, state, err := lc.Uu.OidcLoginURL(ctx)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed start sso login", err)
+		return
+	}
+
+	// State diikat ke browser ini; callback tanpa cookie yang sama ditolak (login CSRF).
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cookieStateOidc, state, 0, path.Dir(c.Request.URL.Path), "", lc.cookieAman(c), true)
+	c.Redirect(http.StatusFound, url)
+}
+
+// cookieStateOidc menyimpan state OIDC di browser yang memulai login.
+const cookieStateOidc = "oidc_state"
+
+func (lc *AuthHandlerImpl) cookieAman(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.HasPrefix(lc.Cfg.Oidc.RedirectUrl, "https://")
+}
+
+// OidcCallback menerima redirect dari identity provider dan menerbitkan token yang sama
+// dengan login password.
+func (lc *AuthHandlerImpl) OidcCallback(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if e := c.Query("error"); e != "" {
+		resp.HandleErrorResponse(c, "sso login dibatalkan", pkg.ExposeError(pkg.ErrorCodeUnauthorized, e))
+		return
+	}
+
+	stateCookie, _ := c.Cookie(cookieStateOidc)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cookieStateOidc, "", -1, path.Dir(c.Request.URL.Path), "", lc.cookieAman(c), true)
+
+	user, err := lc.Uu.LoginOidc(ctx, c.Query("state"), stateCookie, c.Query("code"), perangkat(c))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed sso login", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "login berhasil", user)
+}
+
+// ResetPassword mengganti password memakai token reset dari admin; tidak memerlukan login.
+func (lc *AuthHandlerImpl) ResetPassword(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req request.ResetPassword
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	res, err := lc.Uu.ResetPassword(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed reset password", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success reset password", res)
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/internal/usecase"
+
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+type JadwalDinasHandler interface {
+	CreateShift(c *gin.Context)
+	ListShift(c *gin.Context)
+	UpdateShift(c *gin.Context)
+	DeleteShift(c *gin.Context)
+	CreateJadwalDinas(c *gin.Context)
+	ListJadwalDinas(c *gin.Context)
+	ListJadwalDinasSaya(c *gin.Context)
+	DeleteJadwalDinas(c *gin.Context)
+}
+
+type JadwalDinasHandlerImpl struct {
+	Cfg *config.Config
+	ju  usecase.JadwalDinasUsecase
+}
+
+func NewJadwalDinasHandler(ju usecase.JadwalDinasUsecase, cfg *config.Config) *JadwalDinasHandlerImpl {
+	return &JadwalDinasHandlerImpl{
+		Cfg: cfg,
+		ju:  ju,
+	}
+}
+
+func (h *JadwalDinasHandlerImpl) CreateShift(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateShift
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to create shift", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create shift", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.ju.AddShift(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create shift", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create shift", result)
+}
+
+func (h *JadwalDinasHandlerImpl) ListShift(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var isActive *bool
+	if v := c.Query("is_active"); v != "" {
+		isActive = utils.BoolPtr(utils.ParseBool(v))
+	}
+
+	result, err := h.ju.ListShift(ctx, isActive)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get shift list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get shift list", result)
+}
+
+func (h *JadwalDinasHandlerImpl) UpdateShift(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid shift id"))
+		return
+	}
+
+	var p request.UpdateShift
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+	p.ID = id
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update shift", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	res, err := h.ju.UpdateShift(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update shift", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update shift", res)
+}
+
+func (h *JadwalDinasHandlerImpl) DeleteShift(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid shift id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to delete shift", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	p := pg.DeleteShiftParams{
+		ID:        id,
+		DeletedBy: utils.StringPtr(value.(string)),
+	}
+
+	if err := h.ju.DeleteShift(ctx, p); err != nil {
+		resp.HandleErrorResponse(c, "failed to delete shift", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success delete shift", gin.H{"id": id})
+}
+
+func (h *JadwalDinasHandlerImpl) CreateJadwalDinas(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateJadwalDinas
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to create jadwal dinas", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create jadwal dinas", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.ju.AssignJadwalDinas(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create jadwal dinas", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create jadwal dinas", result)
+}
+
+func (h *JadwalDinasHandlerImpl) ListJadwalDinas(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchJadwalDinas
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	result, err := h.ju.ListJadwalDinas(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get jadwal dinas list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get jadwal dinas list", result)
+}
+
+// ListJadwalDinasSaya menampilkan jadwal dinas milik mahasiswa yang sedang login.
+func (h *JadwalDinasHandlerImpl) ListJadwalDinasSaya(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchJadwalDinas
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get jadwal dinas list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.UserID = idVal.(string)
+
+	result, err := h.ju.ListJadwalDinas(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get jadwal dinas list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get jadwal dinas list", result)
+}
+
+func (h *JadwalDinasHandlerImpl) DeleteJadwalDinas(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid jadwal dinas id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to delete jadwal dinas", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	p := pg.DeleteJadwalDinasParams{
+		ID:        id,
+		DeletedBy: utils.StringPtr(value.(string)),
+	}
+
+	if err := h.ju.DeleteJadwalDinas(ctx, p); err != nil {
+		resp.HandleErrorResponse(c, "failed to delete jadwal dinas", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success delete jadwal dinas", gin.H{"id": id})
+}
@@ -5,6 +5,8 @@ import (
 	"e-klinik/infra/pg"
 	"e-klinik/pkg"
 	"e-klinik/utils"
+	"errors"
+	"net/http"
 
 	"e-klinik/internal/domain/request"
 	"e-klinik/internal/domain/resp"
@@ -18,6 +20,8 @@ import (
 
 type KehadiranHandler interface {
 	CreateKehadiran(c *gin.Context)
+	CheckoutKehadiran(c *gin.Context)
+	GenerateQrKehadiran(c *gin.Context)
 	ListKehadiran(c *gin.Context)
 	UpdateKehadiran(c *gin.Context)
 	DeleteKehadiran(c *gin.Context)
@@ -25,16 +29,17 @@ type KehadiranHandler interface {
 	GetKehadiranByPembimbingStatus(c *gin.Context)
 	GetKehadiranByMahasiswaStatus(c *gin.Context)
 	ListDistinctUserKehadiran(c *gin.Context)
+	ImportKehadiran(c *gin.Context)
 }
 
 type KehadiranHandlerImpl struct {
-	cfg *config.Config
+	Cfg *config.Config
 	ku  usecase.KehadiranUsecase
 }
 
 func NewKehadiranHandler(ku usecase.KehadiranUsecase, cfg *config.Config) *KehadiranHandlerImpl {
 	return &KehadiranHandlerImpl{
-		cfg: cfg,
+		Cfg: cfg,
 		ku:  ku,
 	}
 }
@@ -43,7 +48,7 @@ func (h *KehadiranHandlerImpl) CreateKehadiran(c *gin.Context) {
 	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
 	defer cancel()
 
-	var p pg.CreateKehadiranParams
+	var p request.CreateKehadiran
 	if err := c.ShouldBindJSON(&p); err != nil {
 		resp.HandleErrorResponse(c, "failed to create kehadiran", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
 		return
@@ -61,13 +66,59 @@ func (h *KehadiranHandlerImpl) CreateKehadiran(c *gin.Context) {
 
 	result, err := h.ku.AddKehadiran(ctx, p)
 	if err != nil {
-		resp.HandleErrorResponse(c, "failed to create kehadiran", pkg.WrapError(err, pkg.ErrorCodeInternal, "database error"))
+		resp.HandleErrorResponse(c, "failed to create kehadiran", err)
 		return
 	}
 
 	resp.HandleSuccessResponse(c, "success create kehadiran", result)
 }
 
+func (h *KehadiranHandlerImpl) CheckoutKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed to checkout kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.ku.CheckoutKehadiran(ctx, uuid.Must(uuid.FromString(idVal.(string))), value.(string))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to checkout kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success checkout kehadiran", result)
+}
+
+// GenerateQrKehadiran menerbitkan token QR absen untuk ruangan pembimbing klinik yang sedang login.
+func (h *KehadiranHandlerImpl) GenerateQrKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	ruanganID, err := uuid.FromString(c.Query("ruangan_id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid ruangan_id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to generate qr kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.ku.GenerateQrKehadiran(ctx, ruanganID, uuid.Must(uuid.FromString(idVal.(string))))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to generate qr kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success generate qr kehadiran", result)
+}
+
 func (h *KehadiranHandlerImpl) ListKehadiran(c *gin.Context) {
 	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
 	defer cancel()
@@ -219,3 +270,41 @@ func (h *KehadiranHandlerImpl) ListDistinctUserKehadiran(c *gin.Context) {
 
 	resp.HandleSuccessResponse(c, "success get user kehadiran", res)
 }
+
+// ImportKehadiran menerima multipart/form-data dengan berkas CSV/XLSX pada field "berkas".
+// Kirim dry_run=true untuk hanya mendapatkan laporan validasi per baris.
+func (h *KehadiranHandlerImpl) ImportKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 60*time.Second)
+	defer cancel()
+
+	var p request.ImportKehadiran
+	if err := c.ShouldBind(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to import kehadiran", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid form payload"))
+		return
+	}
+
+	berkas, err := c.FormFile("berkas")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			resp.HandleErrorResponse(c, "failed to import kehadiran", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "berkas wajib diunggah"))
+			return
+		}
+		resp.HandleErrorResponse(c, "failed to import kehadiran", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid berkas"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to import kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.ku.ImportKehadiran(ctx, p, berkas)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to import kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success import kehadiran", result)
+}
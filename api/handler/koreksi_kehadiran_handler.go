@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"e-klinik/api/middleware"
+	"e-klinik/config"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/internal/usecase"
+
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+type KoreksiKehadiranHandler interface {
+	CreateKoreksiKehadiran(c *gin.Context)
+	ListKoreksiKehadiran(c *gin.Context)
+	ListKoreksiKehadiranPembimbing(c *gin.Context)
+	ReviewKoreksiKehadiran(c *gin.Context)
+	ListRiwayatKehadiran(c *gin.Context)
+}
+
+type KoreksiKehadiranHandlerImpl struct {
+	cfg *config.Config
+	ku  usecase.KoreksiKehadiranUsecase
+}
+
+func NewKoreksiKehadiranHandler(ku usecase.KoreksiKehadiranUsecase, cfg *config.Config) *KoreksiKehadiranHandlerImpl {
+	return &KoreksiKehadiranHandlerImpl{
+		cfg: cfg,
+		ku:  ku,
+	}
+}
+
+func (h *KoreksiKehadiranHandlerImpl) CreateKoreksiKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateKoreksiKehadiran
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed to create koreksi kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+	p.DiajukanOleh = uuid.Must(uuid.FromString(idVal.(string)))
+
+	result, err := h.ku.AjukanKoreksi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create koreksi kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create koreksi kehadiran", result)
+}
+
+func (h *KoreksiKehadiranHandlerImpl) ListKoreksiKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchKoreksiKehadiran
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	// Selain admin/koordinator, hanya koreksi atas kehadiran milik, yang diajukan,
+	// atau yang dibimbing pemanggil yang ditampilkan.
+	if !middleware.PunyaRole(c, h.cfg.Auth.RoleAdmin, h.cfg.Auth.RoleKoordinator) {
+		idVal, ok := c.Get("Id")
+		if !ok {
+			resp.HandleErrorResponse(c, "failed to get koreksi kehadiran list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+			return
+		}
+		id := uuid.FromStringOrNil(idVal.(string))
+		req.PemanggilID = &id
+	}
+
+	result, err := h.ku.ListKoreksi(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get koreksi kehadiran list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get koreksi kehadiran list", result)
+}
+
+// ListKoreksiKehadiranPembimbing menampilkan koreksi yang harus direview pembimbing yang sedang login.
+func (h *KoreksiKehadiranHandlerImpl) ListKoreksiKehadiranPembimbing(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchKoreksiKehadiran
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get koreksi kehadiran list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.ReviewerID = idVal.(string)
+
+	result, err := h.ku.ListKoreksi(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get koreksi kehadiran list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get koreksi kehadiran list", result)
+}
+
+func (h *KoreksiKehadiranHandlerImpl) ReviewKoreksiKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid koreksi kehadiran id"))
+		return
+	}
+
+	var p request.ReviewKoreksiKehadiran
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed to review koreksi kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.ReviewerID = uuid.Must(uuid.FromString(idVal.(string)))
+	p.ReviewedBy = utils.StringPtr(value.(string))
+
+	result, err := h.ku.ReviewKoreksi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to review koreksi kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success review koreksi kehadiran", result)
+}
+
+func (h *KoreksiKehadiranHandlerImpl) ListRiwayatKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get riwayat kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.ku.ListRiwayatKehadiran(ctx, id, uuid.Must(uuid.FromString(idVal.(string))))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get riwayat kehadiran", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get riwayat kehadiran", result)
+}
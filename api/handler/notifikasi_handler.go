@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"e-klinik/config"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/internal/usecase"
+
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+type NotifikasiHandler interface {
+	ListNotifikasi(c *gin.Context)
+	TandaiDibaca(c *gin.Context)
+	TandaiSemuaDibaca(c *gin.Context)
+}
+
+type NotifikasiHandlerImpl struct {
+	cfg *config.Config
+	nu  usecase.NotifikasiUsecase
+}
+
+func NewNotifikasiHandler(nu usecase.NotifikasiUsecase, cfg *config.Config) *NotifikasiHandlerImpl {
+	return &NotifikasiHandlerImpl{
+		cfg: cfg,
+		nu:  nu,
+	}
+}
+
+func (h *NotifikasiHandlerImpl) ListNotifikasi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchNotifikasi
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get notifikasi list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.UserID = uuid.Must(uuid.FromString(idVal.(string)))
+
+	result, err := h.nu.ListNotifikasi(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get notifikasi list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get notifikasi list", result)
+}
+
+func (h *NotifikasiHandlerImpl) TandaiDibaca(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid notifikasi id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to read notifikasi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.nu.TandaiDibaca(ctx, uuid.Must(uuid.FromString(idVal.(string))), &id)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to read notifikasi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success read notifikasi", result)
+}
+
+func (h *NotifikasiHandlerImpl) TandaiSemuaDibaca(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to read notifikasi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.nu.TandaiDibaca(ctx, uuid.Must(uuid.FromString(idVal.(string))), nil)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to read notifikasi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success read notifikasi", result)
+}
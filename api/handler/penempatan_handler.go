@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"e-klinik/api/middleware"
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/internal/usecase"
+
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PenempatanHandler interface {
+	CreatePenempatan(c *gin.Context)
+	ListPenempatan(c *gin.Context)
+	UpdatePenempatan(c *gin.Context)
+	DelPenempatan(c *gin.Context)
+}
+
+type PenempatanHandlerImpl struct {
+	Cfg *config.Config
+	pu  usecase.PenempatanUsecase
+}
+
+func NewPenempatanHandler(pu usecase.PenempatanUsecase, cfg *config.Config) *PenempatanHandlerImpl {
+	return &PenempatanHandlerImpl{
+		Cfg: cfg,
+		pu:  pu,
+	}
+}
+
+func (h *PenempatanHandlerImpl) CreatePenempatan(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreatePenempatan
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create penempatan", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.pu.AddPenempatan(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create penempatan", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create penempatan", result)
+}
+
+func (h *PenempatanHandlerImpl) ListPenempatan(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchPenempatan
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	// Selain admin/koordinator, hanya penempatan milik atau yang dibimbing pemanggil yang ditampilkan.
+	if !middleware.PunyaRole(c, h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator) {
+		idVal, ok := c.Get("Id")
+		if !ok {
+			resp.HandleErrorResponse(c, "failed to get penempatan list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+			return
+		}
+		id := uuid.FromStringOrNil(idVal.(string))
+		req.PemanggilID = &id
+	}
+
+	result, err := h.pu.ListPenempatan(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get penempatan list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get penempatan list", result)
+}
+
+func (h *PenempatanHandlerImpl) UpdatePenempatan(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid penempatan id"))
+		return
+	}
+
+	var p request.UpdatePenempatan
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update penempatan", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.pu.UpdatePenempatan(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update penempatan", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update penempatan", result)
+}
+
+func (h *PenempatanHandlerImpl) DelPenempatan(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid penempatan id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to delete penempatan", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	p := pg.DeletePenempatanParams{
+		ID:        id,
+		DeletedBy: utils.StringPtr(value.(string)),
+	}
+
+	if err := h.pu.DeletePenempatan(ctx, p); err != nil {
+		resp.HandleErrorResponse(c, "failed to delete penempatan", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success delete penempatan", gin.H{"id": id})
+}
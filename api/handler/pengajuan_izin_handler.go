@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"e-klinik/api/middleware"
+	"e-klinik/config"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"net/http"
+
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/internal/usecase"
+
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PengajuanIzinHandler interface {
+	CreatePengajuanIzin(c *gin.Context)
+	ListPengajuanIzin(c *gin.Context)
+	ListPengajuanIzinSaya(c *gin.Context)
+	ListPengajuanIzinPembimbing(c *gin.Context)
+	ReviewPengajuanIzin(c *gin.Context)
+	GetLampiranIzin(c *gin.Context)
+}
+
+type PengajuanIzinHandlerImpl struct {
+	cfg *config.Config
+	iu  usecase.PengajuanIzinUsecase
+}
+
+func NewPengajuanIzinHandler(iu usecase.PengajuanIzinUsecase, cfg *config.Config) *PengajuanIzinHandlerImpl {
+	return &PengajuanIzinHandlerImpl{
+		cfg: cfg,
+		iu:  iu,
+	}
+}
+
+// CreatePengajuanIzin menerima multipart/form-data dengan berkas opsional pada field "lampiran".
+func (h *PengajuanIzinHandlerImpl) CreatePengajuanIzin(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	var p request.CreatePengajuanIzin
+	if err := c.ShouldBind(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to create pengajuan izin", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid form payload"))
+		return
+	}
+
+	berkas, err := c.FormFile("lampiran")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		resp.HandleErrorResponse(c, "failed to create pengajuan izin", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid lampiran"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed to create pengajuan izin", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+	p.UserID = uuid.Must(uuid.FromString(idVal.(string)))
+
+	result, err := h.iu.AjukanIzin(ctx, p, berkas)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create pengajuan izin", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create pengajuan izin", result)
+}
+
+func (h *PengajuanIzinHandlerImpl) ListPengajuanIzin(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchPengajuanIzin
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	// Selain admin/koordinator, filter user_id & reviewer_id hanya berlaku di dalam pengajuan
+	// milik pemanggil atau yang harus direviewnya.
+	if !middleware.PunyaRole(c, h.cfg.Auth.RoleAdmin, h.cfg.Auth.RoleKoordinator) {
+		idVal, ok := c.Get("Id")
+		if !ok {
+			resp.HandleErrorResponse(c, "failed to get pengajuan izin list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+			return
+		}
+		id := uuid.FromStringOrNil(idVal.(string))
+		req.PemanggilID = &id
+	}
+
+	result, err := h.iu.ListPengajuanIzin(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get pengajuan izin list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get pengajuan izin list", result)
+}
+
+// ListPengajuanIzinSaya menampilkan pengajuan milik mahasiswa yang sedang login.
+func (h *PengajuanIzinHandlerImpl) ListPengajuanIzinSaya(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchPengajuanIzin
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get pengajuan izin list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.UserID = idVal.(string)
+	req.ReviewerID = ""
+
+	result, err := h.iu.ListPengajuanIzin(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get pengajuan izin list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get pengajuan izin list", result)
+}
+
+// ListPengajuanIzinPembimbing menampilkan pengajuan yang harus direview pembimbing yang sedang login.
+func (h *PengajuanIzinHandlerImpl) ListPengajuanIzinPembimbing(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchPengajuanIzin
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get pengajuan izin list", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.ReviewerID = idVal.(string)
+
+	result, err := h.iu.ListPengajuanIzin(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get pengajuan izin list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get pengajuan izin list", result)
+}
+
+func (h *PengajuanIzinHandlerImpl) ReviewPengajuanIzin(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid pengajuan izin id"))
+		return
+	}
+
+	var p request.ReviewPengajuanIzin
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed to review pengajuan izin", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.ReviewerID = uuid.Must(uuid.FromString(idVal.(string)))
+	p.ReviewedBy = utils.StringPtr(value.(string))
+
+	result, err := h.iu.ReviewPengajuanIzin(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to review pengajuan izin", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success review pengajuan izin", result)
+}
+
+func (h *PengajuanIzinHandlerImpl) GetLampiranIzin(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid pengajuan izin id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get lampiran izin", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.iu.GetLampiranIzin(ctx, id, uuid.Must(uuid.FromString(idVal.(string))))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get lampiran izin", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get lampiran izin", result)
+}
@@ -1,29 +1,60 @@
 package handler
 
 import (
+	"e-klinik/api/middleware"
 	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
 	"e-klinik/utils"
 
+	"e-klinik/internal/domain/request"
 	"e-klinik/internal/domain/resp"
 	"e-klinik/internal/usecase"
 
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
 )
 
 type SkpHandler interface {
 	ListIntervensi(c *gin.Context)
+	CreateSkpTarget(c *gin.Context)
+	ListSkpTarget(c *gin.Context)
+	UpdateSkpTarget(c *gin.Context)
+	DelSkpTarget(c *gin.Context)
+	ProgresSkp(c *gin.Context)
+	ListKatalogSkp(c *gin.Context)
+	CreateSkpKategori(c *gin.Context)
+	UpdateSkpKategori(c *gin.Context)
+	NonaktifkanSkpKategori(c *gin.Context)
+	CreateSkpSubkategori(c *gin.Context)
+	UpdateSkpSubkategori(c *gin.Context)
+	NonaktifkanSkpSubkategori(c *gin.Context)
+	CreateSkpIntervensi(c *gin.Context)
+	UpdateSkpIntervensi(c *gin.Context)
+	NonaktifkanSkpIntervensi(c *gin.Context)
+	UrutkanSkp(c *gin.Context)
+	EksporKatalogSkp(c *gin.Context)
+	ImportKatalogSkp(c *gin.Context)
+	ListSkpLevel(c *gin.Context)
+	CreateSkpLevel(c *gin.Context)
+	UpdateSkpLevel(c *gin.Context)
+	SetLevelMinimalIntervensi(c *gin.Context)
 }
 
 type SkpHandlerImpl struct {
-	cfg *config.Config
+	Cfg *config.Config
 	su  usecase.SkpUsecase
 }
 
 func NewSkpHandler(su usecase.SkpUsecase, cfg *config.Config) *SkpHandlerImpl {
 	return &SkpHandlerImpl{
-		cfg: cfg,
+		Cfg: cfg,
 		su:  su,
 	}
 }
@@ -40,3 +71,666 @@ func (h *SkpHandlerImpl) ListIntervensi(c *gin.Context) {
 
 	resp.HandleSuccessResponse(c, "success get intervensi", result)
 }
+
+func (h *SkpHandlerImpl) CreateSkpTarget(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if !h.koordinator(c, "failed to create skp target") {
+		return
+	}
+
+	var p request.CreateSkpTarget
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create skp target", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.AddSkpTarget(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create skp target", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create skp target", result)
+}
+
+func (h *SkpHandlerImpl) ListSkpTarget(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchSkpTarget
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	result, err := h.su.ListSkpTarget(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get skp target list", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get skp target list", result)
+}
+
+func (h *SkpHandlerImpl) UpdateSkpTarget(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if !h.koordinator(c, "failed to update skp target") {
+		return
+	}
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp target id"))
+		return
+	}
+
+	var p pg.UpdateSkpTargetParams
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update skp target", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UpdateSkpTarget(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update skp target", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update skp target", result)
+}
+
+func (h *SkpHandlerImpl) DelSkpTarget(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if !h.koordinator(c, "failed to delete skp target") {
+		return
+	}
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp target id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to delete skp target", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	p := pg.DeleteSkpTargetParams{
+		ID:        id,
+		DeletedBy: utils.StringPtr(value.(string)),
+	}
+	if err := h.su.DeleteSkpTarget(ctx, p); err != nil {
+		resp.HandleErrorResponse(c, "failed to delete skp target", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success delete skp target", gin.H{"id": id})
+}
+
+// ProgresSkp mengembalikan progres SKP mahasiswa pada satu mata kuliah.
+// Tanpa user_id, progres yang ditampilkan adalah milik pengguna yang login. Selain
+// admin/koordinator, user_id lain hanya boleh diminta oleh pembimbing mahasiswa tersebut.
+func (h *SkpHandlerImpl) ProgresSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchProgresSkp
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	mataKuliahID, err := uuid.FromString(req.MataKuliahID)
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid mata_kuliah_id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to get progres skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	if req.UserID == "" {
+		req.UserID = idVal.(string)
+	}
+	userID, err := uuid.FromString(req.UserID)
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid user_id"))
+		return
+	}
+
+	var pemanggilID *uuid.UUID
+	if !middleware.PunyaRole(c, h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator) {
+		pemanggil := uuid.FromStringOrNil(idVal.(string))
+		pemanggilID = &pemanggil
+	}
+
+	result, err := h.su.ProgresSkp(ctx, userID, mataKuliahID, pemanggilID)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to get progres skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get progres skp", result)
+}
+
+func (h *SkpHandlerImpl) ListKatalogSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchKatalogSkp
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	result, err := h.su.ListKatalogSkp(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get katalog skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get katalog skp", result)
+}
+
+func (h *SkpHandlerImpl) CreateSkpKategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateSkpKategori
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create skp kategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.AddSkpKategori(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create skp kategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create skp kategori", result)
+}
+
+func (h *SkpHandlerImpl) UpdateSkpKategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp kategori id"))
+		return
+	}
+
+	var p pg.UpdateSkpKategoriParams
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update skp kategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UpdateSkpKategori(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update skp kategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update skp kategori", result)
+}
+
+// NonaktifkanSkpKategori tidak menghapus baris agar riwayat kehadiran_skp tetap utuh.
+func (h *SkpHandlerImpl) NonaktifkanSkpKategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp kategori id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to deactivate skp kategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.su.UpdateSkpKategori(ctx, pg.UpdateSkpKategoriParams{
+		IsActive:  utils.BoolPtr(false),
+		UpdatedBy: utils.StringPtr(value.(string)),
+		ID:        id,
+	})
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to deactivate skp kategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success deactivate skp kategori", result)
+}
+
+func (h *SkpHandlerImpl) CreateSkpSubkategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateSkpSubkategori
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create skp subkategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.AddSkpSubkategori(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create skp subkategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create skp subkategori", result)
+}
+
+func (h *SkpHandlerImpl) UpdateSkpSubkategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp subkategori id"))
+		return
+	}
+
+	var p pg.UpdateSkpSubkategoriParams
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update skp subkategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UpdateSkpSubkategori(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update skp subkategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update skp subkategori", result)
+}
+
+// NonaktifkanSkpSubkategori tidak menghapus baris agar riwayat kehadiran_skp tetap utuh.
+func (h *SkpHandlerImpl) NonaktifkanSkpSubkategori(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp subkategori id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to deactivate skp subkategori", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.su.UpdateSkpSubkategori(ctx, pg.UpdateSkpSubkategoriParams{
+		IsActive:  utils.BoolPtr(false),
+		UpdatedBy: utils.StringPtr(value.(string)),
+		ID:        id,
+	})
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to deactivate skp subkategori", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success deactivate skp subkategori", result)
+}
+
+func (h *SkpHandlerImpl) CreateSkpIntervensi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateSkpIntervensi
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create skp intervensi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.AddSkpIntervensi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create skp intervensi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create skp intervensi", result)
+}
+
+func (h *SkpHandlerImpl) UpdateSkpIntervensi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp intervensi id"))
+		return
+	}
+
+	var p request.UpdateSkpIntervensi
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update skp intervensi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UpdateSkpIntervensi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update skp intervensi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update skp intervensi", result)
+}
+
+// NonaktifkanSkpIntervensi tidak menghapus baris agar riwayat kehadiran_skp tetap utuh.
+func (h *SkpHandlerImpl) NonaktifkanSkpIntervensi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp intervensi id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to deactivate skp intervensi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	result, err := h.su.UpdateSkpIntervensi(ctx, request.UpdateSkpIntervensi{
+		IsActive:  utils.BoolPtr(false),
+		UpdatedBy: utils.StringPtr(value.(string)),
+		ID:        id,
+	})
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to deactivate skp intervensi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success deactivate skp intervensi", result)
+}
+
+// UrutkanSkp menyimpan urutan tampil untuk level kategori, subkategori atau intervensi.
+func (h *SkpHandlerImpl) UrutkanSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.UrutkanSkp
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to reorder skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UrutkanSkp(ctx, c.Param("level"), p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to reorder skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success reorder skp", result)
+}
+
+// EksporKatalogSkp mengunduh katalog SKP aktif; format=json (bawaan) atau format=csv.
+func (h *SkpHandlerImpl) EksporKatalogSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	var req request.EksporKatalogSkp
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	err := h.su.EksporKatalogSkp(ctx, req.Format, func(nama, tipe string) io.Writer {
+		c.Header("Content-Type", tipe)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", nama))
+		c.Status(http.StatusOK)
+		return c.Writer
+	})
+	if err != nil {
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		resp.HandleErrorResponse(c, "failed export katalog skp", err)
+	}
+}
+
+// ImportKatalogSkp menerima multipart/form-data dengan berkas JSON, CSV atau XLSX pada field "berkas".
+// Kirim dry_run=true untuk hanya melihat diff (ditambah, diubah nama, dinonaktifkan) tanpa menyimpan.
+func (h *SkpHandlerImpl) ImportKatalogSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 60*time.Second)
+	defer cancel()
+
+	var p request.ImportKatalogSkp
+	if err := c.ShouldBind(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to import katalog skp", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid form payload"))
+		return
+	}
+
+	berkas, err := c.FormFile("berkas")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			resp.HandleErrorResponse(c, "failed to import katalog skp", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "berkas wajib diunggah"))
+			return
+		}
+		resp.HandleErrorResponse(c, "failed to import katalog skp", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid berkas"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to import katalog skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.ImportKatalogSkp(ctx, p, berkas)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to import katalog skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success import katalog skp", result)
+}
+
+func (h *SkpHandlerImpl) ListSkpLevel(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchKatalogSkp
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	result, err := h.su.ListSkpLevel(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get skp level", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get skp level", result)
+}
+
+func (h *SkpHandlerImpl) CreateSkpLevel(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateSkpLevel
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to create skp level", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.AddSkpLevel(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to create skp level", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create skp level", result)
+}
+
+func (h *SkpHandlerImpl) UpdateSkpLevel(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp level id"))
+		return
+	}
+
+	var p pg.UpdateSkpLevelParams
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update skp level", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.UpdateSkpLevel(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update skp level", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update skp level", result)
+}
+
+func (h *SkpHandlerImpl) SetLevelMinimalIntervensi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid skp intervensi id"))
+		return
+	}
+
+	var p request.SetLevelMinimalSkp
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed to update level minimal", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	result, err := h.su.SetLevelMinimalIntervensi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed to update level minimal", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success update level minimal", result)
+}
+
+// koordinator memastikan pemanggil admin atau koordinator; bila bukan, respons 403 sudah dikirim.
+func (h *SkpHandlerImpl) koordinator(c *gin.Context, msg string) bool {
+	if middleware.PunyaRole(c, h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator) {
+		return true
+	}
+	resp.HandleErrorResponse(c, msg, pkg.ExposeError(pkg.ErrorCodeForbidden, "akses ditolak"))
+	return false
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"e-klinik/api/middleware"
 	"e-klinik/config"
 	"e-klinik/infra/pg"
 	"e-klinik/pkg"
@@ -10,6 +11,8 @@ import (
 	"e-klinik/internal/domain/request"
 	"e-klinik/internal/domain/resp"
 	"e-klinik/internal/usecase"
+	"errors"
+	"net/http"
 
 	"time"
 
@@ -25,6 +28,20 @@ type SkpKehadiranHandler interface {
 	SkpByKehadiranId(c *gin.Context)
 	IntervensiKehadiranId(c *gin.Context)
 	ApproveKehadiranSkp(c *gin.Context)
+	AjukanUlangSkp(c *gin.Context)
+	BukaKunciSkp(c *gin.Context)
+	ListPembukaanSkp(c *gin.Context)
+	InboxApproval(c *gin.Context)
+	BatchKeputusanSkp(c *gin.Context)
+	BuatDelegasi(c *gin.Context)
+	BuatDelegasiKoordinator(c *gin.Context)
+	ListDelegasi(c *gin.Context)
+	CabutDelegasi(c *gin.Context)
+	CabutDelegasiKoordinator(c *gin.Context)
+	SimpanBuktiSkp(c *gin.Context)
+	UnggahLampiranSkp(c *gin.Context)
+	GetLampiranSkp(c *gin.Context)
+	HapusLampiranSkp(c *gin.Context)
 }
 
 type SkpKehadiranHandlerImpl struct {
@@ -173,8 +190,14 @@ func (h *SkpKehadiranHandlerImpl) ApproveKehadiranSkp(c *gin.Context) {
 		return
 	}
 
-	value, _ := c.Get("nama")
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed approve kehadiran", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
 	p.UpdatedBy = utils.StringPtr(value.(string))
+	p.ReviewerID = uuid.Must(uuid.FromString(idVal.(string)))
 
 	res, err := h.sk.ApproveSkpKehadiran(ctx, p)
 	if err != nil {
@@ -184,3 +207,399 @@ func (h *SkpKehadiranHandlerImpl) ApproveKehadiranSkp(c *gin.Context) {
 
 	resp.HandleSuccessResponse(c, "success approve kehadiran", res)
 }
+
+// InboxApproval menampilkan antrean persetujuan milik pembimbing yang sedang login.
+func (h *SkpKehadiranHandlerImpl) InboxApproval(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	var req request.SearchInboxApproval
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed get inbox approval", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.PembimbingID = uuid.Must(uuid.FromString(idVal.(string)))
+
+	result, err := h.sk.InboxApproval(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get inbox approval", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get inbox approval", result)
+}
+
+func (h *SkpKehadiranHandlerImpl) BatchKeputusanSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	var p request.BatchKeputusanSkp
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed batch keputusan skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.UpdatedBy = utils.StringPtr(value.(string))
+	p.ReviewerID = uuid.Must(uuid.FromString(idVal.(string)))
+
+	res, err := h.sk.BatchKeputusanSkp(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed batch keputusan skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success batch keputusan skp", res)
+}
+
+// BuatDelegasi mendelegasikan persetujuan pembimbing klinik yang sedang login ke pembimbing lain.
+func (h *SkpKehadiranHandlerImpl) BuatDelegasi(c *gin.Context) {
+	h.buatDelegasi(c, "")
+}
+
+// BuatDelegasiKoordinator dipakai koordinator untuk mendelegasikan persetujuan pembimbing :id.
+func (h *SkpKehadiranHandlerImpl) BuatDelegasiKoordinator(c *gin.Context) {
+	if !h.koordinator(c, "failed create delegasi") {
+		return
+	}
+	h.buatDelegasi(c, c.Param("id"))
+}
+
+// koordinator memastikan pemanggil admin atau koordinator; bila bukan, respons 403 sudah dikirim.
+func (h *SkpKehadiranHandlerImpl) koordinator(c *gin.Context, msg string) bool {
+	if middleware.PunyaRole(c, h.cfg.Auth.RoleAdmin, h.cfg.Auth.RoleKoordinator) {
+		return true
+	}
+	resp.HandleErrorResponse(c, msg, pkg.ExposeError(pkg.ErrorCodeForbidden, "akses ditolak"))
+	return false
+}
+
+func (h *SkpKehadiranHandlerImpl) buatDelegasi(c *gin.Context, pembimbingID string) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var p request.CreateDelegasiPembimbing
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed create delegasi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	if pembimbingID == "" {
+		pembimbingID = idVal.(string)
+	}
+	id, err := uuid.FromString(pembimbingID)
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+	p.PembimbingID = id
+	p.CreatedBy = utils.StringPtr(value.(string))
+
+	res, err := h.sk.BuatDelegasi(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed create delegasi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create delegasi", res)
+}
+
+// ListDelegasi menampilkan delegasi yang diberikan atau diterima pengguna yang sedang login.
+func (h *SkpKehadiranHandlerImpl) ListDelegasi(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	var req request.SearchDelegasiPembimbing
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed get delegasi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	req.UserID = uuid.Must(uuid.FromString(idVal.(string)))
+
+	result, err := h.sk.ListDelegasi(ctx, req)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get delegasi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get delegasi", result)
+}
+
+// CabutDelegasi mencabut delegasi milik pengguna yang sedang login (pemberi atau penerima).
+func (h *SkpKehadiranHandlerImpl) CabutDelegasi(c *gin.Context) {
+	h.cabutDelegasi(c, false)
+}
+
+// CabutDelegasiKoordinator mencabut delegasi siapa pun.
+func (h *SkpKehadiranHandlerImpl) CabutDelegasiKoordinator(c *gin.Context) {
+	if !h.koordinator(c, "failed cabut delegasi") {
+		return
+	}
+	h.cabutDelegasi(c, true)
+}
+
+func (h *SkpKehadiranHandlerImpl) cabutDelegasi(c *gin.Context, koordinator bool) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed cabut delegasi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	var userID *uuid.UUID
+	if !koordinator {
+		uid := uuid.Must(uuid.FromString(idVal.(string)))
+		userID = &uid
+	}
+
+	res, err := h.sk.CabutDelegasi(ctx, id, userID, utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed cabut delegasi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success cabut delegasi", res)
+}
+
+// AjukanUlangSkp mengajukan kembali tindakan yang dikembalikan pembimbing untuk revisi.
+func (h *SkpKehadiranHandlerImpl) AjukanUlangSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran skp id"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed ajukan ulang skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := h.sk.AjukanUlangSkp(ctx, id, uuid.Must(uuid.FromString(idVal.(string))), utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed ajukan ulang skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success ajukan ulang skp", res)
+}
+
+// BukaKunciSkp membuka kembali SKP kehadiran yang sudah dikunci (admin/koordinator).
+func (h *SkpKehadiranHandlerImpl) BukaKunciSkp(c *gin.Context) {
+	if !h.koordinator(c, "failed buka kunci skp") {
+		return
+	}
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran id"))
+		return
+	}
+
+	var p request.BukaKunciSkp
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed buka kunci skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.KehadiranID = id
+	p.DibukaOleh = uuid.Must(uuid.FromString(idVal.(string)))
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	res, err := h.sk.BukaKunciSkp(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed buka kunci skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success buka kunci skp", res)
+}
+
+// ListPembukaanSkp menampilkan riwayat pembukaan kunci SKP sebuah kehadiran (admin/koordinator).
+func (h *SkpKehadiranHandlerImpl) ListPembukaanSkp(c *gin.Context) {
+	if !h.koordinator(c, "failed get pembukaan skp") {
+		return
+	}
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran id"))
+		return
+	}
+
+	res, err := h.sk.ListPembukaanSkp(ctx, id)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get pembukaan skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get pembukaan skp", res)
+}
+
+// SimpanBuktiSkp menyimpan narasi dan identitas pasien (anonim) untuk satu tindakan SKP.
+func (h *SkpKehadiranHandlerImpl) SimpanBuktiSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran skp id"))
+		return
+	}
+
+	var p request.BuktiKehadiranSkp
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed save bukti skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	p.ID = id
+	p.UserID = uuid.Must(uuid.FromString(idVal.(string)))
+	p.UpdatedBy = utils.StringPtr(value.(string))
+
+	res, err := h.sk.SimpanBuktiSkp(ctx, p)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed save bukti skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success save bukti skp", res)
+}
+
+// UnggahLampiranSkp menerima multipart/form-data dengan berkas pada field "lampiran".
+func (h *SkpKehadiranHandlerImpl) UnggahLampiranSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid kehadiran skp id"))
+		return
+	}
+
+	berkas, err := c.FormFile("lampiran")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			resp.HandleErrorResponse(c, "failed upload lampiran skp", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lampiran wajib diisi"))
+			return
+		}
+		resp.HandleErrorResponse(c, "failed upload lampiran skp", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid lampiran"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed upload lampiran skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := h.sk.UnggahLampiranSkp(ctx, id, uuid.Must(uuid.FromString(idVal.(string))), utils.StringPtr(value.(string)), berkas)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed upload lampiran skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success upload lampiran skp", res)
+}
+
+func (h *SkpKehadiranHandlerImpl) GetLampiranSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid lampiran id"))
+		return
+	}
+
+	idVal, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed get lampiran skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := h.sk.GetLampiranSkp(ctx, id, uuid.Must(uuid.FromString(idVal.(string))))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get lampiran skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get lampiran skp", res)
+}
+
+func (h *SkpKehadiranHandlerImpl) HapusLampiranSkp(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid request", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid lampiran id"))
+		return
+	}
+
+	value, vok := c.Get("nama")
+	idVal, iok := c.Get("Id")
+	if !vok || !iok {
+		resp.HandleErrorResponse(c, "failed delete lampiran skp", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	if err := h.sk.HapusLampiranSkp(ctx, id, uuid.Must(uuid.FromString(idVal.(string))), utils.StringPtr(value.(string))); err != nil {
+		resp.HandleErrorResponse(c, "failed delete lampiran skp", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success delete lampiran skp", id)
+}
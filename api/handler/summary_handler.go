@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"e-klinik/api/middleware"
 	"e-klinik/config"
 	"e-klinik/pkg"
 	"e-klinik/utils"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"e-klinik/internal/domain/request"
@@ -11,6 +15,7 @@ import (
 	"e-klinik/internal/usecase"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
 )
 
 type SummaryHandler interface {
@@ -20,6 +25,8 @@ type SummaryHandler interface {
 	ChartGetHarianSKPPersentase(c *gin.Context)
 	ChartGetHariIniSKPPersentase(c *gin.Context)
 	GetGlobalSKPPersentaseTahunanOtomatis(c *gin.Context)
+	EksporRekapKehadiran(c *gin.Context)
+	LogbookKlinik(c *gin.Context)
 }
 
 type SummaryHandlerImpl struct {
@@ -125,3 +132,69 @@ func (h *SummaryHandlerImpl) GetGlobalSKPPersentaseTahunanOtomatis(c *gin.Contex
 
 	resp.HandleSuccessResponse(c, "success get chart hari ini SKP persentase", res)
 }
+
+// EksporRekapKehadiran mengunduh rekap kehadiran dalam format xlsx atau pdf.
+// Berkas dialirkan langsung ke response, sehingga error setelah penulisan dimulai
+// hanya dapat dicatat, tidak lagi dikirim sebagai JSON.
+func (h *SummaryHandlerImpl) EksporRekapKehadiran(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 2*time.Minute)
+	defer cancel()
+
+	var req request.EksporRekapKehadiran
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp.HandleErrorResponse(c, "invalid query parameters", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid query parameters"))
+		return
+	}
+
+	err := h.su.EksporRekapKehadiran(ctx, req, func(nama, tipe string) io.Writer {
+		c.Header("Content-Type", tipe)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", nama))
+		c.Status(http.StatusOK)
+		return c.Writer
+	})
+	if err != nil {
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		resp.HandleErrorResponse(c, "failed export rekap kehadiran", err)
+	}
+}
+
+// LogbookKlinik mengunduh logbook praktik klinik mahasiswa untuk satu penempatan dalam format pdf.
+func (h *SummaryHandlerImpl) LogbookKlinik(c *gin.Context) {
+	ctx, cancel := utils.ContextWithTimeout(c, 2*time.Minute)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	// Selain admin/koordinator, logbook hanya untuk mahasiswa pemilik dan pembimbingnya.
+	var pemanggilID *uuid.UUID
+	if !middleware.PunyaRole(c, h.cfg.Auth.RoleAdmin, h.cfg.Auth.RoleKoordinator) {
+		idVal, ok := c.Get("Id")
+		if !ok {
+			resp.HandleErrorResponse(c, "failed export logbook klinik", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+			return
+		}
+		pemanggil := uuid.FromStringOrNil(idVal.(string))
+		pemanggilID = &pemanggil
+	}
+
+	err = h.su.LogbookKlinik(ctx, id, pemanggilID, func(nama, tipe string) io.Writer {
+		c.Header("Content-Type", tipe)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", nama))
+		c.Status(http.StatusOK)
+		return c.Writer
+	})
+	if err != nil {
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		resp.HandleErrorResponse(c, "failed export logbook klinik", err)
+	}
+}
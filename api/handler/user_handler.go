@@ -41,6 +41,13 @@ type UserHandler interface {
 	UpdateRolePolicyByRoleId(c *gin.Context)
 	CreateNewUser(c *gin.Context)
 	UserViewPermission(c *gin.Context)
+	BukaKunciUser(c *gin.Context)
+	PaksaLogoutUser(c *gin.Context)
+	NonaktifkanUser(c *gin.Context)
+	ListSesi(c *gin.Context)
+	CabutSesi(c *gin.Context)
+	GantiPassword(c *gin.Context)
+	BuatResetPassword(c *gin.Context)
 }
 
 type UserHandlerImpl struct {
@@ -110,7 +117,7 @@ func (lc *UserHandlerImpl) Logout(c *gin.Context) {
 	}
 
 	userID := value.(string)
-	user, err := lc.Uu.Logout(c, userID)
+	user, err := lc.Uu.Logout(c, userID, c.GetString("session"))
 	if err != nil {
 		resp.HandleErrorResponse(
 			c,
@@ -630,7 +637,7 @@ func (lc *UserHandlerImpl) UpdateUser(c *gin.Context) {
 
 	res, err := lc.Uu.UpdateUserPartial(ctx, p)
 	if err != nil {
-		resp.HandleErrorResponse(c, "failed update", pkg.WrapError(err, pkg.ErrorCodeInternal, "internal server error"))
+		resp.HandleErrorResponse(c, "failed update", err)
 		return
 	}
 	resp.HandleSuccessResponse(c, "success update user", res)
@@ -734,8 +741,7 @@ func (lc *UserHandlerImpl) CreateNewUser(c *gin.Context) {
 
 	res, err := lc.Uu.RegisterWithPassword(ctx, p)
 	if err != nil {
-		// registration failure likely internal or business error; wrap
-		resp.HandleErrorResponse(c, "failed create user", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create user"))
+		resp.HandleErrorResponse(c, "failed create user", err)
 		return
 	}
 	resp.HandleSuccessResponse(c, "success create new user", res)
@@ -762,3 +768,200 @@ func (lc *UserHandlerImpl) DelUser(c *gin.Context) {
 
 	resp.HandleSuccessResponse(c, "success delete fasilitas kesehatan", gin.H{"id": idStr})
 }
+
+// BukaKunciUser membuka akun yang terkunci karena login gagal berulang.
+func (lc *UserHandlerImpl) BukaKunciUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed unlock user", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.BukaKunciUser(ctx, id, utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed unlock user", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success unlock user", res)
+}
+
+// ListSesi menampilkan perangkat yang sedang login dengan akun pengguna.
+func (lc *UserHandlerImpl) ListSesi(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	value, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed get sesi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	userID, err := uuid.FromString(value.(string))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get sesi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.ListSesi(ctx, userID, c.GetString("session"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed get sesi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success get sesi", res)
+}
+
+// CabutSesi mengeluarkan salah satu perangkat milik pengguna.
+func (lc *UserHandlerImpl) CabutSesi(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed revoke sesi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	userID, err := uuid.FromString(value.(string))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed revoke sesi", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.CabutSesi(ctx, id, userID)
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed revoke sesi", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success revoke sesi", res)
+}
+
+// PaksaLogoutUser mencabut semua sesi user sehingga user keluar dari semua perangkat.
+func (lc *UserHandlerImpl) PaksaLogoutUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed force logout user", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.PaksaLogoutUser(ctx, id, utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed force logout user", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success force logout user", res)
+}
+
+// NonaktifkanUser menonaktifkan user sekaligus mencabut semua sesinya.
+func (lc *UserHandlerImpl) NonaktifkanUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed deactivate user", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.NonaktifkanUser(ctx, id, utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed deactivate user", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success deactivate user", res)
+}
+
+// GantiPassword mengganti password pengguna yang sedang login. Semua sesi lama dicabut dan
+// token baru untuk perangkat ini dikembalikan.
+func (lc *UserHandlerImpl) GantiPassword(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var p request.GantiPassword
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.HandleErrorResponse(c, "failed to bind JSON", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid JSON payload"))
+		return
+	}
+
+	value, ok := c.Get("Id")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed change password", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	userID, err := uuid.FromString(value.(string))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed change password", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+	if nama, ok := c.Get("nama"); ok {
+		if v, ok2 := nama.(string); ok2 {
+			p.UpdatedBy = utils.StringPtr(v)
+		}
+	}
+
+	res, err := lc.Uu.GantiPassword(ctx, userID, p, perangkat(c))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed change password", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success change password", res)
+}
+
+// BuatResetPassword menerbitkan token reset password sekali pakai untuk user yang dipilih admin.
+func (lc *UserHandlerImpl) BuatResetPassword(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		resp.HandleErrorResponse(c, "invalid id", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "invalid id"))
+		return
+	}
+
+	value, ok := c.Get("nama")
+	if !ok {
+		resp.HandleErrorResponse(c, "failed create reset password", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "user context not found"))
+		return
+	}
+
+	res, err := lc.Uu.BuatResetPassword(ctx, id, utils.StringPtr(value.(string)))
+	if err != nil {
+		resp.HandleErrorResponse(c, "failed create reset password", err)
+		return
+	}
+
+	resp.HandleSuccessResponse(c, "success create reset password", res)
+}
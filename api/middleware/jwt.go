@@ -8,7 +8,22 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func JwtAuth(secret string) gin.HandlerFunc {
+// ruteGantiPassword tetap dapat diakses dengan password sementara, sehingga user yang wajib
+// mengganti password hanya bisa mengganti password atau logout.
+var ruteGantiPassword = []string{"/users/password", "/users/logout"}
+
+func bolehGantiPassword(path string) bool {
+	for _, r := range ruteGantiPassword {
+		if strings.HasSuffix(path, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// JwtAuth memverifikasi access token dan memastikan sesinya belum dicabut (logout, paksa logout,
+// atau user dinonaktifkan) sehingga pencabutan berlaku tanpa menunggu token kedaluwarsa.
+func JwtAuth(secret string, sesi *pkg.SesiStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -51,10 +66,24 @@ func JwtAuth(secret string) gin.HandlerFunc {
 			return
 		}
 
+		if !sesi.Aktif(c.Request.Context(), user.Session) {
+			resp.HandleErrorResponse(c, "session revoked", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "sesi sudah berakhir"))
+			c.Abort()
+			return
+		}
+
+		if user.GantiPassword && !bolehGantiPassword(c.FullPath()) {
+			resp.HandleErrorResponse(c, "password change required", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "password wajib diganti terlebih dahulu"))
+			c.Abort()
+			return
+		}
+
 		// Set user data ke context untuk digunakan downstream
 		c.Set("username", user.Username)
 		c.Set("Id", user.Subject)
 		c.Set("nama", user.Nama)
+		c.Set("session", user.Session)
+		c.Set("role", user.Role)
 
 		c.Next()
 	}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PunyaRole memeriksa apakah salah satu role pada access token pemanggil (diset JwtAuth)
+// termasuk tags. Tag kosong diabaikan sehingga role yang tidak dikonfigurasi tidak pernah cocok.
+func PunyaRole(c *gin.Context, tags ...string) bool {
+	for _, r := range strings.Split(c.GetString("role"), ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		for _, t := range tags {
+			if t != "" && strings.EqualFold(r, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WajibRole hanya meneruskan request dari pemanggil yang memiliki salah satu role tags.
+func WajibRole(tags ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !PunyaRole(c, tags...) {
+			resp.HandleErrorResponse(c, "akses ditolak", pkg.ExposeError(pkg.ErrorCodeForbidden, "akses ditolak"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
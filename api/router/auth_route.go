@@ -10,4 +10,7 @@ func Auth(group *gin.RouterGroup, h *handler.AuthHandlerImpl) {
 
 	group.POST("/login", h.Login)
 	group.POST("/refresh", h.Refresh)
+	group.GET("/oidc/login", h.OidcLogin)
+	group.GET("/oidc/callback", h.OidcCallback)
+	group.POST("/reset-password", h.ResetPassword)
 }
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"e-klinik/api/handler"
+	"e-klinik/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func JadwalDinas(group *gin.RouterGroup, h *handler.JadwalDinasHandlerImpl) {
+	// Shift dan jadwal menentukan jendela absen, sehingga hanya koordinator yang boleh menyusunnya.
+	koordinator := middleware.WajibRole(h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator)
+
+	//Shift
+	group.GET("/shift", h.ListShift)
+	group.POST("/shift", koordinator, h.CreateShift)
+	group.PUT("/shift/:id", koordinator, h.UpdateShift)
+	group.DELETE("/shift/:id", koordinator, h.DeleteShift)
+
+	//Jadwal Dinas
+	group.POST("", koordinator, h.CreateJadwalDinas)
+	group.GET("", h.ListJadwalDinas)
+	group.GET("/saya", h.ListJadwalDinasSaya)
+	group.DELETE("/:id", koordinator, h.DeleteJadwalDinas)
+}
@@ -2,14 +2,20 @@ package router
 
 import (
 	"e-klinik/api/handler"
+	"e-klinik/api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 func Kehadiran(group *gin.RouterGroup, h *handler.KehadiranHandlerImpl) {
+	// Impor menulis kehadiran mahasiswa lain secara massal, sehingga hanya untuk admin/koordinator.
+	koordinator := middleware.WajibRole(h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator)
 
 	//Kehadiran
 	group.POST("", h.CreateKehadiran)
+	group.POST("/checkout", h.CheckoutKehadiran)
+	group.POST("/import", koordinator, h.ImportKehadiran)
+	group.GET("/qr", h.GenerateQrKehadiran)
 	group.GET("", h.ListKehadiran)
 	group.PUT("/:id", h.UpdateKehadiran)
 	group.DELETE("/:id", h.DeleteKehadiran)
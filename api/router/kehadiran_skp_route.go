@@ -16,5 +16,27 @@ func KehadiranSkp(group *gin.RouterGroup, h *handler.SkpKehadiranHandlerImpl) {
 	group.PUT("", h.UpdateKehadiranSkp)
 	group.DELETE("", h.DeleteKehadiranSkp)
 	group.POST("/approve", h.ApproveKehadiranSkp)
+	group.POST("/:id/ajukan-ulang", h.AjukanUlangSkp)
+
+	//Inbox persetujuan pembimbing
+	group.GET("/inbox", h.InboxApproval)
+	group.POST("/inbox/batch", h.BatchKeputusanSkp)
+
+	//Delegasi persetujuan pembimbing klinik
+	group.GET("/delegasi", h.ListDelegasi)
+	group.POST("/delegasi", h.BuatDelegasi)
+	group.DELETE("/delegasi/:id", h.CabutDelegasi)
+	group.POST("/delegasi/koordinator/:id", h.BuatDelegasiKoordinator)
+	group.DELETE("/delegasi/koordinator/:id", h.CabutDelegasiKoordinator)
+
+	//Buka kunci SKP (admin/koordinator)
+	group.POST("/kehadiran/:id/buka", h.BukaKunciSkp)
+	group.GET("/kehadiran/:id/pembukaan", h.ListPembukaanSkp)
+
+	//Bukti SKP
+	group.PUT("/:id/bukti", h.SimpanBuktiSkp)
+	group.POST("/:id/lampiran", h.UnggahLampiranSkp)
+	group.GET("/lampiran/:id", h.GetLampiranSkp)
+	group.DELETE("/lampiran/:id", h.HapusLampiranSkp)
 
 }
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"e-klinik/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func KoreksiKehadiran(group *gin.RouterGroup, h *handler.KoreksiKehadiranHandlerImpl) {
+
+	//Koreksi Kehadiran
+	group.POST("", h.CreateKoreksiKehadiran)
+	group.GET("", h.ListKoreksiKehadiran)
+	group.GET("/pembimbing", h.ListKoreksiKehadiranPembimbing)
+	group.PUT("/:id/review", h.ReviewKoreksiKehadiran)
+
+	//Riwayat Kehadiran
+	group.GET("/kehadiran/:id/riwayat", h.ListRiwayatKehadiran)
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"e-klinik/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Notifikasi(group *gin.RouterGroup, h *handler.NotifikasiHandlerImpl) {
+
+	//Notifikasi
+	group.GET("", h.ListNotifikasi)
+	group.PUT("/dibaca", h.TandaiSemuaDibaca)
+	group.PUT("/:id/dibaca", h.TandaiDibaca)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"e-klinik/api/handler"
+	"e-klinik/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Penempatan(group *gin.RouterGroup, h *handler.PenempatanHandlerImpl) {
+	// Penempatan menjadi sumber fasilitas, kontrak, ruangan, dan pembimbing saat absen,
+	// sehingga hanya admin/koordinator yang boleh mengubahnya.
+	koordinator := middleware.WajibRole(h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator)
+
+	//Penempatan
+	group.POST("", koordinator, h.CreatePenempatan)
+	group.GET("", h.ListPenempatan)
+	group.PUT("/:id", koordinator, h.UpdatePenempatan)
+	group.DELETE("/:id", koordinator, h.DelPenempatan)
+}
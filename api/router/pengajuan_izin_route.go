@@ -0,0 +1,18 @@
+package router
+
+import (
+	"e-klinik/api/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PengajuanIzin(group *gin.RouterGroup, h *handler.PengajuanIzinHandlerImpl) {
+
+	//Pengajuan Izin
+	group.POST("", h.CreatePengajuanIzin)
+	group.GET("", h.ListPengajuanIzin)
+	group.GET("/saya", h.ListPengajuanIzinSaya)
+	group.GET("/pembimbing", h.ListPengajuanIzinPembimbing)
+	group.PUT("/:id/review", h.ReviewPengajuanIzin)
+	group.GET("/:id/lampiran", h.GetLampiranIzin)
+}
@@ -2,13 +2,46 @@ package router
 
 import (
 	"e-klinik/api/handler"
+	"e-klinik/api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 func Skp(group *gin.RouterGroup, h *handler.SkpHandlerImpl) {
+	// Perubahan katalog memengaruhi SKP seluruh mahasiswa, sehingga hanya untuk admin/koordinator.
+	koordinator := middleware.WajibRole(h.Cfg.Auth.RoleAdmin, h.Cfg.Auth.RoleKoordinator)
+	// Impor menulis ulang seluruh hierarki katalog, sehingga hanya untuk admin.
+	admin := middleware.WajibRole(h.Cfg.Auth.RoleAdmin)
 
 	//SKP
 	group.GET("/intervensi", h.ListIntervensi)
 
+	//Katalog SKP (admin)
+	group.GET("/katalog", h.ListKatalogSkp)
+	group.GET("/katalog/ekspor", h.EksporKatalogSkp)
+	group.POST("/katalog/impor", admin, h.ImportKatalogSkp)
+	group.POST("/kategori", koordinator, h.CreateSkpKategori)
+	group.PUT("/kategori/:id", koordinator, h.UpdateSkpKategori)
+	group.DELETE("/kategori/:id", koordinator, h.NonaktifkanSkpKategori)
+	group.POST("/subkategori", koordinator, h.CreateSkpSubkategori)
+	group.PUT("/subkategori/:id", koordinator, h.UpdateSkpSubkategori)
+	group.DELETE("/subkategori/:id", koordinator, h.NonaktifkanSkpSubkategori)
+	group.POST("/intervensi", koordinator, h.CreateSkpIntervensi)
+	group.PUT("/intervensi/:id", koordinator, h.UpdateSkpIntervensi)
+	group.DELETE("/intervensi/:id", koordinator, h.NonaktifkanSkpIntervensi)
+	group.PUT("/urutan/:level", koordinator, h.UrutkanSkp)
+	group.PUT("/intervensi/:id/level-minimal", koordinator, h.SetLevelMinimalIntervensi)
+
+	//Skala tingkat kompetensi
+	group.GET("/level", h.ListSkpLevel)
+	group.POST("/level", koordinator, h.CreateSkpLevel)
+	group.PUT("/level/:id", koordinator, h.UpdateSkpLevel)
+
+	//Target & Progres SKP
+	group.POST("/target", h.CreateSkpTarget)
+	group.GET("/target", h.ListSkpTarget)
+	group.PUT("/target/:id", h.UpdateSkpTarget)
+	group.DELETE("/target/:id", h.DelSkpTarget)
+	group.GET("/progres", h.ProgresSkp)
+
 }
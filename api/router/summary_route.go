@@ -17,4 +17,8 @@ func Summary(group *gin.RouterGroup, h *handler.SummaryHandlerImpl) {
 	group.GET("/chart/skp/hariini", h.ChartGetHariIniSKPPersentase)
 	group.GET("/block/skp/date", h.RekapSkpTercapaiMahasiswaByDate)
 
+	//Ekspor
+	group.GET("/ekspor/kehadiran", h.EksporRekapKehadiran)
+	group.GET("/ekspor/logbook/:id", h.LogbookKlinik)
+
 }
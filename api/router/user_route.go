@@ -2,32 +2,43 @@ package router
 
 import (
 	"e-klinik/api/handler"
+	"e-klinik/api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 func User(group *gin.RouterGroup, h *handler.UserHandlerImpl) {
+	// Pengelolaan user, role, dan group hanya untuk admin; tanpa ini user biasa dapat
+	// memberi dirinya role admin dan melewati semua pemeriksaan role.
+	admin := middleware.WajibRole(h.Cfg.Auth.RoleAdmin)
 
-	group.POST("/register", h.Register)
+	group.POST("/register", admin, h.Register)
 	group.DELETE("/logout", h.Logout)
+	group.GET("/sesi", h.ListSesi)
+	group.DELETE("/sesi/:id", h.CabutSesi)
+	group.PUT("/password", h.GantiPassword)
 
-	group.POST("/roles", h.CreateRole)
+	group.POST("/roles", admin, h.CreateRole)
 	group.GET("/roles", h.ListRole)
 	group.GET("/roles/:id", h.RoleById)
-	group.PUT("/roles/:id", h.UpdateRole)
-	group.DELETE("/roles/:id", h.DelRole)
-	group.PUT("/roles/policies/:id", h.UpdateRolePolicyByRoleId)
-	group.POST("/group", h.CreateGroup)
+	group.PUT("/roles/:id", admin, h.UpdateRole)
+	group.DELETE("/roles/:id", admin, h.DelRole)
+	group.PUT("/roles/policies/:id", admin, h.UpdateRolePolicyByRoleId)
+	group.POST("/group", admin, h.CreateGroup)
 	group.GET("/group", h.ListGroup)
 	group.GET("/group/:id", h.GroupById)
-	group.PUT("/group/:id", h.UpdateGroup)
-	group.DELETE("/group/:id", h.DelGroup)
+	group.PUT("/group/:id", admin, h.UpdateGroup)
+	group.DELETE("/group/:id", admin, h.DelGroup)
 	group.GET("", h.ListUsers)
-	group.DELETE("/:id", h.DelUser)
+	group.DELETE("/:id", admin, h.DelUser)
 	group.GET("/:id", h.UserById)
-	group.PUT("/:id", h.UpdateUser)
-	group.POST("", h.CreateNewUser)
-	group.POST("/user-roles", h.AddRoleUser)
+	group.PUT("/:id", admin, h.UpdateUser)
+	group.PUT("/:id/buka-kunci", admin, h.BukaKunciUser)
+	group.PUT("/:id/paksa-logout", admin, h.PaksaLogoutUser)
+	group.PUT("/:id/nonaktifkan", admin, h.NonaktifkanUser)
+	group.POST("/:id/reset-password", admin, h.BuatResetPassword)
+	group.POST("", admin, h.CreateNewUser)
+	group.POST("/user-roles", admin, h.AddRoleUser)
 	group.GET("/user-roles/:id", h.UserRoleByUserId)
 
 }
@@ -7,6 +7,7 @@ import (
 	"e-klinik/pkg/logging"
 	"encoding/gob"
 	"log"
+	"os"
 )
 
 func main() {
@@ -14,6 +15,12 @@ func main() {
 	cfg := config.NewConfig()
 	logger := logging.NewLogger(cfg)
 	pg := NewPostgre(cfg)
+
+	// Perintah satu kali: go run ./cmd alpa -dari YYYY-MM-DD -sampai YYYY-MM-DD
+	if len(os.Args) > 1 && os.Args[1] == "alpa" {
+		rest.GenerateAlpa(cfg, pg, logger, os.Args[2:])
+		return
+	}
 	gob.Register([]interface{}{})          // If any slice of interface is used
 	gob.Register(map[string]interface{}{}) // If any m
 
@@ -26,7 +33,7 @@ func main() {
 	// failOnError(err, "rabbit failed")
 	// err  = rmq.QueueDeclare()
 	// failOnError(err, "rabbit failed")
-	go rest.HttpServer(cfg, rmq, pg)
+	go rest.HttpServer(cfg, rmq, pg, logger)
 	go rest.RabbitConsumer(rmq, cfg, pg, logger)
 	select {}
 }
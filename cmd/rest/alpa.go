@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"context"
+	"e-klinik/config"
+	"e-klinik/infra/worker"
+	"e-klinik/pkg"
+	"e-klinik/pkg/logging"
+	"e-klinik/utils"
+	"flag"
+	"log"
+	"time"
+)
+
+// GenerateAlpa menjalankan ulang job alpa untuk rentang tanggal tertentu, contoh:
+//
+//	go run ./cmd alpa -dari 2025-01-01 -sampai 2025-01-31
+func GenerateAlpa(cfg *config.Config, pg *pkg.Postgres, logger logging.Logger, args []string) {
+	kemarin, err := utils.GetJakartaDateObject()
+	if err != nil {
+		log.Fatalf("Failed to get jakarta time: %v", err)
+	}
+	kemarin = kemarin.AddDate(0, 0, -1)
+
+	fs := flag.NewFlagSet("alpa", flag.ExitOnError)
+	dari := fs.String("dari", kemarin.Format("2006-01-02"), "tanggal awal (YYYY-MM-DD)")
+	sampai := fs.String("sampai", "", "tanggal akhir (YYYY-MM-DD), default sama dengan -dari")
+	_ = fs.Parse(args)
+
+	tglAwal, err := time.Parse("2006-01-02", *dari)
+	if err != nil {
+		log.Fatalf("Invalid -dari: %v", err)
+	}
+	tglAkhir := tglAwal
+	if *sampai != "" {
+		if tglAkhir, err = time.Parse("2006-01-02", *sampai); err != nil {
+			log.Fatalf("Invalid -sampai: %v", err)
+		}
+	}
+	if tglAkhir.Before(tglAwal) {
+		log.Fatalf("-sampai tidak boleh sebelum -dari")
+	}
+
+	n, err := worker.NewAlpaJob(pg, cfg, logger).Run(context.Background(), tglAwal, tglAkhir)
+	if err != nil {
+		log.Fatalf("Failed to generate alpa: %v", err)
+	}
+	log.Printf("✅ %d record alpa dibuat untuk %s s/d %s", n, tglAwal.Format("2006-01-02"), tglAkhir.Format("2006-01-02"))
+}
@@ -34,7 +34,11 @@ const (
 	_defaultShutdownTimeout = 3 * time.Second
 )
 
-func HttpServer(cfg *config.Config, rmq *pkg.RabbitMQ, pg *pkg.Postgres) {
+func HttpServer(cfg *config.Config, rmq *pkg.RabbitMQ, pg *pkg.Postgres, logger logging.Logger) {
+	if err := cfg.JWT.ValidasiSecretQr(); err != nil {
+		log.Fatalf("Invalid JWT config: %v", err)
+	}
+
 	// Publisher channel
 	//  _ = rmq.SetupExchange(pubCh)
 
@@ -88,8 +92,15 @@ func HttpServer(cfg *config.Config, rmq *pkg.RabbitMQ, pg *pkg.Postgres) {
 	if err != nil {
 		log.Print("Failed to load data:", err)
 	}
+	//Initialize object storage
+	// Tanpa object storage server tetap jalan; fitur lampiran menolak dengan pesan yang jelas.
+	s3, err := pkg.NewS3Storage(cfg, logger)
+	if err != nil {
+		log.Printf("Failed to create s3 client, lampiran dinonaktifkan: %v", err)
+	}
+
 	//Dependency Injection
-	init := di.Injector(cfg, pubCh, pg, rdb, casbin)
+	init := di.Injector(cfg, pubCh, pg, rdb, casbin, s3)
 	server := &http.Server{
 		Addr:         _defaultAddr,
 		Handler:      init.Router,
@@ -168,6 +179,10 @@ func RabbitConsumer(rmq *pkg.RabbitMQ, cfg *config.Config, pg *pkg.Postgres, log
 	// Setup context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+
+	// Job terjadwal pembuatan record alpa
+	go worker.NewAlpaJob(pg, cfg, logger).Start(ctx)
+
 	server := &worker.ConsumerService{
 		Logger: logger, // assume your custom logger
 		RMQ:    rmq,
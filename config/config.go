@@ -25,6 +25,10 @@ type Config struct {
 	Imagor    ImagorConfig
 	RabbitMq  RabbitMQConfig
 	TypeSense TypeSenseConfig
+	Geofence  GeofenceConfig
+	Kehadiran KehadiranConfig
+	Ekspor    EksporConfig
+	Auth      AuthConfig
 }
 
 type ServerConfig struct {
@@ -38,6 +42,19 @@ type JWTConfig struct {
 	RefreshTokenExpireHour int    `env:"JWT_REFRESH_TOKEN_EXPIRY_HOUR" env-default:"168"`
 	AccessTokenSecret      string `env:"JWT_ACCESS_TOKEN_SECRET"`
 	RefreshTokenSecret     string `env:"JWT_REFRESH_TOKEN_SECRET"`
+	QrKehadiranSecret      string `env:"JWT_QR_KEHADIRAN_SECRET"`
+}
+
+// ValidasiSecretQr memastikan token QR absen ditandatangani secret tersendiri. Token QR dibagikan
+// ke mahasiswa, sehingga secret yang sama dengan access/refresh token tidak boleh dipakai.
+func (j JWTConfig) ValidasiSecretQr() error {
+	switch j.QrKehadiranSecret {
+	case "":
+		return fmt.Errorf("JWT_QR_KEHADIRAN_SECRET wajib diisi")
+	case j.AccessTokenSecret, j.RefreshTokenSecret:
+		return fmt.Errorf("JWT_QR_KEHADIRAN_SECRET harus berbeda dari secret access/refresh token")
+	}
+	return nil
 }
 
 type LoggerConfig struct {
@@ -65,13 +82,18 @@ type ImagorConfig struct {
 	Port string `env:"IMAGOR_PORT"`
 }
 
+// PasswordConfig adalah kebijakan password pengguna. IncludeChars mewajibkan karakter simbol,
+// Riwayat adalah jumlah password terakhir yang tidak boleh dipakai ulang, dan ResetTokenMenit
+// adalah masa berlaku token reset password yang diterbitkan admin.
 type PasswordConfig struct {
-	IncludeChars     bool
-	IncludeDigits    bool
-	MinLength        int
-	MaxLength        int
-	IncludeUppercase bool
-	IncludeLowercase bool
+	IncludeChars     bool `env:"PASSWORD_INCLUDE_CHARS" env-default:"false"`
+	IncludeDigits    bool `env:"PASSWORD_INCLUDE_DIGITS" env-default:"true"`
+	MinLength        int  `env:"PASSWORD_MIN_LENGTH" env-default:"8"`
+	MaxLength        int  `env:"PASSWORD_MAX_LENGTH" env-default:"128"`
+	IncludeUppercase bool `env:"PASSWORD_INCLUDE_UPPERCASE" env-default:"true"`
+	IncludeLowercase bool `env:"PASSWORD_INCLUDE_LOWERCASE" env-default:"true"`
+	Riwayat          int  `env:"PASSWORD_RIWAYAT" env-default:"3"`
+	ResetTokenMenit  int  `env:"PASSWORD_RESET_TOKEN_MENIT" env-default:"60"`
 }
 
 type PostgreConfig struct {
@@ -104,11 +126,18 @@ type OtpConfig struct {
 	Limiter    time.Duration
 }
 
+// OIDC mengatur login SSO lewat identity provider kampus. Bila AutoProvision aktif, identitas
+// yang belum tertaut ke akun mana pun dibuatkan pengguna baru dengan role DefaultRole (id role).
+// Identitas baru hanya ditautkan lewat email terverifikasi atau klaim stabil KlaimTautan (mis.
+// "nim") yang nilainya sama dengan username.
 type OIDC struct {
-	ClientId     string `env:"OIDC_CLIENT_ID"`
-	ClientSecret string `env:"OIDC_CLIENT_SECRET"`
-	RedirectUrl  string `env:"OIDC_REDIRECT_URL"`
-	IssuerUrl    string `env:"OIDC_ISSUER_URL"`
+	ClientId      string `env:"OIDC_CLIENT_ID"`
+	ClientSecret  string `env:"OIDC_CLIENT_SECRET"`
+	RedirectUrl   string `env:"OIDC_REDIRECT_URL"`
+	IssuerUrl     string `env:"OIDC_ISSUER_URL"`
+	AutoProvision bool   `env:"OIDC_AUTO_PROVISION" env-default:"false"`
+	DefaultRole   string `env:"OIDC_DEFAULT_ROLE"`
+	KlaimTautan   string `env:"OIDC_LINK_CLAIM"`
 }
 
 type RabbitMQConfig struct {
@@ -125,6 +154,53 @@ type TypeSenseConfig struct {
 	MeiliImageHost string `env:"TYPSENSE_IMAGE_HOST"`
 }
 
+// GeofenceConfig mengatur radius absen. Absen di fasilitas yang belum memiliki koordinat
+// ditolak, kecuali IzinkanTanpaKoordinat diaktifkan; absen tersebut lalu ditandai untuk ditinjau.
+type GeofenceConfig struct {
+	RadiusMeter           float64 `env:"GEOFENCE_RADIUS_METER" env-default:"150"`
+	MaxAkurasiMeter       float64 `env:"GEOFENCE_MAX_ACCURACY_METER" env-default:"100"`
+	IzinkanTanpaKoordinat bool    `env:"GEOFENCE_ALLOW_MISSING_COORDINATES" env-default:"false"`
+}
+
+// KehadiranConfig mengatur aturan absen. Durasi kehadiran dihitung paling lama sampai akhir
+// shift ditambah BatasPulangLambatMenit, atau MaksDurasiJam bila absen tidak terjadwal.
+type KehadiranConfig struct {
+	ToleransiPulangAwalMenit int  `env:"KEHADIRAN_TOLERANSI_PULANG_AWAL_MENIT" env-default:"0"`
+	BatasPulangLambatMenit   int  `env:"KEHADIRAN_BATAS_PULANG_LAMBAT_MENIT" env-default:"120"`
+	MaksDurasiJam            int  `env:"KEHADIRAN_MAKS_DURASI_JAM" env-default:"12"`
+	BatasAwalMasukMenit      int  `env:"KEHADIRAN_BATAS_AWAL_MASUK_MENIT" env-default:"60"`
+	WajibJadwal              bool `env:"KEHADIRAN_WAJIB_JADWAL" env-default:"false"`
+	IntervalAlpaMenit        int  `env:"KEHADIRAN_ALPA_INTERVAL_MENIT" env-default:"30"`
+	ToleransiAlpaMenit       int  `env:"KEHADIRAN_ALPA_TOLERANSI_MENIT" env-default:"60"`
+	WajibQr                  bool `env:"KEHADIRAN_WAJIB_QR" env-default:"true"`
+	QrTtlDetik               int  `env:"KEHADIRAN_QR_TTL_DETIK" env-default:"45"`
+	SlaApprovalJam           int  `env:"KEHADIRAN_SLA_APPROVAL_JAM" env-default:"48"`
+}
+
+// EksporConfig berisi identitas yang dicetak pada kop dan blok tanda tangan berkas ekspor.
+type EksporConfig struct {
+	Institusi          string `env:"EKSPOR_INSTITUSI" env-default:"Fakultas Ilmu Kesehatan"`
+	Kota               string `env:"EKSPOR_KOTA"`
+	JabatanKoordinator string `env:"EKSPOR_JABATAN_KOORDINATOR" env-default:"Koordinator Praktik Klinik"`
+	NamaKoordinator    string `env:"EKSPOR_NAMA_KOORDINATOR"`
+	NipKoordinator     string `env:"EKSPOR_NIP_KOORDINATOR"`
+}
+
+// AuthConfig mengatur penguncian akun setelah login gagal berulang. Setelah MaksGagalLogin
+// kegagalan dalam JendelaGagalMenit, akun dikunci KunciAwalMenit dan lamanya berlipat dua
+// untuk setiap kegagalan berikutnya, paling lama KunciMaksMenit. CacheSesiDetik adalah lama
+// middleware mempercayai salinan lokal status sesi sebelum memeriksa Redis lagi (0 = selalu Redis).
+// RoleAdmin dan RoleKoordinator adalah tag r4_roles yang membuka endpoint admin dan koordinator.
+type AuthConfig struct {
+	MaksGagalLogin    int    `env:"AUTH_MAKS_GAGAL_LOGIN" env-default:"5"`
+	JendelaGagalMenit int    `env:"AUTH_JENDELA_GAGAL_MENIT" env-default:"15"`
+	KunciAwalMenit    int    `env:"AUTH_KUNCI_AWAL_MENIT" env-default:"5"`
+	KunciMaksMenit    int    `env:"AUTH_KUNCI_MAKS_MENIT" env-default:"1440"`
+	CacheSesiDetik    int    `env:"AUTH_CACHE_SESI_DETIK" env-default:"5"`
+	RoleAdmin         string `env:"AUTH_ROLE_ADMIN" env-default:"admin"`
+	RoleKoordinator   string `env:"AUTH_ROLE_KOORDINATOR" env-default:"koordinator"`
+}
+
 func NewConfig() *Config {
 	cfg := &Config{}
 	cwd := projectRoot()
@@ -4,6 +4,10 @@ SELECT
     u.username,
     u.password,
     u.nama,
+    u.locked_until,
+    u.failed_attempts,
+    u.is_active,
+    u.wajib_ganti_password,
     COALESCE(string_agg(DISTINCT ur.tag, ', '), '')::text AS role
     
 FROM users u
@@ -11,7 +15,7 @@ LEFT JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
 LEFT JOIN r4_roles ur ON ur.id = uur.role_id AND ur.deleted_at IS NULL
 WHERE u.username = $1
   AND u.deleted_at IS NULL
-GROUP BY u.id, u.username, u.password, u.nama;
+GROUP BY u.id, u.username, u.password, u.nama, u.locked_until, u.failed_attempts, u.is_active, u.wajib_ganti_password;
 
 -- name: UsersFindById :one
 SELECT u.id,
@@ -19,6 +23,7 @@ SELECT u.id,
        u.password,
 	     u.nama,
        u.refresh,
+       u.wajib_ganti_password,
        COALESCE(string_agg(DISTINCT ur.tag, ', '), '')::text AS role
        FROM users u
 LEFT JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
@@ -94,17 +99,18 @@ WHERE
   );
 
 
--- name: CreateOrUpdateUser :one
+-- name: CreateUser :one
 INSERT INTO users (
   nama,
   username,
-  password
+  password,
+  created_by,
+  wajib_ganti_password,
+  password_changed_at
 ) VALUES (
-  @nama,@username,@password
-) ON CONFLICT (username) DO UPDATE SET 
-nama = @nama,
-password = @password
-RETURNING *, CASE WHEN xmax = 0 THEN 'inserted' ELSE 'updated' END as operation;
+  @nama, @username, @password, sqlc.narg('created_by'), true, now()
+)
+RETURNING *;
 
 -- name: GetUserByID :one
 SELECT * FROM users
@@ -115,8 +121,8 @@ UPDATE users
 SET 
     nama        = COALESCE(sqlc.narg('nama'), nama),
     is_active   = COALESCE(sqlc.narg('is_active'), is_active),
-    password    = COALESCE(sqlc.narg('password'), password),
     refresh     = COALESCE(sqlc.narg('refresh'), refresh),
+    email       = COALESCE(sqlc.narg('email'), email),
     updated_note= COALESCE(sqlc.narg('updated_note'), updated_note),
     updated_by  = COALESCE(sqlc.narg('updated_by'), updated_by),
     updated_at  = now()
@@ -211,4 +217,55 @@ WHERE
   AND k.pembimbing_klinik = COALESCE(sqlc.narg('pembimbing_klinik')::uuid, k.pembimbing_klinik)
   AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date;
 
+-- name: GetUsersByUsernames :many
+SELECT id, username, nama, is_active
+FROM users
+WHERE username = ANY(sqlc.arg('usernames')::text[])
+  AND deleted_at IS NULL;
+
+-- name: CatatGagalLogin :one
+UPDATE users
+SET failed_attempts = CASE
+      WHEN last_failed_at IS NULL
+        OR GREATEST(last_failed_at, COALESCE(locked_until, last_failed_at)) < now() - make_interval(mins => sqlc.arg('jendela_menit')::int)
+      THEN 1
+      ELSE COALESCE(failed_attempts, 0) + 1
+    END,
+    last_failed_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING failed_attempts;
+
+-- name: KunciUser :exec
+UPDATE users
+SET locked_until = sqlc.arg('locked_until')
+WHERE id = sqlc.arg('id');
 
+-- name: ResetGagalLogin :execrows
+UPDATE users
+SET failed_attempts = 0,
+    last_failed_at  = NULL,
+    locked_until    = NULL,
+    updated_by      = COALESCE(sqlc.narg('updated_by'), updated_by),
+    updated_at      = CASE WHEN sqlc.narg('updated_by')::varchar IS NULL THEN updated_at ELSE now() END
+WHERE id = sqlc.arg('id')
+  AND deleted_at IS NULL;
+
+-- name: AdminAktifTerakhir :one
+SELECT (
+  EXISTS (
+    SELECT 1 FROM r5_user_roles uur
+    JOIN r4_roles r ON r.id = uur.role_id AND r.deleted_at IS NULL
+    WHERE uur.user_id = sqlc.arg('id')
+      AND uur.deleted_at IS NULL
+      AND lower(r.tag) = lower(sqlc.arg('tag'))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
+    JOIN r4_roles r ON r.id = uur.role_id AND r.deleted_at IS NULL
+    WHERE lower(r.tag) = lower(sqlc.arg('tag'))
+      AND u.id <> sqlc.arg('id')
+      AND u.is_active
+      AND u.deleted_at IS NULL
+  )
+)::bool AS terakhir;
@@ -15,6 +15,12 @@ SELECT * FROM fasilitas_kesehatan
 WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1;
 
+-- name: GetKoordinatFasilitas :one
+SELECT id, latitude, longitude
+FROM fasilitas_kesehatan
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
 -- name: ListFasilitasKesehatan :many
 SELECT
   id,
@@ -1,10 +1,14 @@
 -- name: CreateKehadiran :one
 INSERT INTO kehadiran (
   fasilitas_id, kontrak_id, ruangan_id, pembimbing_id,user_id,pembimbing_klinik,mata_kuliah_id,
-  jadwal_dinas, created_by, tgl_kehadiran, presensi
+  jadwal_dinas, created_by, tgl_kehadiran, presensi,
+  latitude, longitude, akurasi, jarak_meter, status_lokasi,
+  jadwal_id, penempatan_id
 ) VALUES (
   $1, $2, $3, $4,
-  $5,$6, $7,$8,$9,$10,$11
+  $5,$6, $7,$8,$9,$10,$11,
+  $12, $13, $14, $15, $16,
+  $17, $18
 )
 ON CONFLICT (user_id, tgl_kehadiran) DO NOTHING
 RETURNING *;
@@ -72,6 +76,29 @@ UPDATE kehadiran
 SET deleted_at = now(), deleted_by = $2
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: GetKehadiranBelumPulang :one
+SELECT * FROM kehadiran
+WHERE user_id = sqlc.arg('user_id')
+  AND presensi = 'hadir'
+  AND jam_pulang IS NULL
+  AND deleted_at IS NULL
+  AND tgl_kehadiran >= sqlc.arg('tgl_awal')
+ORDER BY tgl_kehadiran DESC
+LIMIT 1;
+
+-- name: CheckoutKehadiran :one
+UPDATE kehadiran
+SET
+  jam_pulang   = sqlc.arg('jam_pulang'),
+  durasi_menit = sqlc.arg('durasi_menit'),
+  pulang_awal  = sqlc.arg('pulang_awal'),
+  updated_by   = sqlc.narg('updated_by'),
+  updated_at   = now()
+WHERE id = sqlc.arg('id')
+  AND jam_pulang IS NULL
+  AND deleted_at IS NULL
+RETURNING *;
+
 -- name: CheckKehadiran :one
 SELECT id,created_at,presensi
 FROM kehadiran
@@ -87,7 +114,10 @@ SELECT
     COUNT(*) FILTER (WHERE presensi = 'hadir') AS total_hadir,
     COUNT(*) FILTER (WHERE presensi = 'izin') AS total_izin,
     COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit,
-    COUNT(*) AS total_semua
+    COUNT(*) AS total_semua,
+    COALESCE(SUM(durasi_menit), 0)::bigint AS total_menit_dinas,
+    ROUND(COALESCE(SUM(durasi_menit), 0)::numeric / 60, 2) AS total_jam_dinas,
+    COUNT(*) FILTER (WHERE pulang_awal) AS total_pulang_awal
 FROM kehadiran
 WHERE is_active = true
   AND user_id = sqlc.arg('user_id')
@@ -100,7 +130,9 @@ SELECT
     tgl_kehadiran,
     COUNT(*) FILTER (WHERE presensi = 'hadir') AS total_hadir,
     COUNT(*) FILTER (WHERE presensi = 'izin') AS total_izin,
-    COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit
+    COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit,
+    COALESCE(SUM(durasi_menit), 0)::bigint AS total_menit_dinas,
+    ROUND(COALESCE(SUM(durasi_menit), 0)::numeric / 60, 2) AS total_jam_dinas
 FROM kehadiran
 WHERE is_active = true
   AND user_id = sqlc.arg('user_id')
@@ -304,3 +336,194 @@ WHERE k.tgl_kehadiran BETWEEN (CURRENT_DATE - INTERVAL '6 days') AND CURRENT_DAT
   AND k.is_active = TRUE
 GROUP BY f.id, f.nama, k.tgl_kehadiran
 ORDER BY f.nama, k.tgl_kehadiran;
+
+-- name: GenerateAlpaKehadiran :execrows
+INSERT INTO kehadiran (
+  fasilitas_id, kontrak_id, ruangan_id, pembimbing_id, user_id, pembimbing_klinik, mata_kuliah_id,
+  jadwal_dinas, jadwal_id, created_by, tgl_kehadiran, presensi, penempatan_id
+)
+SELECT
+  p.fasilitas_id,
+  p.kontrak_id,
+  COALESCE(j.ruangan_id, p.ruangan_id),
+  p.pembimbing_id,
+  p.user_id,
+  p.pembimbing_klinik,
+  p.mata_kuliah_id,
+  s.kode,
+  j.id,
+  sqlc.narg('created_by')::text,
+  t.tgl::date,
+  'alpa',
+  p.id
+FROM penempatan p
+CROSS JOIN LATERAL generate_series(
+  GREATEST(p.tgl_mulai, sqlc.arg('tgl_awal')::date),
+  LEAST(p.tgl_selesai, sqlc.arg('tgl_akhir')::date),
+  INTERVAL '1 day'
+) AS t(tgl)
+LEFT JOIN jadwal_dinas_mahasiswa j
+  ON j.user_id = p.user_id
+  AND j.tgl_dinas = t.tgl::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+LEFT JOIN shift s ON s.id = j.shift_id
+WHERE p.is_active = true
+  AND p.deleted_at IS NULL
+  AND p.tgl_mulai <= sqlc.arg('tgl_akhir')::date
+  AND p.tgl_selesai >= sqlc.arg('tgl_awal')::date
+  AND (
+    (j.id IS NOT NULL AND ((j.tgl_dinas + s.jam_selesai)
+         + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+        ) AT TIME ZONE 'Asia/Jakarta' <= sqlc.arg('batas_selesai')::timestamptz)
+    OR (
+      j.id IS NULL
+      AND sqlc.arg('tanpa_jadwal')::bool
+      AND NOT EXISTS (
+        SELECT 1 FROM jadwal_dinas_mahasiswa x
+        WHERE x.user_id = p.user_id
+          AND x.tgl_dinas BETWEEN p.tgl_mulai AND p.tgl_selesai
+          AND x.is_active = true
+          AND x.deleted_at IS NULL
+      )
+      AND (t.tgl::date + 1)::timestamp AT TIME ZONE 'Asia/Jakarta' <= sqlc.arg('batas_selesai')::timestamptz
+    )
+  )
+UNION ALL
+SELECT
+  r.fasilitas_id,
+  r.kontrak_id,
+  j.ruangan_id,
+  prev.pembimbing_id,
+  j.user_id,
+  prev.pembimbing_klinik,
+  prev.mata_kuliah_id,
+  s.kode,
+  j.id,
+  sqlc.narg('created_by')::text,
+  j.tgl_dinas,
+  'alpa',
+  NULL::uuid
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+JOIN ruangan r ON r.id = j.ruangan_id
+JOIN LATERAL (
+  SELECT k.pembimbing_id, k.pembimbing_klinik, k.mata_kuliah_id
+  FROM kehadiran k
+  WHERE k.user_id = j.user_id
+    AND k.kontrak_id = r.kontrak_id
+    AND k.deleted_at IS NULL
+  ORDER BY k.tgl_kehadiran DESC
+  LIMIT 1
+) prev ON true
+WHERE j.tgl_dinas BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM penempatan pn
+    WHERE pn.user_id = j.user_id
+      AND j.tgl_dinas BETWEEN pn.tgl_mulai AND pn.tgl_selesai
+      AND pn.is_active = true
+      AND pn.deleted_at IS NULL
+  )
+  AND ((j.tgl_dinas + s.jam_selesai)
+       + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+      ) AT TIME ZONE 'Asia/Jakarta' <= sqlc.arg('batas_selesai')::timestamptz
+ON CONFLICT ON CONSTRAINT uq_kehadiran_per_hari DO NOTHING;
+
+-- name: ListTanggalKehadiranUsers :many
+SELECT user_id, tgl_kehadiran
+FROM kehadiran
+WHERE user_id = ANY(sqlc.arg('user_ids')::uuid[])
+  AND tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND deleted_at IS NULL;
+
+-- name: ListKehadiranEkspor :many
+SELECT
+  k.id,
+  k.tgl_kehadiran,
+  u.username,
+  u.nama AS nama_mahasiswa,
+  r.nama_ruangan,
+  f.nama AS nama_fasilitas,
+  k.jadwal_dinas,
+  k.presensi,
+  k.created_at AS jam_masuk,
+  k.jam_pulang,
+  k.durasi_menit,
+  k.pulang_awal,
+  p.nama AS nama_pembimbing
+FROM kehadiran k
+JOIN users u ON u.id = k.user_id
+JOIN ruangan r ON r.id = k.ruangan_id
+JOIN fasilitas_kesehatan f ON f.id = k.fasilitas_id
+LEFT JOIN users p ON p.id = k.pembimbing_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND (sqlc.narg('user_id')::uuid IS NULL OR k.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR k.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('fasilitas_id')::uuid IS NULL OR k.fasilitas_id = sqlc.narg('fasilitas_id')::uuid)
+  AND (sqlc.narg('setelah_tgl')::date IS NULL
+       OR (k.tgl_kehadiran, k.id) > (sqlc.narg('setelah_tgl')::date, sqlc.narg('setelah_id')::uuid))
+ORDER BY k.tgl_kehadiran, k.id
+LIMIT sqlc.arg('limit');
+
+-- name: RekapKehadiranEkspor :many
+SELECT
+  k.user_id,
+  u.username,
+  u.nama AS nama_mahasiswa,
+  COUNT(*) FILTER (WHERE k.presensi = 'hadir') AS total_hadir,
+  COUNT(*) FILTER (WHERE k.presensi = 'izin') AS total_izin,
+  COUNT(*) FILTER (WHERE k.presensi = 'sakit') AS total_sakit,
+  COUNT(*) FILTER (WHERE k.presensi = 'alpa') AS total_alpa,
+  COUNT(*) AS total_semua,
+  COALESCE(SUM(k.durasi_menit), 0)::bigint AS total_menit_dinas,
+  COUNT(*) FILTER (WHERE k.pulang_awal) AS total_pulang_awal
+FROM kehadiran k
+JOIN users u ON u.id = k.user_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND (sqlc.narg('user_id')::uuid IS NULL OR k.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR k.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('fasilitas_id')::uuid IS NULL OR k.fasilitas_id = sqlc.narg('fasilitas_id')::uuid)
+GROUP BY k.user_id, u.username, u.nama
+ORDER BY u.nama;
+
+-- name: GetPembimbingEkspor :many
+SELECT DISTINCT p.nama
+FROM kehadiran k
+JOIN users p ON p.id = k.pembimbing_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND (sqlc.narg('user_id')::uuid IS NULL OR k.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR k.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('fasilitas_id')::uuid IS NULL OR k.fasilitas_id = sqlc.narg('fasilitas_id')::uuid)
+ORDER BY p.nama;
+
+-- name: CountJadwalAlpaTanpaPenempatan :one
+SELECT COUNT(*)::bigint
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+WHERE j.tgl_dinas BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM penempatan pn
+    WHERE pn.user_id = j.user_id
+      AND j.tgl_dinas BETWEEN pn.tgl_mulai AND pn.tgl_selesai
+      AND pn.is_active = true
+      AND pn.deleted_at IS NULL
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM kehadiran k
+    WHERE k.user_id = j.user_id
+      AND k.tgl_kehadiran = j.tgl_dinas
+      AND k.deleted_at IS NULL
+  )
+  AND ((j.tgl_dinas + s.jam_selesai)
+       + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+      ) AT TIME ZONE 'Asia/Jakarta' <= sqlc.arg('batas_selesai')::timestamptz;
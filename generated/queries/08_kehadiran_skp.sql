@@ -87,6 +87,9 @@ inserted AS (
         i.user_id,
         i.actor
     FROM input_data i
+    JOIN skp_intervensi si
+      ON si.id = i.skp_intervensi_id
+     AND si.is_active = true            -- intervensi nonaktif tidak bisa dipilih lagi
     LEFT JOIN kehadiran_skp k
       ON k.kehadiran_id = i.kehadiran_id 
      AND k.skp_intervensi_id = i.skp_intervensi_id
@@ -128,32 +131,33 @@ SELECT
 ks.id,
   si.nama, 
   ks.skp_intervensi_id,
-  ks.locked
+  ks.locked,
+  ks.status,
+  ks.narasi,
+  ks.inisial_pasien,
+  ks.kelompok_usia,
+  ks.kamar_bed,
+  ks.waktu_tindakan,
+  ks.catatan_review,
+  ks.reviewed_by,
+  ks.reviewed_at,
+  ks.level_id,
+  lv.nama AS level_nama,
+  si.level_minimal_id,
+  lm.nama AS level_minimal_nama
 FROM public.kehadiran_skp ks
 LEFT JOIN public.skp_intervensi si
   ON ks.skp_intervensi_id = si.id
+LEFT JOIN public.skp_level lv
+  ON lv.id = ks.level_id
+LEFT JOIN public.skp_level lm
+  ON lm.id = si.level_minimal_id
 WHERE 
   ks.kehadiran_id = $1
   AND ks.is_active = TRUE
   AND ks.deleted_at IS NULL
 ORDER BY ks.created_at DESC;
 
--- name: ApproveKehadiranSkpByIds :exec
-UPDATE public.kehadiran_skp ks
-SET
-  status = CASE
-    WHEN ks.id = ANY(sqlc.arg('skp_kehadiran_id')::uuid[])
-      THEN 'disetujui'
-    ELSE 'ditolak'
-  END,
-  locked = TRUE,
-  updated_at = now(),
-  updated_by = sqlc.arg('updated_by')
-WHERE
-  ks.kehadiran_id = sqlc.arg('kehadiran_id')::uuid
-  AND ks.deleted_at IS NULL
-  AND ks.is_active = TRUE;
-
 -- name: GetRekapSKPHarian :one
 WITH DataSKP AS (
   SELECT
@@ -278,9 +282,12 @@ SELECT
 FROM kehadiran_skp ks
 JOIN kehadiran k ON k.id = ks.kehadiran_id
 JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+LEFT JOIN skp_level lv ON lv.id = ks.level_id
+LEFT JOIN skp_level lm ON lm.id = si.level_minimal_id
 WHERE
   ks.user_id = sqlc.arg('user_id')
   AND ks.status = 'disetujui'
+  AND (si.level_minimal_id IS NULL OR lv.urutan >= lm.urutan)
   AND ks.is_active = TRUE
   AND k.is_active = TRUE
   AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal') AND sqlc.arg('tgl_akhir')
@@ -303,13 +310,17 @@ Total AS (
 DataRekap AS (
     SELECT
         CASE
-            WHEN (ks.status = 'disetujui' OR ks.locked = TRUE) THEN 'Tercapai'
-            WHEN ks.status = 'ditolak' THEN 'Belum Tercapai'
+            WHEN ks.status = 'disetujui'
+                 AND (si.level_minimal_id IS NULL OR lv.urutan >= lm.urutan) THEN 'Tercapai'
+            WHEN ks.status IN ('disetujui', 'ditolak') THEN 'Belum Tercapai'
             ELSE 'Belum Diverifikasi'
         END AS kategori_status,
         COUNT(*) AS jumlah
     FROM kehadiran_skp ks
     JOIN kehadiran k ON k.id = ks.kehadiran_id
+    JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+    LEFT JOIN skp_level lv ON lv.id = ks.level_id
+    LEFT JOIN skp_level lm ON lm.id = si.level_minimal_id
     WHERE ks.is_active = TRUE
       AND k.is_active = TRUE
       AND k.tgl_kehadiran BETWEEN date_trunc('year', CURRENT_DATE)::date AND CURRENT_DATE
@@ -328,7 +339,339 @@ LEFT JOIN DataRekap dr ON dr.kategori_status = ks.kategori_nama
 CROSS JOIN Total t
 ORDER BY persentase DESC;
 
+-- name: UpdateBuktiKehadiranSkp :one
+UPDATE kehadiran_skp
+SET
+  narasi         = sqlc.narg('narasi'),
+  inisial_pasien = sqlc.narg('inisial_pasien'),
+  kelompok_usia  = sqlc.narg('kelompok_usia'),
+  kamar_bed      = sqlc.narg('kamar_bed'),
+  waktu_tindakan = sqlc.narg('waktu_tindakan'),
+  updated_by     = sqlc.narg('updated_by'),
+  updated_at     = now()
+WHERE id = sqlc.arg('id')
+  AND user_id = sqlc.arg('user_id')
+  AND COALESCE(locked, false) = false
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CreateKehadiranSkpLampiran :one
+INSERT INTO kehadiran_skp_lampiran (
+  kehadiran_skp_id, lampiran_key, lampiran_nama, lampiran_tipe, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: CountKehadiranSkpLampiran :one
+SELECT COUNT(*)::bigint
+FROM kehadiran_skp_lampiran
+WHERE kehadiran_skp_id = $1
+  AND deleted_at IS NULL;
+
+-- name: ListKehadiranSkpLampiran :many
+SELECT id, kehadiran_skp_id, lampiran_key, lampiran_nama, lampiran_tipe, created_at
+FROM kehadiran_skp_lampiran
+WHERE kehadiran_skp_id = ANY(sqlc.arg('kehadiran_skp_ids')::uuid[])
+  AND deleted_at IS NULL
+ORDER BY created_at ASC;
+
+-- name: GetKehadiranSkpLampiran :one
+SELECT
+  l.id,
+  l.kehadiran_skp_id,
+  l.lampiran_key,
+  l.lampiran_nama,
+  l.lampiran_tipe,
+  ks.user_id,
+  ks.locked,
+  k.pembimbing_id,
+  k.pembimbing_klinik
+FROM kehadiran_skp_lampiran l
+JOIN kehadiran_skp ks ON ks.id = l.kehadiran_skp_id
+JOIN kehadiran k ON k.id = ks.kehadiran_id
+WHERE l.id = $1
+  AND l.deleted_at IS NULL;
+
+-- name: DeleteKehadiranSkpLampiran :exec
+UPDATE kehadiran_skp_lampiran
+SET deleted_at = now(), deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListSkpMenungguReview :many
+SELECT id
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND COALESCE(locked, false) = false
+  AND (status IS NULL OR status = 'diajukan')
+  AND is_active = TRUE
+  AND deleted_at IS NULL;
+
+-- name: ReviewKehadiranSkp :one
+UPDATE kehadiran_skp
+SET
+  status         = sqlc.arg('status')::varchar,
+  catatan_review = sqlc.narg('catatan_review'),
+  level_id       = sqlc.narg('level_id'),
+  locked         = (sqlc.arg('status')::varchar <> 'revisi'),
+  reviewer_id    = sqlc.arg('reviewer_id'),
+  reviewed_by    = sqlc.narg('reviewed_by'),
+  reviewed_at    = now(),
+  updated_by     = sqlc.narg('reviewed_by'),
+  updated_at     = now()
+WHERE id = sqlc.arg('id')
+  AND kehadiran_id = sqlc.arg('kehadiran_id')
+  AND COALESCE(locked, false) = false
+  AND (status IS NULL OR status = 'diajukan')
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CreateKehadiranSkpKeputusan :exec
+INSERT INTO kehadiran_skp_keputusan (
+  kehadiran_skp_id, status, catatan_review, reviewer_id, reviewed_by, level_id, atas_nama_id, delegasi_pembimbing_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: ListKehadiranSkpKeputusan :many
+SELECT *
+FROM kehadiran_skp_keputusan
+WHERE kehadiran_skp_id = ANY(sqlc.arg('kehadiran_skp_ids')::uuid[])
+ORDER BY created_at ASC;
+
+-- name: CountSkpRevisi :one
+SELECT COUNT(*)::bigint
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND status = 'revisi'
+  AND is_active = TRUE
+  AND deleted_at IS NULL;
+
+-- name: AjukanUlangKehadiranSkp :one
+UPDATE kehadiran_skp
+SET
+  status     = 'diajukan',
+  updated_by = sqlc.narg('updated_by'),
+  updated_at = now()
+WHERE id = sqlc.arg('id')
+  AND user_id = sqlc.arg('user_id')
+  AND status = 'revisi'
+  AND COALESCE(locked, false) = false
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListInboxApproval :many
+WITH inbox AS (
+  SELECT
+    k.id AS kehadiran_id,
+    k.user_id,
+    u.nama,
+    u.username,
+    k.ruangan_id,
+    r.nama_ruangan,
+    k.tgl_kehadiran,
+    k.status,
+    COALESCE(p.jumlah_skp, 0)::bigint AS jumlah_skp,
+    COALESCE(p.jumlah_menunggu, 0)::bigint AS jumlah_skp_menunggu,
+    (CASE
+      WHEN k.status IS NULL THEN k.created_at
+      ELSE COALESCE(p.diajukan_sejak, k.created_at)
+    END)::timestamptz AS menunggu_sejak,
+    (CASE
+      WHEN k.pembimbing_id = sqlc.arg('pembimbing_id')::uuid OR k.pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid THEN NULL
+      ELSE k.pembimbing_klinik
+    END)::uuid AS atas_nama_id
+  FROM kehadiran k
+  JOIN users u ON u.id = k.user_id
+  LEFT JOIN ruangan r ON r.id = k.ruangan_id
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS jumlah_skp,
+      COUNT(*) FILTER (
+        WHERE COALESCE(ks.locked, false) = false
+          AND (ks.status IS NULL OR ks.status = 'diajukan')
+      ) AS jumlah_menunggu,
+      MIN(COALESCE(ks.updated_at, ks.created_at)) FILTER (WHERE ks.status = 'diajukan') AS diajukan_sejak
+    FROM kehadiran_skp ks
+    WHERE ks.kehadiran_id = k.id
+      AND ks.is_active = TRUE
+      AND ks.deleted_at IS NULL
+  ) p ON true
+  WHERE k.is_active = TRUE
+    AND k.deleted_at IS NULL
+    AND k.presensi = 'hadir'
+    AND (k.pembimbing_id = sqlc.arg('pembimbing_id')::uuid
+         OR k.pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid
+         OR EXISTS (
+           SELECT 1 FROM delegasi_pembimbing d
+           WHERE d.delegasi_id = sqlc.arg('pembimbing_id')::uuid
+             AND d.pembimbing_id = k.pembimbing_klinik
+             AND d.kontrak_id = k.kontrak_id
+             AND d.dicabut_at IS NULL
+             AND CURRENT_DATE BETWEEN d.tgl_mulai AND d.tgl_selesai
+         ))
+    AND (k.status IS NULL OR COALESCE(p.jumlah_menunggu, 0) > 0)
+    AND (sqlc.narg('user_id')::uuid IS NULL OR k.user_id = sqlc.narg('user_id')::uuid)
+    AND (sqlc.narg('ruangan_id')::uuid IS NULL OR k.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+    AND (sqlc.narg('tgl_awal')::date IS NULL OR k.tgl_kehadiran >= sqlc.narg('tgl_awal')::date)
+    AND (sqlc.narg('tgl_akhir')::date IS NULL OR k.tgl_kehadiran <= sqlc.narg('tgl_akhir')::date)
+)
+SELECT
+  kehadiran_id,
+  user_id,
+  nama,
+  username,
+  ruangan_id,
+  nama_ruangan,
+  tgl_kehadiran,
+  status,
+  jumlah_skp,
+  jumlah_skp_menunggu,
+  menunggu_sejak,
+  (EXTRACT(EPOCH FROM now() - menunggu_sejak) / 3600)::float8 AS lama_menunggu_jam,
+  (menunggu_sejak < now() - make_interval(hours => sqlc.arg('sla_jam')::int))::boolean AS lewat_sla,
+  atas_nama_id
+FROM inbox
+WHERE (sqlc.narg('lewat_sla')::boolean IS NULL
+       OR (menunggu_sejak < now() - make_interval(hours => sqlc.arg('sla_jam')::int)) = sqlc.narg('lewat_sla')::boolean)
+ORDER BY menunggu_sejak ASC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountInboxApproval :one
+WITH inbox AS (
+  SELECT
+    k.id AS kehadiran_id,
+    k.user_id,
+    u.nama,
+    u.username,
+    k.ruangan_id,
+    r.nama_ruangan,
+    k.tgl_kehadiran,
+    k.status,
+    COALESCE(p.jumlah_skp, 0)::bigint AS jumlah_skp,
+    COALESCE(p.jumlah_menunggu, 0)::bigint AS jumlah_skp_menunggu,
+    (CASE
+      WHEN k.status IS NULL THEN k.created_at
+      ELSE COALESCE(p.diajukan_sejak, k.created_at)
+    END)::timestamptz AS menunggu_sejak,
+    (CASE
+      WHEN k.pembimbing_id = sqlc.arg('pembimbing_id')::uuid OR k.pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid THEN NULL
+      ELSE k.pembimbing_klinik
+    END)::uuid AS atas_nama_id
+  FROM kehadiran k
+  JOIN users u ON u.id = k.user_id
+  LEFT JOIN ruangan r ON r.id = k.ruangan_id
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS jumlah_skp,
+      COUNT(*) FILTER (
+        WHERE COALESCE(ks.locked, false) = false
+          AND (ks.status IS NULL OR ks.status = 'diajukan')
+      ) AS jumlah_menunggu,
+      MIN(COALESCE(ks.updated_at, ks.created_at)) FILTER (WHERE ks.status = 'diajukan') AS diajukan_sejak
+    FROM kehadiran_skp ks
+    WHERE ks.kehadiran_id = k.id
+      AND ks.is_active = TRUE
+      AND ks.deleted_at IS NULL
+  ) p ON true
+  WHERE k.is_active = TRUE
+    AND k.deleted_at IS NULL
+    AND k.presensi = 'hadir'
+    AND (k.pembimbing_id = sqlc.arg('pembimbing_id')::uuid
+         OR k.pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid
+         OR EXISTS (
+           SELECT 1 FROM delegasi_pembimbing d
+           WHERE d.delegasi_id = sqlc.arg('pembimbing_id')::uuid
+             AND d.pembimbing_id = k.pembimbing_klinik
+             AND d.kontrak_id = k.kontrak_id
+             AND d.dicabut_at IS NULL
+             AND CURRENT_DATE BETWEEN d.tgl_mulai AND d.tgl_selesai
+         ))
+    AND (k.status IS NULL OR COALESCE(p.jumlah_menunggu, 0) > 0)
+    AND (sqlc.narg('user_id')::uuid IS NULL OR k.user_id = sqlc.narg('user_id')::uuid)
+    AND (sqlc.narg('ruangan_id')::uuid IS NULL OR k.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+    AND (sqlc.narg('tgl_awal')::date IS NULL OR k.tgl_kehadiran >= sqlc.narg('tgl_awal')::date)
+    AND (sqlc.narg('tgl_akhir')::date IS NULL OR k.tgl_kehadiran <= sqlc.narg('tgl_akhir')::date)
+)
+SELECT COUNT(*)::bigint
+FROM inbox
+WHERE (sqlc.narg('lewat_sla')::boolean IS NULL
+       OR (menunggu_sejak < now() - make_interval(hours => sqlc.arg('sla_jam')::int)) = sqlc.narg('lewat_sla')::boolean);
+
+-- name: ListSkpMenungguByKehadiran :many
+SELECT
+  ks.id,
+  ks.kehadiran_id,
+  ks.skp_intervensi_id,
+  si.nama,
+  ks.status,
+  ks.narasi,
+  ks.waktu_tindakan,
+  si.level_minimal_id,
+  COALESCE(ks.updated_at, ks.created_at)::timestamptz AS diajukan_at
+FROM kehadiran_skp ks
+JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+WHERE ks.kehadiran_id = ANY(sqlc.arg('kehadiran_ids')::uuid[])
+  AND COALESCE(ks.locked, false) = false
+  AND (ks.status IS NULL OR ks.status = 'diajukan')
+  AND ks.is_active = TRUE
+  AND ks.deleted_at IS NULL
+ORDER BY ks.created_at ASC;
+
+-- name: ListKehadiranSkpByKehadiran :many
+SELECT *
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+ORDER BY created_at ASC, id ASC;
+
+-- name: BukaKunciKehadiranSkp :execrows
+UPDATE kehadiran_skp
+SET
+  locked         = false,
+  status         = NULL,
+  catatan_review = NULL,
+  level_id       = NULL,
+  reviewer_id    = NULL,
+  reviewed_by    = NULL,
+  reviewed_at    = NULL,
+  updated_note   = sqlc.narg('alasan'),
+  updated_by     = sqlc.narg('updated_by'),
+  updated_at     = now()
+WHERE kehadiran_id = sqlc.arg('kehadiran_id')
+  AND is_active = TRUE
+  AND deleted_at IS NULL;
 
+-- name: BukaStatusKehadiran :one
+UPDATE kehadiran
+SET
+  status       = NULL,
+  updated_note = sqlc.narg('alasan'),
+  updated_by   = sqlc.narg('updated_by'),
+  updated_at   = now()
+WHERE id = sqlc.arg('id')
+  AND deleted_at IS NULL
+RETURNING *;
 
+-- name: CreatePembukaanKehadiranSkp :one
+INSERT INTO pembukaan_kehadiran_skp (
+  kehadiran_id, alasan, sebelum, dibuka_oleh, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
 
+-- name: ListPembukaanKehadiranSkp :many
+SELECT * FROM pembukaan_kehadiran_skp
+WHERE kehadiran_id = $1
+ORDER BY created_at ASC;
 
+-- name: CreateKehadiranKeputusan :exec
+INSERT INTO kehadiran_keputusan (
+  kehadiran_id, status, reviewer_id, reviewed_by, atas_nama_id, delegasi_pembimbing_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
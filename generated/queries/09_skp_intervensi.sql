@@ -12,5 +12,204 @@ JOIN
     public.skp_subkategori s ON i.subkategori_id = s.id
 JOIN
     public.skp_kategori k ON i.kategori_id = k.id
+WHERE
+    i.is_active = TRUE
+    AND s.is_active = TRUE
+    AND k.is_active = TRUE
 ORDER BY
-    k.nama, s.nama, i.nama;
\ No newline at end of file
+    k.urutan, k.nama, s.urutan, s.nama, i.urutan, i.nama;
+
+-- name: ListKatalogSkp :many
+SELECT
+    k.id AS kategori_id,
+    k.kode AS kategori_kode,
+    k.nama AS kategori_nama,
+    k.urutan AS kategori_urutan,
+    k.is_active AS kategori_aktif,
+    s.id AS subkategori_id,
+    s.kode AS subkategori_kode,
+    s.nama AS subkategori_nama,
+    s.urutan AS subkategori_urutan,
+    s.is_active AS subkategori_aktif,
+    i.id AS intervensi_id,
+    i.kode AS intervensi_kode,
+    i.nama AS intervensi_nama,
+    i.urutan AS intervensi_urutan,
+    i.is_active AS intervensi_aktif,
+    i.versi AS intervensi_versi,
+    i.akar_id AS intervensi_akar_id,
+    i.level_minimal_id AS intervensi_level_minimal_id
+FROM
+    public.skp_kategori k
+LEFT JOIN
+    public.skp_subkategori s ON s.kategori_id = k.id
+LEFT JOIN
+    public.skp_intervensi i ON i.subkategori_id = s.id
+WHERE
+    (sqlc.narg('is_active')::boolean IS NULL
+     OR (k.is_active = sqlc.narg('is_active')::boolean
+         AND (s.id IS NULL OR s.is_active = sqlc.narg('is_active')::boolean)
+         AND (i.id IS NULL OR i.is_active = sqlc.narg('is_active')::boolean)))
+ORDER BY
+    k.urutan, k.nama, s.urutan, s.nama, i.urutan, i.nama, i.versi;
+
+-- name: CreateSkpKategori :one
+INSERT INTO skp_kategori (
+  kode, nama, urutan, created_by
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UpdateSkpKategori :one
+UPDATE skp_kategori
+SET
+  kode       = COALESCE(sqlc.narg('kode'), kode),
+  nama       = COALESCE(sqlc.narg('nama'), nama),
+  is_active  = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by = sqlc.narg('updated_by'),
+  updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: UrutkanSkpKategori :execrows
+UPDATE skp_kategori t
+SET urutan = u.urutan,
+    updated_by = sqlc.narg('updated_by'),
+    updated_at = now()
+FROM unnest(sqlc.arg('ids')::uuid[], sqlc.arg('urutan')::int[]) AS u(id, urutan)
+WHERE t.id = u.id;
+
+-- name: GetSkpSubkategori :one
+SELECT * FROM skp_subkategori
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateSkpSubkategori :one
+INSERT INTO skp_subkategori (
+  kategori_id, kode, nama, urutan, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: UpdateSkpSubkategori :one
+UPDATE skp_subkategori
+SET
+  kode       = COALESCE(sqlc.narg('kode'), kode),
+  nama       = COALESCE(sqlc.narg('nama'), nama),
+  is_active  = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by = sqlc.narg('updated_by'),
+  updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: UrutkanSkpSubkategori :execrows
+UPDATE skp_subkategori t
+SET urutan = u.urutan,
+    updated_by = sqlc.narg('updated_by'),
+    updated_at = now()
+FROM unnest(sqlc.arg('ids')::uuid[], sqlc.arg('urutan')::int[]) AS u(id, urutan)
+WHERE t.id = u.id;
+
+-- name: GetSkpIntervensi :one
+SELECT * FROM skp_intervensi
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateSkpIntervensi :one
+INSERT INTO skp_intervensi (
+  kategori_id, subkategori_id, kode, nama, urutan, versi, akar_id, level_minimal_id, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: UpdateSkpIntervensi :one
+UPDATE skp_intervensi
+SET
+  kategori_id    = COALESCE(sqlc.narg('kategori_id'), kategori_id),
+  subkategori_id = COALESCE(sqlc.narg('subkategori_id'), subkategori_id),
+  kode           = COALESCE(sqlc.narg('kode'), kode),
+  nama           = COALESCE(sqlc.narg('nama'), nama),
+  is_active      = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by     = sqlc.narg('updated_by'),
+  updated_at     = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: UrutkanSkpIntervensi :execrows
+UPDATE skp_intervensi t
+SET urutan = u.urutan,
+    updated_by = sqlc.narg('updated_by'),
+    updated_at = now()
+FROM unnest(sqlc.arg('ids')::uuid[], sqlc.arg('urutan')::int[]) AS u(id, urutan)
+WHERE t.id = u.id;
+
+-- name: SetLevelMinimalIntervensi :one
+UPDATE skp_intervensi
+SET
+  level_minimal_id = sqlc.narg('level_minimal_id'),
+  updated_by       = sqlc.narg('updated_by'),
+  updated_at       = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: CekIntervensiDipakai :one
+SELECT EXISTS (
+  SELECT 1 FROM kehadiran_skp
+  WHERE skp_intervensi_id = $1
+);
+
+-- name: PindahkanTargetIntervensi :exec
+UPDATE skp_target
+SET skp_intervensi_id = sqlc.arg('intervensi_baru'),
+    updated_by = sqlc.narg('updated_by'),
+    updated_at = now()
+WHERE skp_intervensi_id = sqlc.arg('intervensi_lama')
+  AND deleted_at IS NULL;
+
+-- name: ListSemuaSkpKategori :many
+SELECT * FROM skp_kategori
+ORDER BY urutan, nama;
+
+-- name: ListSemuaSkpSubkategori :many
+SELECT * FROM skp_subkategori
+ORDER BY urutan, nama;
+
+-- name: ListSkpIntervensiTerbaru :many
+SELECT DISTINCT ON (COALESCE(akar_id, id))
+  id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at,
+  created_by, created_at, versi, akar_id, level_minimal_id, kode
+FROM skp_intervensi
+ORDER BY COALESCE(akar_id, id), versi DESC;
+
+-- name: ListSkpLevel :many
+SELECT * FROM skp_level
+WHERE (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+ORDER BY urutan, nama;
+
+-- name: GetSkpLevel :one
+SELECT * FROM skp_level
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateSkpLevel :one
+INSERT INTO skp_level (
+  kode, nama, urutan, deskripsi, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: UpdateSkpLevel :one
+UPDATE skp_level
+SET
+  nama       = COALESCE(sqlc.narg('nama'), nama),
+  urutan     = COALESCE(sqlc.narg('urutan'), urutan),
+  deskripsi  = COALESCE(sqlc.narg('deskripsi'), deskripsi),
+  is_active  = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by = sqlc.narg('updated_by'),
+  updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
@@ -41,4 +41,15 @@ JOIN
     users t2 ON t1.user_id = t2.id
 WHERE
     t1.kontrak_id = $1
-    AND t1.deleted_at IS NULL;
\ No newline at end of file
+    AND t1.deleted_at IS NULL;
+
+-- name: CekPembimbingKlinikRuangan :one
+SELECT EXISTS (
+  SELECT 1
+  FROM pembimbing_klinik pk
+  JOIN ruangan r ON r.kontrak_id = pk.kontrak_id
+  WHERE r.id = sqlc.arg('ruangan_id')
+    AND pk.user_id = sqlc.arg('user_id')
+    AND pk.is_active = true
+    AND pk.deleted_at IS NULL
+);
\ No newline at end of file
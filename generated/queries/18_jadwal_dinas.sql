@@ -0,0 +1,118 @@
+-- name: CreateShift :one
+INSERT INTO shift (
+  kode, nama, jam_mulai, jam_selesai, created_by
+) VALUES (
+  sqlc.arg('kode'), sqlc.arg('nama'), sqlc.arg('jam_mulai'), sqlc.arg('jam_selesai'), sqlc.narg('created_by')
+)
+RETURNING id, kode, nama, to_char(jam_mulai, 'HH24:MI') AS jam_mulai, to_char(jam_selesai, 'HH24:MI') AS jam_selesai, is_active, created_by, created_at;
+
+-- name: ListShift :many
+SELECT id, kode, nama, to_char(jam_mulai, 'HH24:MI') AS jam_mulai, to_char(jam_selesai, 'HH24:MI') AS jam_selesai, is_active, created_by, created_at
+FROM shift
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+ORDER BY jam_mulai;
+
+-- name: GetShiftByKode :one
+SELECT * FROM shift
+WHERE kode = sqlc.arg('kode') AND deleted_at IS NULL AND is_active = true
+LIMIT 1;
+
+-- name: UpdateShiftPartial :one
+UPDATE shift
+SET
+  nama        = COALESCE(sqlc.narg('nama'), nama),
+  jam_mulai   = COALESCE(sqlc.narg('jam_mulai'), jam_mulai),
+  jam_selesai = COALESCE(sqlc.narg('jam_selesai'), jam_selesai),
+  is_active   = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by  = COALESCE(sqlc.narg('updated_by'), updated_by),
+  updated_at  = now()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING id, kode, nama, to_char(jam_mulai, 'HH24:MI') AS jam_mulai, to_char(jam_selesai, 'HH24:MI') AS jam_selesai, is_active, created_by, created_at;
+
+-- name: DeleteShift :exec
+UPDATE shift
+SET deleted_at = now(), deleted_by = sqlc.narg('deleted_by')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
+
+-- name: UpsertJadwalDinasRentang :many
+INSERT INTO jadwal_dinas_mahasiswa (
+  user_id, ruangan_id, shift_id, tgl_dinas, created_by
+)
+SELECT
+  u.user_id,
+  sqlc.arg('ruangan_id')::uuid,
+  sqlc.arg('shift_id')::uuid,
+  t.tgl::date,
+  sqlc.narg('created_by')::text
+FROM unnest(sqlc.arg('user_ids')::uuid[]) AS u(user_id)
+CROSS JOIN generate_series(sqlc.arg('tgl_mulai')::date, sqlc.arg('tgl_selesai')::date, INTERVAL '1 day') AS t(tgl)
+ON CONFLICT (user_id, tgl_dinas) WHERE deleted_at IS NULL
+DO UPDATE SET
+  ruangan_id = EXCLUDED.ruangan_id,
+  shift_id   = EXCLUDED.shift_id,
+  updated_by = EXCLUDED.created_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: ListJadwalDinas :many
+SELECT
+  j.id,
+  j.user_id,
+  u.nama AS nama_mahasiswa,
+  j.ruangan_id,
+  r.nama_ruangan,
+  j.shift_id,
+  s.kode AS kode_shift,
+  s.nama AS nama_shift,
+  to_char(s.jam_mulai, 'HH24:MI') AS jam_mulai,
+  to_char(s.jam_selesai, 'HH24:MI') AS jam_selesai,
+  j.tgl_dinas
+FROM jadwal_dinas_mahasiswa j
+JOIN users u ON u.id = j.user_id
+JOIN ruangan r ON r.id = j.ruangan_id
+JOIN shift s ON s.id = j.shift_id
+WHERE j.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR j.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR j.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('tgl_awal')::date IS NULL OR j.tgl_dinas >= sqlc.narg('tgl_awal')::date)
+  AND (sqlc.narg('tgl_akhir')::date IS NULL OR j.tgl_dinas <= sqlc.narg('tgl_akhir')::date)
+ORDER BY j.tgl_dinas, u.nama
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountJadwalDinas :one
+SELECT COUNT(*)::bigint
+FROM jadwal_dinas_mahasiswa j
+WHERE j.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR j.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR j.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('tgl_awal')::date IS NULL OR j.tgl_dinas >= sqlc.narg('tgl_awal')::date)
+  AND (sqlc.narg('tgl_akhir')::date IS NULL OR j.tgl_dinas <= sqlc.narg('tgl_akhir')::date);
+
+-- name: GetJadwalDinasMahasiswa :one
+SELECT
+  j.id,
+  j.ruangan_id,
+  j.tgl_dinas,
+  s.kode AS kode_shift,
+  s.jam_mulai,
+  s.jam_selesai
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+WHERE j.user_id = sqlc.arg('user_id')
+  AND j.tgl_dinas = sqlc.arg('tgl_dinas')
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetShiftJadwalDinas :one
+SELECT s.kode, s.jam_mulai, s.jam_selesai
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+WHERE j.id = sqlc.arg('id');
+
+-- name: DeleteJadwalDinas :exec
+UPDATE jadwal_dinas_mahasiswa
+SET deleted_at = now(), deleted_by = sqlc.narg('deleted_by')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
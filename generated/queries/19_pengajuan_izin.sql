@@ -0,0 +1,103 @@
+-- name: CreatePengajuanIzin :one
+INSERT INTO pengajuan_izin (
+  user_id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik,
+  jenis, tgl_mulai, tgl_selesai, alasan, lampiran_key, lampiran_nama, lampiran_tipe, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7,
+  $8, $9, $10, $11, $12, $13, $14, $15
+)
+RETURNING *;
+
+-- name: GetPengajuanIzin :one
+SELECT * FROM pengajuan_izin
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: CekPengajuanIzinBertumpuk :one
+SELECT EXISTS (
+  SELECT 1 FROM pengajuan_izin
+  WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND status <> 'ditolak'
+    AND tgl_mulai <= sqlc.arg('tgl_selesai')::date
+    AND tgl_selesai >= sqlc.arg('tgl_mulai')::date
+);
+
+-- name: ListPengajuanIzin :many
+SELECT
+  p.id,
+  p.user_id,
+  u.nama AS nama_mahasiswa,
+  p.jenis,
+  p.tgl_mulai,
+  p.tgl_selesai,
+  p.alasan,
+  p.lampiran_nama,
+  p.status,
+  p.catatan_review,
+  p.reviewed_by,
+  p.reviewed_at,
+  p.created_at
+FROM pengajuan_izin p
+JOIN users u ON u.id = p.user_id
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('reviewer_id')::uuid IS NULL OR p.pembimbing_id = sqlc.narg('reviewer_id')::uuid OR p.pembimbing_klinik = sqlc.narg('reviewer_id')::uuid)
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR p.user_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid)
+ORDER BY p.created_at DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountPengajuanIzin :one
+SELECT COUNT(*)::bigint
+FROM pengajuan_izin p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('reviewer_id')::uuid IS NULL OR p.pembimbing_id = sqlc.narg('reviewer_id')::uuid OR p.pembimbing_klinik = sqlc.narg('reviewer_id')::uuid)
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR p.user_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid);
+
+-- name: ReviewPengajuanIzin :one
+UPDATE pengajuan_izin
+SET
+  status         = sqlc.arg('status'),
+  catatan_review = sqlc.narg('catatan_review'),
+  reviewed_by    = sqlc.narg('reviewed_by'),
+  reviewed_at    = now(),
+  updated_by     = sqlc.narg('reviewed_by'),
+  updated_at     = now()
+WHERE id = sqlc.arg('id')
+  AND status = 'menunggu'
+  AND deleted_at IS NULL
+  AND (pembimbing_id = sqlc.arg('reviewer_id') OR pembimbing_klinik = sqlc.arg('reviewer_id'))
+RETURNING *;
+
+-- name: CreateKehadiranDariIzin :execrows
+INSERT INTO kehadiran (
+  fasilitas_id, kontrak_id, ruangan_id, pembimbing_id, user_id, pembimbing_klinik, mata_kuliah_id,
+  created_by, tgl_kehadiran, presensi, updated_note
+)
+SELECT
+  p.fasilitas_id,
+  p.kontrak_id,
+  p.ruangan_id,
+  p.pembimbing_id,
+  p.user_id,
+  p.pembimbing_klinik,
+  p.mata_kuliah_id,
+  p.reviewed_by,
+  t.tgl::date,
+  p.jenis,
+  'pengajuan_izin:' || p.id::text
+FROM pengajuan_izin p
+CROSS JOIN generate_series(p.tgl_mulai, p.tgl_selesai, INTERVAL '1 day') AS t(tgl)
+WHERE p.id = sqlc.arg('id')
+  AND p.status = 'disetujui'
+ON CONFLICT ON CONSTRAINT uq_kehadiran_per_hari
+DO UPDATE SET
+  presensi     = EXCLUDED.presensi,
+  updated_note = EXCLUDED.updated_note,
+  updated_by   = EXCLUDED.created_by,
+  updated_at   = now()
+WHERE kehadiran.presensi = 'alpa';
@@ -0,0 +1,107 @@
+-- name: CreateKoreksiKehadiran :one
+INSERT INTO koreksi_kehadiran (
+  kehadiran_id, diajukan_oleh, presensi, ruangan_id, tgl_kehadiran, alasan, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetKoreksiKehadiran :one
+SELECT * FROM koreksi_kehadiran
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListKoreksiKehadiran :many
+SELECT
+  kk.id,
+  kk.kehadiran_id,
+  k.user_id,
+  u.nama AS nama_mahasiswa,
+  k.tgl_kehadiran AS tgl_kehadiran_lama,
+  k.presensi AS presensi_lama,
+  k.ruangan_id AS ruangan_id_lama,
+  kk.tgl_kehadiran,
+  kk.presensi,
+  kk.ruangan_id,
+  kk.alasan,
+  kk.diajukan_oleh,
+  kk.status,
+  kk.catatan_review,
+  kk.reviewed_by,
+  kk.reviewed_at,
+  kk.created_at
+FROM koreksi_kehadiran kk
+JOIN kehadiran k ON k.id = kk.kehadiran_id
+JOIN users u ON u.id = k.user_id
+WHERE kk.deleted_at IS NULL
+  AND (sqlc.narg('kehadiran_id')::uuid IS NULL OR kk.kehadiran_id = sqlc.narg('kehadiran_id')::uuid)
+  AND (sqlc.narg('diajukan_oleh')::uuid IS NULL OR kk.diajukan_oleh = sqlc.narg('diajukan_oleh')::uuid)
+  AND (sqlc.narg('reviewer_id')::uuid IS NULL OR (
+    (k.pembimbing_id = sqlc.narg('reviewer_id')::uuid OR k.pembimbing_klinik = sqlc.narg('reviewer_id')::uuid)
+    AND kk.diajukan_oleh <> sqlc.narg('reviewer_id')::uuid
+  ))
+  AND (sqlc.narg('status')::text IS NULL OR kk.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR k.user_id = sqlc.narg('pemanggil_id')::uuid OR kk.diajukan_oleh = sqlc.narg('pemanggil_id')::uuid OR k.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR k.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid)
+ORDER BY kk.created_at DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountKoreksiKehadiran :one
+SELECT COUNT(*)::bigint
+FROM koreksi_kehadiran kk
+JOIN kehadiran k ON k.id = kk.kehadiran_id
+WHERE kk.deleted_at IS NULL
+  AND (sqlc.narg('kehadiran_id')::uuid IS NULL OR kk.kehadiran_id = sqlc.narg('kehadiran_id')::uuid)
+  AND (sqlc.narg('diajukan_oleh')::uuid IS NULL OR kk.diajukan_oleh = sqlc.narg('diajukan_oleh')::uuid)
+  AND (sqlc.narg('reviewer_id')::uuid IS NULL OR (
+    (k.pembimbing_id = sqlc.narg('reviewer_id')::uuid OR k.pembimbing_klinik = sqlc.narg('reviewer_id')::uuid)
+    AND kk.diajukan_oleh <> sqlc.narg('reviewer_id')::uuid
+  ))
+  AND (sqlc.narg('status')::text IS NULL OR kk.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR k.user_id = sqlc.narg('pemanggil_id')::uuid OR kk.diajukan_oleh = sqlc.narg('pemanggil_id')::uuid OR k.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR k.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid);
+
+-- name: ReviewKoreksiKehadiran :one
+UPDATE koreksi_kehadiran
+SET
+  status         = sqlc.arg('status'),
+  catatan_review = sqlc.narg('catatan_review'),
+  reviewer_id    = sqlc.arg('reviewer_id'),
+  reviewed_by    = sqlc.narg('reviewed_by'),
+  reviewed_at    = now(),
+  updated_by     = sqlc.narg('reviewed_by'),
+  updated_at     = now()
+WHERE id = sqlc.arg('id')
+  AND status = 'menunggu'
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ApplyKoreksiKehadiran :one
+UPDATE kehadiran k
+SET
+  presensi      = COALESCE(kk.presensi, k.presensi),
+  ruangan_id    = COALESCE(kk.ruangan_id, k.ruangan_id),
+  fasilitas_id  = COALESCE(r.fasilitas_id, k.fasilitas_id),
+  kontrak_id    = COALESCE(r.kontrak_id, k.kontrak_id),
+  tgl_kehadiran = COALESCE(kk.tgl_kehadiran, k.tgl_kehadiran),
+  updated_by    = sqlc.narg('updated_by'),
+  updated_note  = kk.alasan,
+  updated_at    = now()
+FROM koreksi_kehadiran kk
+LEFT JOIN ruangan r ON r.id = kk.ruangan_id
+WHERE kk.id = sqlc.arg('koreksi_id')
+  AND k.id = kk.kehadiran_id
+  AND k.deleted_at IS NULL
+RETURNING k.*;
+
+-- name: CreateRiwayatKehadiran :one
+INSERT INTO riwayat_kehadiran (
+  kehadiran_id, koreksi_id, sebelum, sesudah, alasan, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListRiwayatKehadiran :many
+SELECT * FROM riwayat_kehadiran
+WHERE kehadiran_id = $1
+ORDER BY created_at ASC;
@@ -0,0 +1,188 @@
+-- name: CreatePenempatan :one
+INSERT INTO penempatan (
+  user_id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id,
+  pembimbing_id, pembimbing_klinik, tgl_mulai, tgl_selesai, created_by
+) VALUES (
+  $1, $2, $3, $4, $5,
+  $6, $7, $8, $9, $10
+)
+RETURNING *;
+
+-- name: GetPenempatan :one
+SELECT * FROM penempatan
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetPenempatanAktif :one
+SELECT * FROM penempatan
+WHERE user_id = sqlc.arg('user_id')
+  AND sqlc.arg('tgl')::date BETWEEN tgl_mulai AND tgl_selesai
+  AND is_active = true
+  AND deleted_at IS NULL
+ORDER BY tgl_mulai DESC
+LIMIT 1;
+
+-- name: CekPenempatanBertumpuk :one
+SELECT EXISTS (
+  SELECT 1 FROM penempatan
+  WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND is_active = true
+    AND (sqlc.narg('kecuali_id')::uuid IS NULL OR id <> sqlc.narg('kecuali_id')::uuid)
+    AND tgl_mulai <= sqlc.arg('tgl_selesai')::date
+    AND tgl_selesai >= sqlc.arg('tgl_mulai')::date
+);
+
+-- name: ListPenempatan :many
+SELECT
+  p.id,
+  p.user_id,
+  u.nama AS nama_mahasiswa,
+  p.fasilitas_id,
+  f.nama AS nama_fasilitas,
+  p.kontrak_id,
+  k.no_utama AS no_kontrak,
+  p.ruangan_id,
+  r.nama_ruangan,
+  p.mata_kuliah_id,
+  mk.mata_kuliah,
+  p.pembimbing_id,
+  pa.nama AS nama_pembimbing,
+  p.pembimbing_klinik,
+  pk.nama AS nama_pembimbing_klinik,
+  p.tgl_mulai,
+  p.tgl_selesai,
+  p.is_active,
+  p.created_at
+FROM penempatan p
+JOIN users u ON u.id = p.user_id
+JOIN fasilitas_kesehatan f ON f.id = p.fasilitas_id
+JOIN kontrak k ON k.id = p.kontrak_id
+JOIN ruangan r ON r.id = p.ruangan_id
+JOIN mata_kuliah mk ON mk.id = p.mata_kuliah_id
+JOIN users pa ON pa.id = p.pembimbing_id
+JOIN users pk ON pk.id = p.pembimbing_klinik
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('kontrak_id')::uuid IS NULL OR p.kontrak_id = sqlc.narg('kontrak_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR p.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('pembimbing_id')::uuid IS NULL OR p.pembimbing_id = sqlc.narg('pembimbing_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pembimbing_id')::uuid)
+  AND (sqlc.narg('tgl')::date IS NULL OR sqlc.narg('tgl')::date BETWEEN p.tgl_mulai AND p.tgl_selesai)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR p.user_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid)
+ORDER BY p.tgl_mulai DESC, u.nama
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountPenempatan :one
+SELECT COUNT(*)::bigint
+FROM penempatan p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('kontrak_id')::uuid IS NULL OR p.kontrak_id = sqlc.narg('kontrak_id')::uuid)
+  AND (sqlc.narg('ruangan_id')::uuid IS NULL OR p.ruangan_id = sqlc.narg('ruangan_id')::uuid)
+  AND (sqlc.narg('pembimbing_id')::uuid IS NULL OR p.pembimbing_id = sqlc.narg('pembimbing_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pembimbing_id')::uuid)
+  AND (sqlc.narg('tgl')::date IS NULL OR sqlc.narg('tgl')::date BETWEEN p.tgl_mulai AND p.tgl_selesai)
+  AND (sqlc.narg('pemanggil_id')::uuid IS NULL OR p.user_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_id = sqlc.narg('pemanggil_id')::uuid OR p.pembimbing_klinik = sqlc.narg('pemanggil_id')::uuid);
+
+-- name: UpdatePenempatan :one
+UPDATE penempatan
+SET
+  fasilitas_id      = sqlc.arg('fasilitas_id'),
+  kontrak_id        = sqlc.arg('kontrak_id'),
+  ruangan_id        = sqlc.arg('ruangan_id'),
+  mata_kuliah_id    = sqlc.arg('mata_kuliah_id'),
+  pembimbing_id     = sqlc.arg('pembimbing_id'),
+  pembimbing_klinik = sqlc.arg('pembimbing_klinik'),
+  tgl_mulai         = sqlc.arg('tgl_mulai'),
+  tgl_selesai       = sqlc.arg('tgl_selesai'),
+  is_active         = sqlc.arg('is_active'),
+  updated_by        = sqlc.narg('updated_by'),
+  updated_note      = sqlc.narg('updated_note'),
+  updated_at        = now()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeletePenempatan :execrows
+UPDATE penempatan
+SET deleted_at = now(), deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetLogbookPenempatan :one
+SELECT
+  p.id,
+  p.user_id,
+  p.pembimbing_id,
+  p.pembimbing_klinik,
+  u.username,
+  u.nama AS nama_mahasiswa,
+  f.nama AS nama_fasilitas,
+  r.nama_ruangan,
+  mk.mata_kuliah,
+  pa.nama AS nama_pembimbing,
+  pk.nama AS nama_pembimbing_klinik,
+  p.tgl_mulai,
+  p.tgl_selesai
+FROM penempatan p
+JOIN users u ON u.id = p.user_id
+JOIN fasilitas_kesehatan f ON f.id = p.fasilitas_id
+JOIN ruangan r ON r.id = p.ruangan_id
+JOIN mata_kuliah mk ON mk.id = p.mata_kuliah_id
+JOIN users pa ON pa.id = p.pembimbing_id
+JOIN users pk ON pk.id = p.pembimbing_klinik
+WHERE p.id = $1 AND p.deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListLogbookKehadiran :many
+SELECT
+  k.id,
+  k.tgl_kehadiran,
+  k.jadwal_dinas,
+  r.nama_ruangan,
+  k.presensi,
+  k.status,
+  k.created_at AS jam_masuk,
+  k.jam_pulang,
+  k.durasi_menit
+FROM kehadiran k
+JOIN ruangan r ON r.id = k.ruangan_id
+WHERE k.user_id = sqlc.arg('user_id')
+  AND k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+ORDER BY k.tgl_kehadiran, k.created_at, k.id;
+
+-- name: ListLogbookSkpDisetujui :many
+SELECT
+  ks.kehadiran_id,
+  si.nama AS nama_intervensi,
+  lv.nama AS nama_level,
+  COALESCE(rv.nama, ks.reviewed_by, '-')::text AS nama_penyetuju,
+  ks.reviewed_at
+FROM kehadiran_skp ks
+JOIN kehadiran k ON k.id = ks.kehadiran_id
+JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+LEFT JOIN skp_level lv ON lv.id = ks.level_id
+LEFT JOIN users rv ON rv.id = ks.reviewer_id
+WHERE k.user_id = sqlc.arg('user_id')
+  AND k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN sqlc.arg('tgl_awal')::date AND sqlc.arg('tgl_akhir')::date
+  AND ks.is_active = true
+  AND ks.deleted_at IS NULL
+  AND ks.status = 'disetujui'
+ORDER BY k.tgl_kehadiran, ks.reviewed_at, si.nama, ks.id;
+
+-- name: CekPembimbingMahasiswa :one
+SELECT EXISTS (
+  SELECT 1 FROM penempatan
+  WHERE user_id = sqlc.arg('user_id')
+    AND mata_kuliah_id = sqlc.arg('mata_kuliah_id')
+    AND deleted_at IS NULL
+    AND (pembimbing_id = sqlc.arg('pembimbing_id')::uuid OR pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid)
+  UNION ALL
+  SELECT 1 FROM kehadiran
+  WHERE user_id = sqlc.arg('user_id')
+    AND mata_kuliah_id = sqlc.arg('mata_kuliah_id')
+    AND deleted_at IS NULL
+    AND (pembimbing_id = sqlc.arg('pembimbing_id')::uuid OR pembimbing_klinik = sqlc.arg('pembimbing_id')::uuid)
+);
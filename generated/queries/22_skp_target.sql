@@ -0,0 +1,87 @@
+-- name: CreateSkpTarget :one
+INSERT INTO skp_target (
+  mata_kuliah_id, skp_intervensi_id, skp_subkategori_id, jumlah_target, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListSkpTarget :many
+SELECT
+  t.id,
+  t.mata_kuliah_id,
+  mk.mata_kuliah,
+  t.skp_intervensi_id,
+  si.nama AS nama_intervensi,
+  COALESCE(t.skp_subkategori_id, si.subkategori_id)::uuid AS skp_subkategori_id,
+  ss.nama AS nama_subkategori,
+  t.jumlah_target,
+  t.is_active,
+  t.created_by,
+  t.created_at
+FROM skp_target t
+JOIN mata_kuliah mk ON mk.id = t.mata_kuliah_id
+LEFT JOIN skp_intervensi si ON si.id = t.skp_intervensi_id
+JOIN skp_subkategori ss ON ss.id = COALESCE(t.skp_subkategori_id, si.subkategori_id)
+WHERE t.deleted_at IS NULL
+  AND (sqlc.narg('mata_kuliah_id')::uuid IS NULL OR t.mata_kuliah_id = sqlc.narg('mata_kuliah_id')::uuid)
+ORDER BY mk.mata_kuliah, ss.nama, si.nama NULLS FIRST
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountSkpTarget :one
+SELECT COUNT(*)::bigint
+FROM skp_target t
+WHERE t.deleted_at IS NULL
+  AND (sqlc.narg('mata_kuliah_id')::uuid IS NULL OR t.mata_kuliah_id = sqlc.narg('mata_kuliah_id')::uuid);
+
+-- name: UpdateSkpTarget :one
+UPDATE skp_target
+SET
+  jumlah_target = COALESCE(sqlc.narg('jumlah_target'), jumlah_target),
+  is_active     = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_by    = sqlc.narg('updated_by'),
+  updated_note  = sqlc.narg('updated_note'),
+  updated_at    = now()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteSkpTarget :exec
+UPDATE skp_target
+SET deleted_at = now(), deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetProgresSkp :many
+SELECT
+  t.id AS target_id,
+  t.skp_intervensi_id,
+  si.nama AS nama_intervensi,
+  ss.id AS skp_subkategori_id,
+  ss.nama AS nama_subkategori,
+  t.jumlah_target,
+  COALESCE(c.jumlah, 0)::bigint AS jumlah_tercapai
+FROM skp_target t
+LEFT JOIN skp_intervensi si ON si.id = t.skp_intervensi_id
+JOIN skp_subkategori ss ON ss.id = COALESCE(t.skp_subkategori_id, si.subkategori_id)
+LEFT JOIN LATERAL (
+  SELECT COUNT(*) AS jumlah
+  FROM kehadiran_skp ks
+  JOIN kehadiran k ON k.id = ks.kehadiran_id
+  JOIN skp_intervensi i ON i.id = ks.skp_intervensi_id
+  LEFT JOIN skp_level lv ON lv.id = ks.level_id
+  LEFT JOIN skp_level lm ON lm.id = i.level_minimal_id
+  WHERE ks.user_id = sqlc.arg('user_id')
+    AND k.mata_kuliah_id = t.mata_kuliah_id
+    AND ks.status = 'disetujui'
+    AND (i.level_minimal_id IS NULL OR lv.urutan >= lm.urutan)
+    AND ks.is_active = TRUE
+    AND ks.deleted_at IS NULL
+    AND k.is_active = TRUE
+    AND k.deleted_at IS NULL
+    AND (COALESCE(i.akar_id, i.id) = COALESCE(si.akar_id, si.id)
+         OR (t.skp_intervensi_id IS NULL AND i.subkategori_id = t.skp_subkategori_id))
+) c ON true
+WHERE t.mata_kuliah_id = sqlc.arg('mata_kuliah_id')
+  AND t.is_active = TRUE
+  AND t.deleted_at IS NULL
+ORDER BY ss.nama, si.nama NULLS FIRST;
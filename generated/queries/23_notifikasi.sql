@@ -0,0 +1,29 @@
+-- name: CreateNotifikasi :execrows
+INSERT INTO notifikasi (
+  user_id, jenis, judul, pesan, ref_id, created_by
+)
+SELECT DISTINCT u.user_id, sqlc.arg('jenis')::varchar, sqlc.arg('judul')::varchar, sqlc.arg('pesan')::text, sqlc.narg('ref_id')::uuid, sqlc.narg('created_by')::varchar
+FROM unnest(sqlc.arg('user_ids')::uuid[]) AS u(user_id);
+
+-- name: ListNotifikasi :many
+SELECT * FROM notifikasi
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('belum_dibaca')::boolean IS NULL
+       OR (dibaca_at IS NULL) = sqlc.narg('belum_dibaca')::boolean)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountNotifikasi :one
+SELECT COUNT(*)::bigint
+FROM notifikasi
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('belum_dibaca')::boolean IS NULL
+       OR (dibaca_at IS NULL) = sqlc.narg('belum_dibaca')::boolean);
+
+-- name: TandaiNotifikasiDibaca :execrows
+UPDATE notifikasi
+SET dibaca_at = now()
+WHERE user_id = sqlc.arg('user_id')
+  AND dibaca_at IS NULL
+  AND (sqlc.narg('id')::uuid IS NULL OR id = sqlc.narg('id')::uuid);
@@ -0,0 +1,83 @@
+-- name: CekPembimbingKontrak :one
+SELECT EXISTS (
+  SELECT 1 FROM pembimbing_klinik
+  WHERE kontrak_id = $1
+    AND user_id = $2
+    AND is_active = TRUE
+    AND deleted_at IS NULL
+);
+
+-- name: CekDelegasiBentrok :one
+SELECT EXISTS (
+  SELECT 1 FROM delegasi_pembimbing
+  WHERE kontrak_id = sqlc.arg('kontrak_id')
+    AND pembimbing_id = sqlc.arg('pembimbing_id')
+    AND dicabut_at IS NULL
+    AND tgl_mulai <= sqlc.arg('tgl_selesai')::date
+    AND tgl_selesai >= sqlc.arg('tgl_mulai')::date
+);
+
+-- name: CreateDelegasiPembimbing :one
+INSERT INTO delegasi_pembimbing (
+  kontrak_id, pembimbing_id, delegasi_id, tgl_mulai, tgl_selesai, alasan, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetDelegasiPembimbing :one
+SELECT * FROM delegasi_pembimbing
+WHERE id = $1
+LIMIT 1;
+
+-- name: CabutDelegasiPembimbing :one
+UPDATE delegasi_pembimbing
+SET dicabut_by = sqlc.narg('dicabut_by'),
+    dicabut_at = now()
+WHERE id = sqlc.arg('id')
+  AND dicabut_at IS NULL
+RETURNING *;
+
+-- name: GetDelegasiAktif :one
+SELECT * FROM delegasi_pembimbing
+WHERE kontrak_id = sqlc.arg('kontrak_id')
+  AND pembimbing_id = sqlc.arg('pembimbing_id')
+  AND delegasi_id = sqlc.arg('delegasi_id')
+  AND dicabut_at IS NULL
+  AND CURRENT_DATE BETWEEN tgl_mulai AND tgl_selesai
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListDelegasiPembimbing :many
+SELECT
+  d.id,
+  d.kontrak_id,
+  d.pembimbing_id,
+  p.nama AS nama_pembimbing,
+  d.delegasi_id,
+  dl.nama AS nama_delegasi,
+  d.tgl_mulai,
+  d.tgl_selesai,
+  d.alasan,
+  d.dicabut_by,
+  d.dicabut_at,
+  d.created_by,
+  d.created_at
+FROM delegasi_pembimbing d
+JOIN users p ON p.id = d.pembimbing_id
+JOIN users dl ON dl.id = d.delegasi_id
+WHERE (d.pembimbing_id = sqlc.arg('user_id') OR d.delegasi_id = sqlc.arg('user_id'))
+  AND (sqlc.narg('kontrak_id')::uuid IS NULL OR d.kontrak_id = sqlc.narg('kontrak_id')::uuid)
+  AND (sqlc.narg('aktif')::boolean IS NULL
+       OR (d.dicabut_at IS NULL AND d.tgl_selesai >= CURRENT_DATE) = sqlc.narg('aktif')::boolean)
+ORDER BY d.tgl_mulai DESC, d.created_at DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountDelegasiPembimbing :one
+SELECT COUNT(*)::bigint
+FROM delegasi_pembimbing d
+WHERE (d.pembimbing_id = sqlc.arg('user_id') OR d.delegasi_id = sqlc.arg('user_id'))
+  AND (sqlc.narg('kontrak_id')::uuid IS NULL OR d.kontrak_id = sqlc.narg('kontrak_id')::uuid)
+  AND (sqlc.narg('aktif')::boolean IS NULL
+       OR (d.dicabut_at IS NULL AND d.tgl_selesai >= CURRENT_DATE) = sqlc.narg('aktif')::boolean);
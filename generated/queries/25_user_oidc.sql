@@ -0,0 +1,35 @@
+-- name: GetUserOidc :one
+SELECT u.id, u.username
+FROM user_oidc o
+JOIN users u ON u.id = o.user_id
+WHERE o.issuer = $1
+  AND o.subject = $2
+  AND u.deleted_at IS NULL
+LIMIT 1;
+
+-- name: CariUserOidc :one
+SELECT id, username
+FROM users
+WHERE deleted_at IS NULL
+  AND (username = sqlc.narg('username')::text
+       OR lower(email) = lower(sqlc.narg('email')::text))
+ORDER BY (username IS NOT DISTINCT FROM sqlc.narg('username')::text) DESC
+LIMIT 1;
+
+-- name: CreateUserSso :one
+INSERT INTO users (
+  nama, username, password, email, created_by
+) VALUES (
+  $1, $2, '', $3, $4
+)
+RETURNING id, username;
+
+-- name: TautkanUserOidc :exec
+INSERT INTO user_oidc (
+  user_id, issuer, subject, email, last_login_at
+) VALUES (
+  $1, $2, $3, $4, now()
+)
+ON CONFLICT (issuer, subject) DO UPDATE
+SET email = EXCLUDED.email,
+    last_login_at = now();
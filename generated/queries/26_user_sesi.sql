@@ -0,0 +1,47 @@
+-- name: CreateUserSesi :one
+INSERT INTO user_sesi (
+  id, user_id, refresh_hash, user_agent, ip_address, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetUserSesi :one
+SELECT * FROM user_sesi
+WHERE id = $1
+LIMIT 1;
+
+-- name: RotasiUserSesi :execrows
+UPDATE user_sesi
+SET refresh_hash = sqlc.arg('refresh_hash_baru'),
+    expires_at = sqlc.arg('expires_at'),
+    user_agent = COALESCE(sqlc.narg('user_agent'), user_agent),
+    ip_address = COALESCE(sqlc.narg('ip_address'), ip_address),
+    last_used_at = now()
+WHERE id = sqlc.arg('id')
+  AND refresh_hash = sqlc.arg('refresh_hash')
+  AND revoked_at IS NULL;
+
+-- name: CabutUserSesi :execrows
+UPDATE user_sesi
+SET revoked_at = now(),
+    revoked_reason = sqlc.narg('revoked_reason')
+WHERE id = sqlc.arg('id')
+  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id')::uuid)
+  AND revoked_at IS NULL;
+
+-- name: ListUserSesiAktif :many
+SELECT id, user_agent, ip_address, created_at, last_used_at, expires_at
+FROM user_sesi
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > now()
+ORDER BY last_used_at DESC;
+
+-- name: CabutSemuaUserSesi :many
+UPDATE user_sesi
+SET revoked_at = now(),
+    revoked_reason = sqlc.narg('revoked_reason')
+WHERE user_id = sqlc.arg('user_id')
+  AND revoked_at IS NULL
+RETURNING id;
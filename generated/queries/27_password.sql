@@ -0,0 +1,53 @@
+-- name: GetPasswordUser :one
+SELECT password FROM users
+WHERE id = $1
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListRiwayatPassword :many
+SELECT password FROM riwayat_password
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CreateRiwayatPassword :exec
+INSERT INTO riwayat_password (
+  user_id, password
+) VALUES (
+  $1, $2
+);
+
+-- name: GantiPasswordUser :execrows
+UPDATE users
+SET password = sqlc.arg('password'),
+    wajib_ganti_password = sqlc.arg('wajib_ganti_password'),
+    password_changed_at = now(),
+    failed_attempts = 0,
+    last_failed_at = NULL,
+    locked_until = NULL,
+    updated_by = sqlc.narg('updated_by'),
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+  AND deleted_at IS NULL;
+
+-- name: BatalkanResetPassword :exec
+UPDATE reset_password
+SET used_at = now()
+WHERE user_id = $1
+  AND used_at IS NULL;
+
+-- name: CreateResetPassword :one
+INSERT INTO reset_password (
+  user_id, token_hash, expires_at, created_by
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, expires_at;
+
+-- name: PakaiResetPassword :one
+UPDATE reset_password
+SET used_at = now()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > now()
+RETURNING user_id;
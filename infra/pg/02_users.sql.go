@@ -12,6 +12,64 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const adminAktifTerakhir = `-- name: AdminAktifTerakhir :one
+SELECT (
+  EXISTS (
+    SELECT 1 FROM r5_user_roles uur
+    JOIN r4_roles r ON r.id = uur.role_id AND r.deleted_at IS NULL
+    WHERE uur.user_id = $1
+      AND uur.deleted_at IS NULL
+      AND lower(r.tag) = lower($2)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
+    JOIN r4_roles r ON r.id = uur.role_id AND r.deleted_at IS NULL
+    WHERE lower(r.tag) = lower($2)
+      AND u.id <> $1
+      AND u.is_active
+      AND u.deleted_at IS NULL
+  )
+)::bool AS terakhir
+`
+
+type AdminAktifTerakhirParams struct {
+	ID  uuid.UUID `json:"id"`
+	Tag string    `json:"tag"`
+}
+
+func (q *Queries) AdminAktifTerakhir(ctx context.Context, arg AdminAktifTerakhirParams) (bool, error) {
+	row := q.db.QueryRow(ctx, adminAktifTerakhir, arg.ID, arg.Tag)
+	var terakhir bool
+	err := row.Scan(&terakhir)
+	return terakhir, err
+}
+
+const catatGagalLogin = `-- name: CatatGagalLogin :one
+UPDATE users
+SET failed_attempts = CASE
+      WHEN last_failed_at IS NULL
+        OR GREATEST(last_failed_at, COALESCE(locked_until, last_failed_at)) < now() - make_interval(mins => $1::int)
+      THEN 1
+      ELSE COALESCE(failed_attempts, 0) + 1
+    END,
+    last_failed_at = now()
+WHERE id = $2
+RETURNING failed_attempts
+`
+
+type CatatGagalLoginParams struct {
+	JendelaMenit int32     `json:"jendela_menit"`
+	ID           uuid.UUID `json:"id"`
+}
+
+func (q *Queries) CatatGagalLogin(ctx context.Context, arg CatatGagalLoginParams) (*int32, error) {
+	row := q.db.QueryRow(ctx, catatGagalLogin, arg.JendelaMenit, arg.ID)
+	var failedAttempts *int32
+	err := row.Scan(&failedAttempts)
+	return failedAttempts, err
+}
+
 const countDistinctUserKehadiran = `-- name: CountDistinctUserKehadiran :one
 SELECT COUNT(DISTINCT k.user_id) AS total
 FROM kehadiran k
@@ -84,49 +142,35 @@ func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64,
 	return column_1, err
 }
 
-const createOrUpdateUser = `-- name: CreateOrUpdateUser :one
+const createUser = `-- name: CreateUser :one
 INSERT INTO users (
   nama,
   username,
-  password
+  password,
+  created_by,
+  wajib_ganti_password,
+  password_changed_at
 ) VALUES (
-  $1,$2,$3
-) ON CONFLICT (username) DO UPDATE SET 
-nama = $1,
-password = $3
-RETURNING id, nama, username, password, last_active, is_active, locked_until, failed_attempts, last_failed_at, refresh, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, CASE WHEN xmax = 0 THEN 'inserted' ELSE 'updated' END as operation
+  $1, $2, $3, $4, true, now()
+)
+RETURNING id, nama, username, password, last_active, is_active, locked_until, failed_attempts, last_failed_at, refresh, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, email, wajib_ganti_password, password_changed_at
 `
 
-type CreateOrUpdateUserParams struct {
-	Nama     string `json:"nama"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-}
-
-type CreateOrUpdateUserRow struct {
-	ID             uuid.UUID          `json:"id"`
-	Nama           string             `json:"nama"`
-	Username       string             `json:"username"`
-	Password       string             `json:"password"`
-	LastActive     pgtype.Timestamptz `json:"last_active"`
-	IsActive       bool               `json:"is_active"`
-	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
-	FailedAttempts *int32             `json:"failed_attempts"`
-	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
-	Refresh        *string            `json:"refresh"`
-	DeletedBy      *string            `json:"deleted_by"`
-	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
-	UpdatedNote    *string            `json:"updated_note"`
-	UpdatedBy      *string            `json:"updated_by"`
-	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
-	CreatedBy      *string            `json:"created_by"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	Operation      string             `json:"operation"`
+type CreateUserParams struct {
+	Nama      string  `json:"nama"`
+	Username  string  `json:"username"`
+	Password  string  `json:"password"`
+	CreatedBy *string `json:"created_by"`
 }
 
-func (q *Queries) CreateOrUpdateUser(ctx context.Context, arg CreateOrUpdateUserParams) (CreateOrUpdateUserRow, error) {
-	row := q.db.QueryRow(ctx, createOrUpdateUser, arg.Nama, arg.Username, arg.Password)
-	var i CreateOrUpdateUserRow
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Nama,
+		arg.Username,
+		arg.Password,
+		arg.CreatedBy,
+	)
+	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.Nama,
@@ -145,7 +189,9 @@ func (q *Queries) CreateOrUpdateUser(ctx context.Context, arg CreateOrUpdateUser
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.CreatedAt,
-		&i.Operation,
+		&i.Email,
+		&i.WajibGantiPassword,
+		&i.PasswordChangedAt,
 	)
 	return i, err
 }
@@ -190,7 +236,7 @@ func (q *Queries) GetUserActiveStatus(ctx context.Context, username string) (boo
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, nama, username, password, last_active, is_active, locked_until, failed_attempts, last_failed_at, refresh, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at FROM users
+SELECT id, nama, username, password, last_active, is_active, locked_until, failed_attempts, last_failed_at, refresh, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, email, wajib_ganti_password, password_changed_at FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -215,6 +261,9 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Email,
+		&i.WajibGantiPassword,
+		&i.PasswordChangedAt,
 	)
 	return i, err
 }
@@ -311,6 +360,61 @@ func (q *Queries) GetUsersByRoles(ctx context.Context, roleIds []int32) ([]GetUs
 	return items, nil
 }
 
+const getUsersByUsernames = `-- name: GetUsersByUsernames :many
+SELECT id, username, nama, is_active
+FROM users
+WHERE username = ANY($1::text[])
+  AND deleted_at IS NULL
+`
+
+type GetUsersByUsernamesRow struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Nama     string    `json:"nama"`
+	IsActive bool      `json:"is_active"`
+}
+
+func (q *Queries) GetUsersByUsernames(ctx context.Context, usernames []string) ([]GetUsersByUsernamesRow, error) {
+	rows, err := q.db.Query(ctx, getUsersByUsernames, usernames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUsersByUsernamesRow{}
+	for rows.Next() {
+		var i GetUsersByUsernamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Nama,
+			&i.IsActive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const kunciUser = `-- name: KunciUser :exec
+UPDATE users
+SET locked_until = $1
+WHERE id = $2
+`
+
+type KunciUserParams struct {
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	ID          uuid.UUID          `json:"id"`
+}
+
+func (q *Queries) KunciUser(ctx context.Context, arg KunciUserParams) error {
+	_, err := q.db.Exec(ctx, kunciUser, arg.LockedUntil, arg.ID)
+	return err
+}
+
 const listDistinctUserKehadiran = `-- name: ListDistinctUserKehadiran :many
 SELECT DISTINCT
   u.id AS user_id,
@@ -495,6 +599,30 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 	return items, nil
 }
 
+const resetGagalLogin = `-- name: ResetGagalLogin :execrows
+UPDATE users
+SET failed_attempts = 0,
+    last_failed_at  = NULL,
+    locked_until    = NULL,
+    updated_by      = COALESCE($1, updated_by),
+    updated_at      = CASE WHEN $1::varchar IS NULL THEN updated_at ELSE now() END
+WHERE id = $2
+  AND deleted_at IS NULL
+`
+
+type ResetGagalLoginParams struct {
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) ResetGagalLogin(ctx context.Context, arg ResetGagalLoginParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resetGagalLogin, arg.UpdatedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const softDelUser = `-- name: SoftDelUser :exec
 UPDATE users SET deleted_at = NOW() WHERE username = $1
 `
@@ -523,8 +651,8 @@ UPDATE users
 SET 
     nama        = COALESCE($1, nama),
     is_active   = COALESCE($2, is_active),
-    password    = COALESCE($3, password),
-    refresh     = COALESCE($4, refresh),
+    refresh     = COALESCE($3, refresh),
+    email       = COALESCE($4, email),
     updated_note= COALESCE($5, updated_note),
     updated_by  = COALESCE($6, updated_by),
     updated_at  = now()
@@ -534,8 +662,8 @@ WHERE id = $7
 type UpdateUserPartialParams struct {
 	Nama        *string   `json:"nama"`
 	IsActive    *bool     `json:"is_active"`
-	Password    *string   `json:"password"`
 	Refresh     *string   `json:"refresh"`
+	Email       *string   `json:"email"`
 	UpdatedNote *string   `json:"updated_note"`
 	UpdatedBy   *string   `json:"updated_by"`
 	ID          uuid.UUID `json:"id"`
@@ -545,8 +673,8 @@ func (q *Queries) UpdateUserPartial(ctx context.Context, arg UpdateUserPartialPa
 	_, err := q.db.Exec(ctx, updateUserPartial,
 		arg.Nama,
 		arg.IsActive,
-		arg.Password,
 		arg.Refresh,
+		arg.Email,
 		arg.UpdatedNote,
 		arg.UpdatedBy,
 		arg.ID,
@@ -560,6 +688,7 @@ SELECT u.id,
        u.password,
 	     u.nama,
        u.refresh,
+       u.wajib_ganti_password,
        COALESCE(string_agg(DISTINCT ur.tag, ', '), '')::text AS role
        FROM users u
 LEFT JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
@@ -570,12 +699,13 @@ GROUP BY u.id, u.username, u.password, u.nama
 `
 
 type UsersFindByIdRow struct {
-	ID       uuid.UUID `json:"id"`
-	Username string    `json:"username"`
-	Password string    `json:"password"`
-	Nama     string    `json:"nama"`
-	Refresh  *string   `json:"refresh"`
-	Role     string    `json:"role"`
+	ID                 uuid.UUID `json:"id"`
+	Username           string    `json:"username"`
+	Password           string    `json:"password"`
+	Nama               string    `json:"nama"`
+	Refresh            *string   `json:"refresh"`
+	WajibGantiPassword bool      `json:"wajib_ganti_password"`
+	Role               string    `json:"role"`
 }
 
 func (q *Queries) UsersFindById(ctx context.Context, id uuid.UUID) (UsersFindByIdRow, error) {
@@ -587,6 +717,7 @@ func (q *Queries) UsersFindById(ctx context.Context, id uuid.UUID) (UsersFindByI
 		&i.Password,
 		&i.Nama,
 		&i.Refresh,
+		&i.WajibGantiPassword,
 		&i.Role,
 	)
 	return i, err
@@ -598,6 +729,10 @@ SELECT
     u.username,
     u.password,
     u.nama,
+    u.locked_until,
+    u.failed_attempts,
+    u.is_active,
+    u.wajib_ganti_password,
     COALESCE(string_agg(DISTINCT ur.tag, ', '), '')::text AS role
     
 FROM users u
@@ -605,15 +740,19 @@ LEFT JOIN r5_user_roles uur ON uur.user_id = u.id AND uur.deleted_at IS NULL
 LEFT JOIN r4_roles ur ON ur.id = uur.role_id AND ur.deleted_at IS NULL
 WHERE u.username = $1
   AND u.deleted_at IS NULL
-GROUP BY u.id, u.username, u.password, u.nama
+GROUP BY u.id, u.username, u.password, u.nama, u.locked_until, u.failed_attempts, u.is_active, u.wajib_ganti_password
 `
 
 type UsersFindByUsernameRow struct {
-	ID       uuid.UUID `json:"id"`
-	Username string    `json:"username"`
-	Password string    `json:"password"`
-	Nama     string    `json:"nama"`
-	Role     string    `json:"role"`
+	ID                 uuid.UUID          `json:"id"`
+	Username           string             `json:"username"`
+	Password           string             `json:"password"`
+	Nama               string             `json:"nama"`
+	LockedUntil        pgtype.Timestamptz `json:"locked_until"`
+	FailedAttempts     *int32             `json:"failed_attempts"`
+	IsActive           bool               `json:"is_active"`
+	WajibGantiPassword bool               `json:"wajib_ganti_password"`
+	Role               string             `json:"role"`
 }
 
 func (q *Queries) UsersFindByUsername(ctx context.Context, username string) (UsersFindByUsernameRow, error) {
@@ -624,6 +763,10 @@ func (q *Queries) UsersFindByUsername(ctx context.Context, username string) (Use
 		&i.Username,
 		&i.Password,
 		&i.Nama,
+		&i.LockedUntil,
+		&i.FailedAttempts,
+		&i.IsActive,
+		&i.WajibGantiPassword,
 		&i.Role,
 	)
 	return i, err
@@ -188,6 +188,26 @@ func (q *Queries) GetFasilitasKesehatan(ctx context.Context, id uuid.UUID) (Fasi
 	return i, err
 }
 
+const getKoordinatFasilitas = `-- name: GetKoordinatFasilitas :one
+SELECT id, latitude, longitude
+FROM fasilitas_kesehatan
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetKoordinatFasilitasRow struct {
+	ID        uuid.UUID `json:"id"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+}
+
+func (q *Queries) GetKoordinatFasilitas(ctx context.Context, id uuid.UUID) (GetKoordinatFasilitasRow, error) {
+	row := q.db.QueryRow(ctx, getKoordinatFasilitas, id)
+	var i GetKoordinatFasilitasRow
+	err := row.Scan(&i.ID, &i.Latitude, &i.Longitude)
+	return i, err
+}
+
 const listDistinctKabupaten = `-- name: ListDistinctKabupaten :many
 SELECT id,nama
 FROM kabupaten
@@ -35,6 +35,110 @@ func (q *Queries) CheckKehadiran(ctx context.Context, userID uuid.UUID) (CheckKe
 	return i, err
 }
 
+const checkoutKehadiran = `-- name: CheckoutKehadiran :one
+UPDATE kehadiran
+SET
+  jam_pulang   = $1,
+  durasi_menit = $2,
+  pulang_awal  = $3,
+  updated_by   = $4,
+  updated_at   = now()
+WHERE id = $5
+  AND jam_pulang IS NULL
+  AND deleted_at IS NULL
+RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id
+`
+
+type CheckoutKehadiranParams struct {
+	JamPulang   pgtype.Timestamptz `json:"jam_pulang"`
+	DurasiMenit *int32             `json:"durasi_menit"`
+	PulangAwal  *bool              `json:"pulang_awal"`
+	UpdatedBy   *string            `json:"updated_by"`
+	ID          uuid.UUID          `json:"id"`
+}
+
+func (q *Queries) CheckoutKehadiran(ctx context.Context, arg CheckoutKehadiranParams) (Kehadiran, error) {
+	row := q.db.QueryRow(ctx, checkoutKehadiran,
+		arg.JamPulang,
+		arg.DurasiMenit,
+		arg.PulangAwal,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	var i Kehadiran
+	err := row.Scan(
+		&i.ID,
+		&i.FasilitasID,
+		&i.KontrakID,
+		&i.RuanganID,
+		&i.MataKuliahID,
+		&i.PembimbingID,
+		&i.PembimbingKlinik,
+		&i.JadwalDinas,
+		&i.UserID,
+		&i.IsActive,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.TglKehadiran,
+		&i.Presensi,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
+	)
+	return i, err
+}
+
+const countJadwalAlpaTanpaPenempatan = `-- name: CountJadwalAlpaTanpaPenempatan :one
+SELECT COUNT(*)::bigint
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+WHERE j.tgl_dinas BETWEEN $1::date AND $2::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM penempatan pn
+    WHERE pn.user_id = j.user_id
+      AND j.tgl_dinas BETWEEN pn.tgl_mulai AND pn.tgl_selesai
+      AND pn.is_active = true
+      AND pn.deleted_at IS NULL
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM kehadiran k
+    WHERE k.user_id = j.user_id
+      AND k.tgl_kehadiran = j.tgl_dinas
+      AND k.deleted_at IS NULL
+  )
+  AND ((j.tgl_dinas + s.jam_selesai)
+       + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+      ) AT TIME ZONE 'Asia/Jakarta' <= $3::timestamptz
+`
+
+type CountJadwalAlpaTanpaPenempatanParams struct {
+	TglAwal      pgtype.Date        `json:"tgl_awal"`
+	TglAkhir     pgtype.Date        `json:"tgl_akhir"`
+	BatasSelesai pgtype.Timestamptz `json:"batas_selesai"`
+}
+
+func (q *Queries) CountJadwalAlpaTanpaPenempatan(ctx context.Context, arg CountJadwalAlpaTanpaPenempatanParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countJadwalAlpaTanpaPenempatan, arg.TglAwal, arg.TglAkhir, arg.BatasSelesai)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const countKehadiran = `-- name: CountKehadiran :one
 SELECT COUNT(*)::bigint
 FROM kehadiran
@@ -70,13 +174,17 @@ func (q *Queries) CountKehadiran(ctx context.Context, arg CountKehadiranParams)
 const createKehadiran = `-- name: CreateKehadiran :one
 INSERT INTO kehadiran (
   fasilitas_id, kontrak_id, ruangan_id, pembimbing_id,user_id,pembimbing_klinik,mata_kuliah_id,
-  jadwal_dinas, created_by, tgl_kehadiran, presensi
+  jadwal_dinas, created_by, tgl_kehadiran, presensi,
+  latitude, longitude, akurasi, jarak_meter, status_lokasi,
+  jadwal_id, penempatan_id
 ) VALUES (
   $1, $2, $3, $4,
-  $5,$6, $7,$8,$9,$10,$11
+  $5,$6, $7,$8,$9,$10,$11,
+  $12, $13, $14, $15, $16,
+  $17, $18
 )
 ON CONFLICT (user_id, tgl_kehadiran) DO NOTHING
-RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at
+RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id
 `
 
 type CreateKehadiranParams struct {
@@ -91,6 +199,13 @@ type CreateKehadiranParams struct {
 	CreatedBy        *string     `json:"created_by"`
 	TglKehadiran     pgtype.Date `json:"tgl_kehadiran"`
 	Presensi         string      `json:"presensi"`
+	Latitude         *float64    `json:"latitude"`
+	Longitude        *float64    `json:"longitude"`
+	Akurasi          *float64    `json:"akurasi"`
+	JarakMeter       *float64    `json:"jarak_meter"`
+	StatusLokasi     *string     `json:"status_lokasi"`
+	JadwalID         *uuid.UUID  `json:"jadwal_id"`
+	PenempatanID     *uuid.UUID  `json:"penempatan_id"`
 }
 
 func (q *Queries) CreateKehadiran(ctx context.Context, arg CreateKehadiranParams) (Kehadiran, error) {
@@ -106,6 +221,13 @@ func (q *Queries) CreateKehadiran(ctx context.Context, arg CreateKehadiranParams
 		arg.CreatedBy,
 		arg.TglKehadiran,
 		arg.Presensi,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Akurasi,
+		arg.JarakMeter,
+		arg.StatusLokasi,
+		arg.JadwalID,
+		arg.PenempatanID,
 	)
 	var i Kehadiran
 	err := row.Scan(
@@ -129,6 +251,16 @@ func (q *Queries) CreateKehadiran(ctx context.Context, arg CreateKehadiranParams
 		&i.Status,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
 	)
 	return i, err
 }
@@ -149,8 +281,125 @@ func (q *Queries) DeleteKehadiran(ctx context.Context, arg DeleteKehadiranParams
 	return err
 }
 
+const generateAlpaKehadiran = `-- name: GenerateAlpaKehadiran :execrows
+INSERT INTO kehadiran (
+  fasilitas_id, kontrak_id, ruangan_id, pembimbing_id, user_id, pembimbing_klinik, mata_kuliah_id,
+  jadwal_dinas, jadwal_id, created_by, tgl_kehadiran, presensi, penempatan_id
+)
+SELECT
+  p.fasilitas_id,
+  p.kontrak_id,
+  COALESCE(j.ruangan_id, p.ruangan_id),
+  p.pembimbing_id,
+  p.user_id,
+  p.pembimbing_klinik,
+  p.mata_kuliah_id,
+  s.kode,
+  j.id,
+  $1::text,
+  t.tgl::date,
+  'alpa',
+  p.id
+FROM penempatan p
+CROSS JOIN LATERAL generate_series(
+  GREATEST(p.tgl_mulai, $2::date),
+  LEAST(p.tgl_selesai, $3::date),
+  INTERVAL '1 day'
+) AS t(tgl)
+LEFT JOIN jadwal_dinas_mahasiswa j
+  ON j.user_id = p.user_id
+  AND j.tgl_dinas = t.tgl::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+LEFT JOIN shift s ON s.id = j.shift_id
+WHERE p.is_active = true
+  AND p.deleted_at IS NULL
+  AND p.tgl_mulai <= $3::date
+  AND p.tgl_selesai >= $2::date
+  AND (
+    (j.id IS NOT NULL AND ((j.tgl_dinas + s.jam_selesai)
+         + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+        ) AT TIME ZONE 'Asia/Jakarta' <= $4::timestamptz)
+    OR (
+      j.id IS NULL
+      AND $5::bool
+      AND NOT EXISTS (
+        SELECT 1 FROM jadwal_dinas_mahasiswa x
+        WHERE x.user_id = p.user_id
+          AND x.tgl_dinas BETWEEN p.tgl_mulai AND p.tgl_selesai
+          AND x.is_active = true
+          AND x.deleted_at IS NULL
+      )
+      AND (t.tgl::date + 1)::timestamp AT TIME ZONE 'Asia/Jakarta' <= $4::timestamptz
+    )
+  )
+UNION ALL
+SELECT
+  r.fasilitas_id,
+  r.kontrak_id,
+  j.ruangan_id,
+  prev.pembimbing_id,
+  j.user_id,
+  prev.pembimbing_klinik,
+  prev.mata_kuliah_id,
+  s.kode,
+  j.id,
+  $1::text,
+  j.tgl_dinas,
+  'alpa',
+  NULL::uuid
+FROM jadwal_dinas_mahasiswa j
+JOIN shift s ON s.id = j.shift_id
+JOIN ruangan r ON r.id = j.ruangan_id
+JOIN LATERAL (
+  SELECT k.pembimbing_id, k.pembimbing_klinik, k.mata_kuliah_id
+  FROM kehadiran k
+  WHERE k.user_id = j.user_id
+    AND k.kontrak_id = r.kontrak_id
+    AND k.deleted_at IS NULL
+  ORDER BY k.tgl_kehadiran DESC
+  LIMIT 1
+) prev ON true
+WHERE j.tgl_dinas BETWEEN $2::date AND $3::date
+  AND j.is_active = true
+  AND j.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM penempatan pn
+    WHERE pn.user_id = j.user_id
+      AND j.tgl_dinas BETWEEN pn.tgl_mulai AND pn.tgl_selesai
+      AND pn.is_active = true
+      AND pn.deleted_at IS NULL
+  )
+  AND ((j.tgl_dinas + s.jam_selesai)
+       + CASE WHEN s.jam_selesai <= s.jam_mulai THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+      ) AT TIME ZONE 'Asia/Jakarta' <= $4::timestamptz
+ON CONFLICT ON CONSTRAINT uq_kehadiran_per_hari DO NOTHING
+`
+
+type GenerateAlpaKehadiranParams struct {
+	CreatedBy    *string            `json:"created_by"`
+	TglAwal      pgtype.Date        `json:"tgl_awal"`
+	TglAkhir     pgtype.Date        `json:"tgl_akhir"`
+	BatasSelesai pgtype.Timestamptz `json:"batas_selesai"`
+	TanpaJadwal  bool               `json:"tanpa_jadwal"`
+}
+
+func (q *Queries) GenerateAlpaKehadiran(ctx context.Context, arg GenerateAlpaKehadiranParams) (int64, error) {
+	result, err := q.db.Exec(ctx, generateAlpaKehadiran,
+		arg.CreatedBy,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.BatasSelesai,
+		arg.TanpaJadwal,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getKehadiran = `-- name: GetKehadiran :one
-SELECT id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at FROM kehadiran
+SELECT id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id FROM kehadiran
 WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -179,6 +428,70 @@ func (q *Queries) GetKehadiran(ctx context.Context, id uuid.UUID) (Kehadiran, er
 		&i.Status,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
+	)
+	return i, err
+}
+
+const getKehadiranBelumPulang = `-- name: GetKehadiranBelumPulang :one
+SELECT id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id FROM kehadiran
+WHERE user_id = $1
+  AND presensi = 'hadir'
+  AND jam_pulang IS NULL
+  AND deleted_at IS NULL
+  AND tgl_kehadiran >= $2
+ORDER BY tgl_kehadiran DESC
+LIMIT 1
+`
+
+type GetKehadiranBelumPulangParams struct {
+	UserID  uuid.UUID   `json:"user_id"`
+	TglAwal pgtype.Date `json:"tgl_awal"`
+}
+
+func (q *Queries) GetKehadiranBelumPulang(ctx context.Context, arg GetKehadiranBelumPulangParams) (Kehadiran, error) {
+	row := q.db.QueryRow(ctx, getKehadiranBelumPulang, arg.UserID, arg.TglAwal)
+	var i Kehadiran
+	err := row.Scan(
+		&i.ID,
+		&i.FasilitasID,
+		&i.KontrakID,
+		&i.RuanganID,
+		&i.MataKuliahID,
+		&i.PembimbingID,
+		&i.PembimbingKlinik,
+		&i.JadwalDinas,
+		&i.UserID,
+		&i.IsActive,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.TglKehadiran,
+		&i.Presensi,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
 	)
 	return i, err
 }
@@ -296,6 +609,53 @@ func (q *Queries) GetMahasiswaTidakHadir(ctx context.Context, arg GetMahasiswaTi
 	return items, nil
 }
 
+const getPembimbingEkspor = `-- name: GetPembimbingEkspor :many
+SELECT DISTINCT p.nama
+FROM kehadiran k
+JOIN users p ON p.id = k.pembimbing_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN $1::date AND $2::date
+  AND ($3::uuid IS NULL OR k.user_id = $3::uuid)
+  AND ($4::uuid IS NULL OR k.ruangan_id = $4::uuid)
+  AND ($5::uuid IS NULL OR k.fasilitas_id = $5::uuid)
+ORDER BY p.nama
+`
+
+type GetPembimbingEksporParams struct {
+	TglAwal     pgtype.Date `json:"tgl_awal"`
+	TglAkhir    pgtype.Date `json:"tgl_akhir"`
+	UserID      *uuid.UUID  `json:"user_id"`
+	RuanganID   *uuid.UUID  `json:"ruangan_id"`
+	FasilitasID *uuid.UUID  `json:"fasilitas_id"`
+}
+
+func (q *Queries) GetPembimbingEkspor(ctx context.Context, arg GetPembimbingEksporParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getPembimbingEkspor,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.UserID,
+		arg.RuanganID,
+		arg.FasilitasID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var nama string
+		if err := rows.Scan(&nama); err != nil {
+			return nil, err
+		}
+		items = append(items, nama)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRekapDetailFasilitasHarian = `-- name: GetRekapDetailFasilitasHarian :many
 SELECT
   r.id AS ruangan_id,
@@ -750,13 +1110,239 @@ func (q *Queries) ListKehadiran(ctx context.Context, arg ListKehadiranParams) ([
 	return items, nil
 }
 
+const listKehadiranEkspor = `-- name: ListKehadiranEkspor :many
+SELECT
+  k.id,
+  k.tgl_kehadiran,
+  u.username,
+  u.nama AS nama_mahasiswa,
+  r.nama_ruangan,
+  f.nama AS nama_fasilitas,
+  k.jadwal_dinas,
+  k.presensi,
+  k.created_at AS jam_masuk,
+  k.jam_pulang,
+  k.durasi_menit,
+  k.pulang_awal,
+  p.nama AS nama_pembimbing
+FROM kehadiran k
+JOIN users u ON u.id = k.user_id
+JOIN ruangan r ON r.id = k.ruangan_id
+JOIN fasilitas_kesehatan f ON f.id = k.fasilitas_id
+LEFT JOIN users p ON p.id = k.pembimbing_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN $1::date AND $2::date
+  AND ($3::uuid IS NULL OR k.user_id = $3::uuid)
+  AND ($4::uuid IS NULL OR k.ruangan_id = $4::uuid)
+  AND ($5::uuid IS NULL OR k.fasilitas_id = $5::uuid)
+  AND ($6::date IS NULL
+       OR (k.tgl_kehadiran, k.id) > ($6::date, $7::uuid))
+ORDER BY k.tgl_kehadiran, k.id
+LIMIT $8
+`
+
+type ListKehadiranEksporParams struct {
+	TglAwal     pgtype.Date `json:"tgl_awal"`
+	TglAkhir    pgtype.Date `json:"tgl_akhir"`
+	UserID      *uuid.UUID  `json:"user_id"`
+	RuanganID   *uuid.UUID  `json:"ruangan_id"`
+	FasilitasID *uuid.UUID  `json:"fasilitas_id"`
+	SetelahTgl  pgtype.Date `json:"setelah_tgl"`
+	SetelahID   *uuid.UUID  `json:"setelah_id"`
+	Limit       int32       `json:"limit"`
+}
+
+type ListKehadiranEksporRow struct {
+	ID             uuid.UUID          `json:"id"`
+	TglKehadiran   pgtype.Date        `json:"tgl_kehadiran"`
+	Username       string             `json:"username"`
+	NamaMahasiswa  string             `json:"nama_mahasiswa"`
+	NamaRuangan    string             `json:"nama_ruangan"`
+	NamaFasilitas  string             `json:"nama_fasilitas"`
+	JadwalDinas    *string            `json:"jadwal_dinas"`
+	Presensi       string             `json:"presensi"`
+	JamMasuk       pgtype.Timestamptz `json:"jam_masuk"`
+	JamPulang      pgtype.Timestamptz `json:"jam_pulang"`
+	DurasiMenit    *int32             `json:"durasi_menit"`
+	PulangAwal     *bool              `json:"pulang_awal"`
+	NamaPembimbing *string            `json:"nama_pembimbing"`
+}
+
+func (q *Queries) ListKehadiranEkspor(ctx context.Context, arg ListKehadiranEksporParams) ([]ListKehadiranEksporRow, error) {
+	rows, err := q.db.Query(ctx, listKehadiranEkspor,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.UserID,
+		arg.RuanganID,
+		arg.FasilitasID,
+		arg.SetelahTgl,
+		arg.SetelahID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListKehadiranEksporRow{}
+	for rows.Next() {
+		var i ListKehadiranEksporRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TglKehadiran,
+			&i.Username,
+			&i.NamaMahasiswa,
+			&i.NamaRuangan,
+			&i.NamaFasilitas,
+			&i.JadwalDinas,
+			&i.Presensi,
+			&i.JamMasuk,
+			&i.JamPulang,
+			&i.DurasiMenit,
+			&i.PulangAwal,
+			&i.NamaPembimbing,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTanggalKehadiranUsers = `-- name: ListTanggalKehadiranUsers :many
+SELECT user_id, tgl_kehadiran
+FROM kehadiran
+WHERE user_id = ANY($1::uuid[])
+  AND tgl_kehadiran BETWEEN $2::date AND $3::date
+  AND deleted_at IS NULL
+`
+
+type ListTanggalKehadiranUsersParams struct {
+	UserIds  []uuid.UUID `json:"user_ids"`
+	TglAwal  pgtype.Date `json:"tgl_awal"`
+	TglAkhir pgtype.Date `json:"tgl_akhir"`
+}
+
+type ListTanggalKehadiranUsersRow struct {
+	UserID       uuid.UUID   `json:"user_id"`
+	TglKehadiran pgtype.Date `json:"tgl_kehadiran"`
+}
+
+func (q *Queries) ListTanggalKehadiranUsers(ctx context.Context, arg ListTanggalKehadiranUsersParams) ([]ListTanggalKehadiranUsersRow, error) {
+	rows, err := q.db.Query(ctx, listTanggalKehadiranUsers, arg.UserIds, arg.TglAwal, arg.TglAkhir)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTanggalKehadiranUsersRow{}
+	for rows.Next() {
+		var i ListTanggalKehadiranUsersRow
+		if err := rows.Scan(&i.UserID, &i.TglKehadiran); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rekapKehadiranEkspor = `-- name: RekapKehadiranEkspor :many
+SELECT
+  k.user_id,
+  u.username,
+  u.nama AS nama_mahasiswa,
+  COUNT(*) FILTER (WHERE k.presensi = 'hadir') AS total_hadir,
+  COUNT(*) FILTER (WHERE k.presensi = 'izin') AS total_izin,
+  COUNT(*) FILTER (WHERE k.presensi = 'sakit') AS total_sakit,
+  COUNT(*) FILTER (WHERE k.presensi = 'alpa') AS total_alpa,
+  COUNT(*) AS total_semua,
+  COALESCE(SUM(k.durasi_menit), 0)::bigint AS total_menit_dinas,
+  COUNT(*) FILTER (WHERE k.pulang_awal) AS total_pulang_awal
+FROM kehadiran k
+JOIN users u ON u.id = k.user_id
+WHERE k.is_active = true
+  AND k.deleted_at IS NULL
+  AND k.tgl_kehadiran BETWEEN $1::date AND $2::date
+  AND ($3::uuid IS NULL OR k.user_id = $3::uuid)
+  AND ($4::uuid IS NULL OR k.ruangan_id = $4::uuid)
+  AND ($5::uuid IS NULL OR k.fasilitas_id = $5::uuid)
+GROUP BY k.user_id, u.username, u.nama
+ORDER BY u.nama
+`
+
+type RekapKehadiranEksporParams struct {
+	TglAwal     pgtype.Date `json:"tgl_awal"`
+	TglAkhir    pgtype.Date `json:"tgl_akhir"`
+	UserID      *uuid.UUID  `json:"user_id"`
+	RuanganID   *uuid.UUID  `json:"ruangan_id"`
+	FasilitasID *uuid.UUID  `json:"fasilitas_id"`
+}
+
+type RekapKehadiranEksporRow struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Username        string    `json:"username"`
+	NamaMahasiswa   string    `json:"nama_mahasiswa"`
+	TotalHadir      int64     `json:"total_hadir"`
+	TotalIzin       int64     `json:"total_izin"`
+	TotalSakit      int64     `json:"total_sakit"`
+	TotalAlpa       int64     `json:"total_alpa"`
+	TotalSemua      int64     `json:"total_semua"`
+	TotalMenitDinas int64     `json:"total_menit_dinas"`
+	TotalPulangAwal int64     `json:"total_pulang_awal"`
+}
+
+func (q *Queries) RekapKehadiranEkspor(ctx context.Context, arg RekapKehadiranEksporParams) ([]RekapKehadiranEksporRow, error) {
+	rows, err := q.db.Query(ctx, rekapKehadiranEkspor,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.UserID,
+		arg.RuanganID,
+		arg.FasilitasID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RekapKehadiranEksporRow{}
+	for rows.Next() {
+		var i RekapKehadiranEksporRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Username,
+			&i.NamaMahasiswa,
+			&i.TotalHadir,
+			&i.TotalIzin,
+			&i.TotalSakit,
+			&i.TotalAlpa,
+			&i.TotalSemua,
+			&i.TotalMenitDinas,
+			&i.TotalPulangAwal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const rekapKehadiranMahasiswa = `-- name: RekapKehadiranMahasiswa :one
 SELECT
     user_id,
     COUNT(*) FILTER (WHERE presensi = 'hadir') AS total_hadir,
     COUNT(*) FILTER (WHERE presensi = 'izin') AS total_izin,
     COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit,
-    COUNT(*) AS total_semua
+    COUNT(*) AS total_semua,
+    COALESCE(SUM(durasi_menit), 0)::bigint AS total_menit_dinas,
+    ROUND(COALESCE(SUM(durasi_menit), 0)::numeric / 60, 2) AS total_jam_dinas,
+    COUNT(*) FILTER (WHERE pulang_awal) AS total_pulang_awal
 FROM kehadiran
 WHERE is_active = true
   AND user_id = $1
@@ -771,11 +1357,14 @@ type RekapKehadiranMahasiswaParams struct {
 }
 
 type RekapKehadiranMahasiswaRow struct {
-	UserID     uuid.UUID `json:"user_id"`
-	TotalHadir int64     `json:"total_hadir"`
-	TotalIzin  int64     `json:"total_izin"`
-	TotalSakit int64     `json:"total_sakit"`
-	TotalSemua int64     `json:"total_semua"`
+	UserID          uuid.UUID      `json:"user_id"`
+	TotalHadir      int64          `json:"total_hadir"`
+	TotalIzin       int64          `json:"total_izin"`
+	TotalSakit      int64          `json:"total_sakit"`
+	TotalSemua      int64          `json:"total_semua"`
+	TotalMenitDinas int64          `json:"total_menit_dinas"`
+	TotalJamDinas   pgtype.Numeric `json:"total_jam_dinas"`
+	TotalPulangAwal int64          `json:"total_pulang_awal"`
 }
 
 func (q *Queries) RekapKehadiranMahasiswa(ctx context.Context, arg RekapKehadiranMahasiswaParams) (RekapKehadiranMahasiswaRow, error) {
@@ -787,6 +1376,9 @@ func (q *Queries) RekapKehadiranMahasiswa(ctx context.Context, arg RekapKehadira
 		&i.TotalIzin,
 		&i.TotalSakit,
 		&i.TotalSemua,
+		&i.TotalMenitDinas,
+		&i.TotalJamDinas,
+		&i.TotalPulangAwal,
 	)
 	return i, err
 }
@@ -796,7 +1388,9 @@ SELECT
     tgl_kehadiran,
     COUNT(*) FILTER (WHERE presensi = 'hadir') AS total_hadir,
     COUNT(*) FILTER (WHERE presensi = 'izin') AS total_izin,
-    COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit
+    COUNT(*) FILTER (WHERE presensi = 'sakit') AS total_sakit,
+    COALESCE(SUM(durasi_menit), 0)::bigint AS total_menit_dinas,
+    ROUND(COALESCE(SUM(durasi_menit), 0)::numeric / 60, 2) AS total_jam_dinas
 FROM kehadiran
 WHERE is_active = true
   AND user_id = $1
@@ -812,10 +1406,12 @@ type RekapKehadiranMahasiswaDetailParams struct {
 }
 
 type RekapKehadiranMahasiswaDetailRow struct {
-	TglKehadiran pgtype.Date `json:"tgl_kehadiran"`
-	TotalHadir   int64       `json:"total_hadir"`
-	TotalIzin    int64       `json:"total_izin"`
-	TotalSakit   int64       `json:"total_sakit"`
+	TglKehadiran    pgtype.Date    `json:"tgl_kehadiran"`
+	TotalHadir      int64          `json:"total_hadir"`
+	TotalIzin       int64          `json:"total_izin"`
+	TotalSakit      int64          `json:"total_sakit"`
+	TotalMenitDinas int64          `json:"total_menit_dinas"`
+	TotalJamDinas   pgtype.Numeric `json:"total_jam_dinas"`
 }
 
 func (q *Queries) RekapKehadiranMahasiswaDetail(ctx context.Context, arg RekapKehadiranMahasiswaDetailParams) ([]RekapKehadiranMahasiswaDetailRow, error) {
@@ -832,6 +1428,8 @@ func (q *Queries) RekapKehadiranMahasiswaDetail(ctx context.Context, arg RekapKe
 			&i.TotalHadir,
 			&i.TotalIzin,
 			&i.TotalSakit,
+			&i.TotalMenitDinas,
+			&i.TotalJamDinas,
 		); err != nil {
 			return nil, err
 		}
@@ -857,7 +1455,7 @@ SET
   updated_note  = COALESCE($10, updated_note),
   updated_at    = now()
 WHERE id = $1
-RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at
+RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id
 `
 
 type UpdateKehadiranPartialParams struct {
@@ -908,6 +1506,16 @@ func (q *Queries) UpdateKehadiranPartial(ctx context.Context, arg UpdateKehadira
 		&i.Status,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
 	)
 	return i, err
 }
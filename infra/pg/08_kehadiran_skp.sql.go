@@ -12,32 +12,231 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const approveKehadiranSkpByIds = `-- name: ApproveKehadiranSkpByIds :exec
-UPDATE public.kehadiran_skp ks
+const ajukanUlangKehadiranSkp = `-- name: AjukanUlangKehadiranSkp :one
+UPDATE kehadiran_skp
 SET
-  status = CASE
-    WHEN ks.id = ANY($1::uuid[])
-      THEN 'disetujui'
-    ELSE 'ditolak'
-  END,
-  locked = TRUE,
-  updated_at = now(),
-  updated_by = $2
-WHERE
-  ks.kehadiran_id = $3::uuid
-  AND ks.deleted_at IS NULL
-  AND ks.is_active = TRUE
+  status     = 'diajukan',
+  updated_by = $1,
+  updated_at = now()
+WHERE id = $2
+  AND user_id = $3
+  AND status = 'revisi'
+  AND COALESCE(locked, false) = false
+  AND deleted_at IS NULL
+RETURNING id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
 `
 
-type ApproveKehadiranSkpByIdsParams struct {
-	SkpKehadiranID []uuid.UUID `json:"skp_kehadiran_id"`
-	UpdatedBy      *string     `json:"updated_by"`
-	KehadiranID    uuid.UUID   `json:"kehadiran_id"`
+type AjukanUlangKehadiranSkpParams struct {
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
 }
 
-func (q *Queries) ApproveKehadiranSkpByIds(ctx context.Context, arg ApproveKehadiranSkpByIdsParams) error {
-	_, err := q.db.Exec(ctx, approveKehadiranSkpByIds, arg.SkpKehadiranID, arg.UpdatedBy, arg.KehadiranID)
-	return err
+func (q *Queries) AjukanUlangKehadiranSkp(ctx context.Context, arg AjukanUlangKehadiranSkpParams) (KehadiranSkp, error) {
+	row := q.db.QueryRow(ctx, ajukanUlangKehadiranSkp, arg.UpdatedBy, arg.ID, arg.UserID)
+	var i KehadiranSkp
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranID,
+		&i.SkpIntervensiID,
+		&i.UserID,
+		&i.Status,
+		&i.IsActive,
+		&i.Locked,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Narasi,
+		&i.InisialPasien,
+		&i.KelompokUsia,
+		&i.KamarBed,
+		&i.WaktuTindakan,
+		&i.CatatanReview,
+		&i.ReviewerID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.LevelID,
+	)
+	return i, err
+}
+
+const bukaKunciKehadiranSkp = `-- name: BukaKunciKehadiranSkp :execrows
+UPDATE kehadiran_skp
+SET
+  locked         = false,
+  status         = NULL,
+  catatan_review = NULL,
+  level_id       = NULL,
+  reviewer_id    = NULL,
+  reviewed_by    = NULL,
+  reviewed_at    = NULL,
+  updated_note   = $1,
+  updated_by     = $2,
+  updated_at     = now()
+WHERE kehadiran_id = $3
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+`
+
+type BukaKunciKehadiranSkpParams struct {
+	Alasan      *string   `json:"alasan"`
+	UpdatedBy   *string   `json:"updated_by"`
+	KehadiranID uuid.UUID `json:"kehadiran_id"`
+}
+
+func (q *Queries) BukaKunciKehadiranSkp(ctx context.Context, arg BukaKunciKehadiranSkpParams) (int64, error) {
+	result, err := q.db.Exec(ctx, bukaKunciKehadiranSkp, arg.Alasan, arg.UpdatedBy, arg.KehadiranID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const bukaStatusKehadiran = `-- name: BukaStatusKehadiran :one
+UPDATE kehadiran
+SET
+  status       = NULL,
+  updated_note = $1,
+  updated_by   = $2,
+  updated_at   = now()
+WHERE id = $3
+  AND deleted_at IS NULL
+RETURNING id, fasilitas_id, kontrak_id, ruangan_id, mata_kuliah_id, pembimbing_id, pembimbing_klinik, jadwal_dinas, user_id, is_active, deleted_by, deleted_at, updated_note, updated_by, updated_at, tgl_kehadiran, presensi, status, created_by, created_at, latitude, longitude, akurasi, jarak_meter, status_lokasi, jam_pulang, durasi_menit, pulang_awal, jadwal_id, penempatan_id
+`
+
+type BukaStatusKehadiranParams struct {
+	Alasan    *string   `json:"alasan"`
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) BukaStatusKehadiran(ctx context.Context, arg BukaStatusKehadiranParams) (Kehadiran, error) {
+	row := q.db.QueryRow(ctx, bukaStatusKehadiran, arg.Alasan, arg.UpdatedBy, arg.ID)
+	var i Kehadiran
+	err := row.Scan(
+		&i.ID,
+		&i.FasilitasID,
+		&i.KontrakID,
+		&i.RuanganID,
+		&i.MataKuliahID,
+		&i.PembimbingID,
+		&i.PembimbingKlinik,
+		&i.JadwalDinas,
+		&i.UserID,
+		&i.IsActive,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.TglKehadiran,
+		&i.Presensi,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Akurasi,
+		&i.JarakMeter,
+		&i.StatusLokasi,
+		&i.JamPulang,
+		&i.DurasiMenit,
+		&i.PulangAwal,
+		&i.JadwalID,
+		&i.PenempatanID,
+	)
+	return i, err
+}
+
+const countInboxApproval = `-- name: CountInboxApproval :one
+WITH inbox AS (
+  SELECT
+    k.id AS kehadiran_id,
+    k.user_id,
+    u.nama,
+    u.username,
+    k.ruangan_id,
+    r.nama_ruangan,
+    k.tgl_kehadiran,
+    k.status,
+    COALESCE(p.jumlah_skp, 0)::bigint AS jumlah_skp,
+    COALESCE(p.jumlah_menunggu, 0)::bigint AS jumlah_skp_menunggu,
+    (CASE
+      WHEN k.status IS NULL THEN k.created_at
+      ELSE COALESCE(p.diajukan_sejak, k.created_at)
+    END)::timestamptz AS menunggu_sejak,
+    (CASE
+      WHEN k.pembimbing_id = $1::uuid OR k.pembimbing_klinik = $1::uuid THEN NULL
+      ELSE k.pembimbing_klinik
+    END)::uuid AS atas_nama_id
+  FROM kehadiran k
+  JOIN users u ON u.id = k.user_id
+  LEFT JOIN ruangan r ON r.id = k.ruangan_id
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS jumlah_skp,
+      COUNT(*) FILTER (
+        WHERE COALESCE(ks.locked, false) = false
+          AND (ks.status IS NULL OR ks.status = 'diajukan')
+      ) AS jumlah_menunggu,
+      MIN(COALESCE(ks.updated_at, ks.created_at)) FILTER (WHERE ks.status = 'diajukan') AS diajukan_sejak
+    FROM kehadiran_skp ks
+    WHERE ks.kehadiran_id = k.id
+      AND ks.is_active = TRUE
+      AND ks.deleted_at IS NULL
+  ) p ON true
+  WHERE k.is_active = TRUE
+    AND k.deleted_at IS NULL
+    AND k.presensi = 'hadir'
+    AND (k.pembimbing_id = $1::uuid
+         OR k.pembimbing_klinik = $1::uuid
+         OR EXISTS (
+           SELECT 1 FROM delegasi_pembimbing d
+           WHERE d.delegasi_id = $1::uuid
+             AND d.pembimbing_id = k.pembimbing_klinik
+             AND d.kontrak_id = k.kontrak_id
+             AND d.dicabut_at IS NULL
+             AND CURRENT_DATE BETWEEN d.tgl_mulai AND d.tgl_selesai
+         ))
+    AND (k.status IS NULL OR COALESCE(p.jumlah_menunggu, 0) > 0)
+    AND ($2::uuid IS NULL OR k.user_id = $2::uuid)
+    AND ($3::uuid IS NULL OR k.ruangan_id = $3::uuid)
+    AND ($4::date IS NULL OR k.tgl_kehadiran >= $4::date)
+    AND ($5::date IS NULL OR k.tgl_kehadiran <= $5::date)
+)
+SELECT COUNT(*)::bigint
+FROM inbox
+WHERE ($6::boolean IS NULL
+       OR (menunggu_sejak < now() - make_interval(hours => $7::int)) = $6::boolean)
+`
+
+type CountInboxApprovalParams struct {
+	PembimbingID uuid.UUID   `json:"pembimbing_id"`
+	UserID       *uuid.UUID  `json:"user_id"`
+	RuanganID    *uuid.UUID  `json:"ruangan_id"`
+	TglAwal      pgtype.Date `json:"tgl_awal"`
+	TglAkhir     pgtype.Date `json:"tgl_akhir"`
+	LewatSla     *bool       `json:"lewat_sla"`
+	SlaJam       int32       `json:"sla_jam"`
+}
+
+func (q *Queries) CountInboxApproval(ctx context.Context, arg CountInboxApprovalParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countInboxApproval,
+		arg.PembimbingID,
+		arg.UserID,
+		arg.RuanganID,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.LewatSla,
+		arg.SlaJam,
+	)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
 }
 
 const countKehadiranSkp = `-- name: CountKehadiranSkp :one
@@ -69,6 +268,176 @@ func (q *Queries) CountKehadiranSkp(ctx context.Context, arg CountKehadiranSkpPa
 	return column_1, err
 }
 
+const countKehadiranSkpLampiran = `-- name: CountKehadiranSkpLampiran :one
+SELECT COUNT(*)::bigint
+FROM kehadiran_skp_lampiran
+WHERE kehadiran_skp_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountKehadiranSkpLampiran(ctx context.Context, kehadiranSkpID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countKehadiranSkpLampiran, kehadiranSkpID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countSkpRevisi = `-- name: CountSkpRevisi :one
+SELECT COUNT(*)::bigint
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND status = 'revisi'
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountSkpRevisi(ctx context.Context, kehadiranID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countSkpRevisi, kehadiranID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createKehadiranKeputusan = `-- name: CreateKehadiranKeputusan :exec
+INSERT INTO kehadiran_keputusan (
+  kehadiran_id, status, reviewer_id, reviewed_by, atas_nama_id, delegasi_pembimbing_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+`
+
+type CreateKehadiranKeputusanParams struct {
+	KehadiranID          uuid.UUID  `json:"kehadiran_id"`
+	Status               string     `json:"status"`
+	ReviewerID           uuid.UUID  `json:"reviewer_id"`
+	ReviewedBy           *string    `json:"reviewed_by"`
+	AtasNamaID           *uuid.UUID `json:"atas_nama_id"`
+	DelegasiPembimbingID *uuid.UUID `json:"delegasi_pembimbing_id"`
+}
+
+func (q *Queries) CreateKehadiranKeputusan(ctx context.Context, arg CreateKehadiranKeputusanParams) error {
+	_, err := q.db.Exec(ctx, createKehadiranKeputusan,
+		arg.KehadiranID,
+		arg.Status,
+		arg.ReviewerID,
+		arg.ReviewedBy,
+		arg.AtasNamaID,
+		arg.DelegasiPembimbingID,
+	)
+	return err
+}
+
+const createKehadiranSkpKeputusan = `-- name: CreateKehadiranSkpKeputusan :exec
+INSERT INTO kehadiran_skp_keputusan (
+  kehadiran_skp_id, status, catatan_review, reviewer_id, reviewed_by, level_id, atas_nama_id, delegasi_pembimbing_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+`
+
+type CreateKehadiranSkpKeputusanParams struct {
+	KehadiranSkpID       uuid.UUID  `json:"kehadiran_skp_id"`
+	Status               string     `json:"status"`
+	CatatanReview        *string    `json:"catatan_review"`
+	ReviewerID           uuid.UUID  `json:"reviewer_id"`
+	ReviewedBy           *string    `json:"reviewed_by"`
+	LevelID              *uuid.UUID `json:"level_id"`
+	AtasNamaID           *uuid.UUID `json:"atas_nama_id"`
+	DelegasiPembimbingID *uuid.UUID `json:"delegasi_pembimbing_id"`
+}
+
+func (q *Queries) CreateKehadiranSkpKeputusan(ctx context.Context, arg CreateKehadiranSkpKeputusanParams) error {
+	_, err := q.db.Exec(ctx, createKehadiranSkpKeputusan,
+		arg.KehadiranSkpID,
+		arg.Status,
+		arg.CatatanReview,
+		arg.ReviewerID,
+		arg.ReviewedBy,
+		arg.LevelID,
+		arg.AtasNamaID,
+		arg.DelegasiPembimbingID,
+	)
+	return err
+}
+
+const createKehadiranSkpLampiran = `-- name: CreateKehadiranSkpLampiran :one
+INSERT INTO kehadiran_skp_lampiran (
+  kehadiran_skp_id, lampiran_key, lampiran_nama, lampiran_tipe, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, kehadiran_skp_id, lampiran_key, lampiran_nama, lampiran_tipe, deleted_by, deleted_at, created_by, created_at
+`
+
+type CreateKehadiranSkpLampiranParams struct {
+	KehadiranSkpID uuid.UUID `json:"kehadiran_skp_id"`
+	LampiranKey    string    `json:"lampiran_key"`
+	LampiranNama   string    `json:"lampiran_nama"`
+	LampiranTipe   string    `json:"lampiran_tipe"`
+	CreatedBy      *string   `json:"created_by"`
+}
+
+func (q *Queries) CreateKehadiranSkpLampiran(ctx context.Context, arg CreateKehadiranSkpLampiranParams) (KehadiranSkpLampiran, error) {
+	row := q.db.QueryRow(ctx, createKehadiranSkpLampiran,
+		arg.KehadiranSkpID,
+		arg.LampiranKey,
+		arg.LampiranNama,
+		arg.LampiranTipe,
+		arg.CreatedBy,
+	)
+	var i KehadiranSkpLampiran
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranSkpID,
+		&i.LampiranKey,
+		&i.LampiranNama,
+		&i.LampiranTipe,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPembukaanKehadiranSkp = `-- name: CreatePembukaanKehadiranSkp :one
+INSERT INTO pembukaan_kehadiran_skp (
+  kehadiran_id, alasan, sebelum, dibuka_oleh, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, kehadiran_id, alasan, sebelum, dibuka_oleh, created_by, created_at
+`
+
+type CreatePembukaanKehadiranSkpParams struct {
+	KehadiranID uuid.UUID `json:"kehadiran_id"`
+	Alasan      string    `json:"alasan"`
+	Sebelum     []byte    `json:"sebelum"`
+	DibukaOleh  uuid.UUID `json:"dibuka_oleh"`
+	CreatedBy   *string   `json:"created_by"`
+}
+
+func (q *Queries) CreatePembukaanKehadiranSkp(ctx context.Context, arg CreatePembukaanKehadiranSkpParams) (PembukaanKehadiranSkp, error) {
+	row := q.db.QueryRow(ctx, createPembukaanKehadiranSkp,
+		arg.KehadiranID,
+		arg.Alasan,
+		arg.Sebelum,
+		arg.DibukaOleh,
+		arg.CreatedBy,
+	)
+	var i PembukaanKehadiranSkp
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranID,
+		&i.Alasan,
+		&i.Sebelum,
+		&i.DibukaOleh,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const deleteKehadiranSkp = `-- name: DeleteKehadiranSkp :exec
 UPDATE kehadiran_skp
 SET deleted_at = now(),
@@ -87,6 +456,22 @@ func (q *Queries) DeleteKehadiranSkp(ctx context.Context, arg DeleteKehadiranSkp
 	return err
 }
 
+const deleteKehadiranSkpLampiran = `-- name: DeleteKehadiranSkpLampiran :exec
+UPDATE kehadiran_skp_lampiran
+SET deleted_at = now(), deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type DeleteKehadiranSkpLampiranParams struct {
+	ID        uuid.UUID `json:"id"`
+	DeletedBy *string   `json:"deleted_by"`
+}
+
+func (q *Queries) DeleteKehadiranSkpLampiran(ctx context.Context, arg DeleteKehadiranSkpLampiranParams) error {
+	_, err := q.db.Exec(ctx, deleteKehadiranSkpLampiran, arg.ID, arg.DeletedBy)
+	return err
+}
+
 const getCapaianSKP7HariTerakhir = `-- name: GetCapaianSKP7HariTerakhir :many
 WITH date_series AS (
   SELECT generate_series(
@@ -297,13 +682,17 @@ Total AS (
 DataRekap AS (
     SELECT
         CASE
-            WHEN (ks.status = 'disetujui' OR ks.locked = TRUE) THEN 'Tercapai'
-            WHEN ks.status = 'ditolak' THEN 'Belum Tercapai'
+            WHEN ks.status = 'disetujui'
+                 AND (si.level_minimal_id IS NULL OR lv.urutan >= lm.urutan) THEN 'Tercapai'
+            WHEN ks.status IN ('disetujui', 'ditolak') THEN 'Belum Tercapai'
             ELSE 'Belum Diverifikasi'
         END AS kategori_status,
         COUNT(*) AS jumlah
     FROM kehadiran_skp ks
     JOIN kehadiran k ON k.id = ks.kehadiran_id
+    JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+    LEFT JOIN skp_level lv ON lv.id = ks.level_id
+    LEFT JOIN skp_level lm ON lm.id = si.level_minimal_id
     WHERE ks.is_active = TRUE
       AND k.is_active = TRUE
       AND k.tgl_kehadiran BETWEEN date_trunc('year', CURRENT_DATE)::date AND CURRENT_DATE
@@ -356,7 +745,7 @@ func (q *Queries) GetGlobalSKPPersentaseTahunanOtomatis(ctx context.Context) ([]
 }
 
 const getKehadiranSkp = `-- name: GetKehadiranSkp :one
-SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at
+SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
 FROM kehadiran_skp
 WHERE id = $1
   AND deleted_at IS NULL
@@ -380,6 +769,63 @@ func (q *Queries) GetKehadiranSkp(ctx context.Context, id uuid.UUID) (KehadiranS
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Narasi,
+		&i.InisialPasien,
+		&i.KelompokUsia,
+		&i.KamarBed,
+		&i.WaktuTindakan,
+		&i.CatatanReview,
+		&i.ReviewerID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.LevelID,
+	)
+	return i, err
+}
+
+const getKehadiranSkpLampiran = `-- name: GetKehadiranSkpLampiran :one
+SELECT
+  l.id,
+  l.kehadiran_skp_id,
+  l.lampiran_key,
+  l.lampiran_nama,
+  l.lampiran_tipe,
+  ks.user_id,
+  ks.locked,
+  k.pembimbing_id,
+  k.pembimbing_klinik
+FROM kehadiran_skp_lampiran l
+JOIN kehadiran_skp ks ON ks.id = l.kehadiran_skp_id
+JOIN kehadiran k ON k.id = ks.kehadiran_id
+WHERE l.id = $1
+  AND l.deleted_at IS NULL
+`
+
+type GetKehadiranSkpLampiranRow struct {
+	ID               uuid.UUID `json:"id"`
+	KehadiranSkpID   uuid.UUID `json:"kehadiran_skp_id"`
+	LampiranKey      string    `json:"lampiran_key"`
+	LampiranNama     string    `json:"lampiran_nama"`
+	LampiranTipe     string    `json:"lampiran_tipe"`
+	UserID           uuid.UUID `json:"user_id"`
+	Locked           *bool     `json:"locked"`
+	PembimbingID     uuid.UUID `json:"pembimbing_id"`
+	PembimbingKlinik uuid.UUID `json:"pembimbing_klinik"`
+}
+
+func (q *Queries) GetKehadiranSkpLampiran(ctx context.Context, id uuid.UUID) (GetKehadiranSkpLampiranRow, error) {
+	row := q.db.QueryRow(ctx, getKehadiranSkpLampiran, id)
+	var i GetKehadiranSkpLampiranRow
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranSkpID,
+		&i.LampiranKey,
+		&i.LampiranNama,
+		&i.LampiranTipe,
+		&i.UserID,
+		&i.Locked,
+		&i.PembimbingID,
+		&i.PembimbingKlinik,
 	)
 	return i, err
 }
@@ -428,9 +874,12 @@ SELECT
 FROM kehadiran_skp ks
 JOIN kehadiran k ON k.id = ks.kehadiran_id
 JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+LEFT JOIN skp_level lv ON lv.id = ks.level_id
+LEFT JOIN skp_level lm ON lm.id = si.level_minimal_id
 WHERE
   ks.user_id = $1
   AND ks.status = 'disetujui'
+  AND (si.level_minimal_id IS NULL OR lv.urutan >= lm.urutan)
   AND ks.is_active = TRUE
   AND k.is_active = TRUE
   AND k.tgl_kehadiran BETWEEN $2 AND $3
@@ -475,10 +924,27 @@ SELECT
 ks.id,
   si.nama, 
   ks.skp_intervensi_id,
-  ks.locked
+  ks.locked,
+  ks.status,
+  ks.narasi,
+  ks.inisial_pasien,
+  ks.kelompok_usia,
+  ks.kamar_bed,
+  ks.waktu_tindakan,
+  ks.catatan_review,
+  ks.reviewed_by,
+  ks.reviewed_at,
+  ks.level_id,
+  lv.nama AS level_nama,
+  si.level_minimal_id,
+  lm.nama AS level_minimal_nama
 FROM public.kehadiran_skp ks
 LEFT JOIN public.skp_intervensi si
   ON ks.skp_intervensi_id = si.id
+LEFT JOIN public.skp_level lv
+  ON lv.id = ks.level_id
+LEFT JOIN public.skp_level lm
+  ON lm.id = si.level_minimal_id
 WHERE 
   ks.kehadiran_id = $1
   AND ks.is_active = TRUE
@@ -487,10 +953,23 @@ ORDER BY ks.created_at DESC
 `
 
 type IntervensiKehadiranIDRow struct {
-	ID              uuid.UUID `json:"id"`
-	Nama            *string   `json:"nama"`
-	SkpIntervensiID uuid.UUID `json:"skp_intervensi_id"`
-	Locked          *bool     `json:"locked"`
+	ID               uuid.UUID          `json:"id"`
+	Nama             *string            `json:"nama"`
+	SkpIntervensiID  uuid.UUID          `json:"skp_intervensi_id"`
+	Locked           *bool              `json:"locked"`
+	Status           *string            `json:"status"`
+	Narasi           *string            `json:"narasi"`
+	InisialPasien    *string            `json:"inisial_pasien"`
+	KelompokUsia     *string            `json:"kelompok_usia"`
+	KamarBed         *string            `json:"kamar_bed"`
+	WaktuTindakan    pgtype.Timestamptz `json:"waktu_tindakan"`
+	CatatanReview    *string            `json:"catatan_review"`
+	ReviewedBy       *string            `json:"reviewed_by"`
+	ReviewedAt       pgtype.Timestamptz `json:"reviewed_at"`
+	LevelID          *uuid.UUID         `json:"level_id"`
+	LevelNama        *string            `json:"level_nama"`
+	LevelMinimalID   *uuid.UUID         `json:"level_minimal_id"`
+	LevelMinimalNama *string            `json:"level_minimal_nama"`
 }
 
 func (q *Queries) IntervensiKehadiranID(ctx context.Context, kehadiranID uuid.UUID) ([]IntervensiKehadiranIDRow, error) {
@@ -507,6 +986,172 @@ func (q *Queries) IntervensiKehadiranID(ctx context.Context, kehadiranID uuid.UU
 			&i.Nama,
 			&i.SkpIntervensiID,
 			&i.Locked,
+			&i.Status,
+			&i.Narasi,
+			&i.InisialPasien,
+			&i.KelompokUsia,
+			&i.KamarBed,
+			&i.WaktuTindakan,
+			&i.CatatanReview,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.LevelID,
+			&i.LevelNama,
+			&i.LevelMinimalID,
+			&i.LevelMinimalNama,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInboxApproval = `-- name: ListInboxApproval :many
+WITH inbox AS (
+  SELECT
+    k.id AS kehadiran_id,
+    k.user_id,
+    u.nama,
+    u.username,
+    k.ruangan_id,
+    r.nama_ruangan,
+    k.tgl_kehadiran,
+    k.status,
+    COALESCE(p.jumlah_skp, 0)::bigint AS jumlah_skp,
+    COALESCE(p.jumlah_menunggu, 0)::bigint AS jumlah_skp_menunggu,
+    (CASE
+      WHEN k.status IS NULL THEN k.created_at
+      ELSE COALESCE(p.diajukan_sejak, k.created_at)
+    END)::timestamptz AS menunggu_sejak,
+    (CASE
+      WHEN k.pembimbing_id = $1::uuid OR k.pembimbing_klinik = $1::uuid THEN NULL
+      ELSE k.pembimbing_klinik
+    END)::uuid AS atas_nama_id
+  FROM kehadiran k
+  JOIN users u ON u.id = k.user_id
+  LEFT JOIN ruangan r ON r.id = k.ruangan_id
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS jumlah_skp,
+      COUNT(*) FILTER (
+        WHERE COALESCE(ks.locked, false) = false
+          AND (ks.status IS NULL OR ks.status = 'diajukan')
+      ) AS jumlah_menunggu,
+      MIN(COALESCE(ks.updated_at, ks.created_at)) FILTER (WHERE ks.status = 'diajukan') AS diajukan_sejak
+    FROM kehadiran_skp ks
+    WHERE ks.kehadiran_id = k.id
+      AND ks.is_active = TRUE
+      AND ks.deleted_at IS NULL
+  ) p ON true
+  WHERE k.is_active = TRUE
+    AND k.deleted_at IS NULL
+    AND k.presensi = 'hadir'
+    AND (k.pembimbing_id = $1::uuid
+         OR k.pembimbing_klinik = $1::uuid
+         OR EXISTS (
+           SELECT 1 FROM delegasi_pembimbing d
+           WHERE d.delegasi_id = $1::uuid
+             AND d.pembimbing_id = k.pembimbing_klinik
+             AND d.kontrak_id = k.kontrak_id
+             AND d.dicabut_at IS NULL
+             AND CURRENT_DATE BETWEEN d.tgl_mulai AND d.tgl_selesai
+         ))
+    AND (k.status IS NULL OR COALESCE(p.jumlah_menunggu, 0) > 0)
+    AND ($2::uuid IS NULL OR k.user_id = $2::uuid)
+    AND ($3::uuid IS NULL OR k.ruangan_id = $3::uuid)
+    AND ($4::date IS NULL OR k.tgl_kehadiran >= $4::date)
+    AND ($5::date IS NULL OR k.tgl_kehadiran <= $5::date)
+)
+SELECT
+  kehadiran_id,
+  user_id,
+  nama,
+  username,
+  ruangan_id,
+  nama_ruangan,
+  tgl_kehadiran,
+  status,
+  jumlah_skp,
+  jumlah_skp_menunggu,
+  menunggu_sejak,
+  (EXTRACT(EPOCH FROM now() - menunggu_sejak) / 3600)::float8 AS lama_menunggu_jam,
+  (menunggu_sejak < now() - make_interval(hours => $6::int))::boolean AS lewat_sla,
+  atas_nama_id
+FROM inbox
+WHERE ($7::boolean IS NULL
+       OR (menunggu_sejak < now() - make_interval(hours => $6::int)) = $7::boolean)
+ORDER BY menunggu_sejak ASC
+LIMIT $8
+OFFSET $9
+`
+
+type ListInboxApprovalParams struct {
+	PembimbingID uuid.UUID   `json:"pembimbing_id"`
+	UserID       *uuid.UUID  `json:"user_id"`
+	RuanganID    *uuid.UUID  `json:"ruangan_id"`
+	TglAwal      pgtype.Date `json:"tgl_awal"`
+	TglAkhir     pgtype.Date `json:"tgl_akhir"`
+	SlaJam       int32       `json:"sla_jam"`
+	LewatSla     *bool       `json:"lewat_sla"`
+	Limit        int32       `json:"limit"`
+	Offset       int32       `json:"offset"`
+}
+
+type ListInboxApprovalRow struct {
+	KehadiranID       uuid.UUID          `json:"kehadiran_id"`
+	UserID            uuid.UUID          `json:"user_id"`
+	Nama              string             `json:"nama"`
+	Username          string             `json:"username"`
+	RuanganID         uuid.UUID          `json:"ruangan_id"`
+	NamaRuangan       *string            `json:"nama_ruangan"`
+	TglKehadiran      pgtype.Date        `json:"tgl_kehadiran"`
+	Status            *string            `json:"status"`
+	JumlahSkp         int64              `json:"jumlah_skp"`
+	JumlahSkpMenunggu int64              `json:"jumlah_skp_menunggu"`
+	MenungguSejak     pgtype.Timestamptz `json:"menunggu_sejak"`
+	LamaMenungguJam   float64            `json:"lama_menunggu_jam"`
+	LewatSla          bool               `json:"lewat_sla"`
+	AtasNamaID        *uuid.UUID         `json:"atas_nama_id"`
+}
+
+func (q *Queries) ListInboxApproval(ctx context.Context, arg ListInboxApprovalParams) ([]ListInboxApprovalRow, error) {
+	rows, err := q.db.Query(ctx, listInboxApproval,
+		arg.PembimbingID,
+		arg.UserID,
+		arg.RuanganID,
+		arg.TglAwal,
+		arg.TglAkhir,
+		arg.SlaJam,
+		arg.LewatSla,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInboxApprovalRow{}
+	for rows.Next() {
+		var i ListInboxApprovalRow
+		if err := rows.Scan(
+			&i.KehadiranID,
+			&i.UserID,
+			&i.Nama,
+			&i.Username,
+			&i.RuanganID,
+			&i.NamaRuangan,
+			&i.TglKehadiran,
+			&i.Status,
+			&i.JumlahSkp,
+			&i.JumlahSkpMenunggu,
+			&i.MenungguSejak,
+			&i.LamaMenungguJam,
+			&i.LewatSla,
+			&i.AtasNamaID,
 		); err != nil {
 			return nil, err
 		}
@@ -600,6 +1245,340 @@ func (q *Queries) ListKehadiranSkp(ctx context.Context, arg ListKehadiranSkpPara
 	return items, nil
 }
 
+const listKehadiranSkpByKehadiran = `-- name: ListKehadiranSkpByKehadiran :many
+SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListKehadiranSkpByKehadiran(ctx context.Context, kehadiranID uuid.UUID) ([]KehadiranSkp, error) {
+	rows, err := q.db.Query(ctx, listKehadiranSkpByKehadiran, kehadiranID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KehadiranSkp{}
+	for rows.Next() {
+		var i KehadiranSkp
+		if err := rows.Scan(
+			&i.ID,
+			&i.KehadiranID,
+			&i.SkpIntervensiID,
+			&i.UserID,
+			&i.Status,
+			&i.IsActive,
+			&i.Locked,
+			&i.DeletedBy,
+			&i.DeletedAt,
+			&i.UpdatedNote,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Narasi,
+			&i.InisialPasien,
+			&i.KelompokUsia,
+			&i.KamarBed,
+			&i.WaktuTindakan,
+			&i.CatatanReview,
+			&i.ReviewerID,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.LevelID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKehadiranSkpKeputusan = `-- name: ListKehadiranSkpKeputusan :many
+SELECT id, kehadiran_skp_id, status, catatan_review, reviewer_id, reviewed_by, created_at, level_id, atas_nama_id, delegasi_pembimbing_id
+FROM kehadiran_skp_keputusan
+WHERE kehadiran_skp_id = ANY($1::uuid[])
+ORDER BY created_at ASC
+`
+
+func (q *Queries) ListKehadiranSkpKeputusan(ctx context.Context, kehadiranSkpIds []uuid.UUID) ([]KehadiranSkpKeputusan, error) {
+	rows, err := q.db.Query(ctx, listKehadiranSkpKeputusan, kehadiranSkpIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KehadiranSkpKeputusan{}
+	for rows.Next() {
+		var i KehadiranSkpKeputusan
+		if err := rows.Scan(
+			&i.ID,
+			&i.KehadiranSkpID,
+			&i.Status,
+			&i.CatatanReview,
+			&i.ReviewerID,
+			&i.ReviewedBy,
+			&i.CreatedAt,
+			&i.LevelID,
+			&i.AtasNamaID,
+			&i.DelegasiPembimbingID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKehadiranSkpLampiran = `-- name: ListKehadiranSkpLampiran :many
+SELECT id, kehadiran_skp_id, lampiran_key, lampiran_nama, lampiran_tipe, created_at
+FROM kehadiran_skp_lampiran
+WHERE kehadiran_skp_id = ANY($1::uuid[])
+  AND deleted_at IS NULL
+ORDER BY created_at ASC
+`
+
+type ListKehadiranSkpLampiranRow struct {
+	ID             uuid.UUID          `json:"id"`
+	KehadiranSkpID uuid.UUID          `json:"kehadiran_skp_id"`
+	LampiranKey    string             `json:"lampiran_key"`
+	LampiranNama   string             `json:"lampiran_nama"`
+	LampiranTipe   string             `json:"lampiran_tipe"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListKehadiranSkpLampiran(ctx context.Context, kehadiranSkpIds []uuid.UUID) ([]ListKehadiranSkpLampiranRow, error) {
+	rows, err := q.db.Query(ctx, listKehadiranSkpLampiran, kehadiranSkpIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListKehadiranSkpLampiranRow{}
+	for rows.Next() {
+		var i ListKehadiranSkpLampiranRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.KehadiranSkpID,
+			&i.LampiranKey,
+			&i.LampiranNama,
+			&i.LampiranTipe,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPembukaanKehadiranSkp = `-- name: ListPembukaanKehadiranSkp :many
+SELECT id, kehadiran_id, alasan, sebelum, dibuka_oleh, created_by, created_at FROM pembukaan_kehadiran_skp
+WHERE kehadiran_id = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) ListPembukaanKehadiranSkp(ctx context.Context, kehadiranID uuid.UUID) ([]PembukaanKehadiranSkp, error) {
+	rows, err := q.db.Query(ctx, listPembukaanKehadiranSkp, kehadiranID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PembukaanKehadiranSkp{}
+	for rows.Next() {
+		var i PembukaanKehadiranSkp
+		if err := rows.Scan(
+			&i.ID,
+			&i.KehadiranID,
+			&i.Alasan,
+			&i.Sebelum,
+			&i.DibukaOleh,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSkpMenungguByKehadiran = `-- name: ListSkpMenungguByKehadiran :many
+SELECT
+  ks.id,
+  ks.kehadiran_id,
+  ks.skp_intervensi_id,
+  si.nama,
+  ks.status,
+  ks.narasi,
+  ks.waktu_tindakan,
+  si.level_minimal_id,
+  COALESCE(ks.updated_at, ks.created_at)::timestamptz AS diajukan_at
+FROM kehadiran_skp ks
+JOIN skp_intervensi si ON si.id = ks.skp_intervensi_id
+WHERE ks.kehadiran_id = ANY($1::uuid[])
+  AND COALESCE(ks.locked, false) = false
+  AND (ks.status IS NULL OR ks.status = 'diajukan')
+  AND ks.is_active = TRUE
+  AND ks.deleted_at IS NULL
+ORDER BY ks.created_at ASC
+`
+
+type ListSkpMenungguByKehadiranRow struct {
+	ID              uuid.UUID          `json:"id"`
+	KehadiranID     uuid.UUID          `json:"kehadiran_id"`
+	SkpIntervensiID uuid.UUID          `json:"skp_intervensi_id"`
+	Nama            string             `json:"nama"`
+	Status          *string            `json:"status"`
+	Narasi          *string            `json:"narasi"`
+	WaktuTindakan   pgtype.Timestamptz `json:"waktu_tindakan"`
+	LevelMinimalID  *uuid.UUID         `json:"level_minimal_id"`
+	DiajukanAt      pgtype.Timestamptz `json:"diajukan_at"`
+}
+
+func (q *Queries) ListSkpMenungguByKehadiran(ctx context.Context, kehadiranIds []uuid.UUID) ([]ListSkpMenungguByKehadiranRow, error) {
+	rows, err := q.db.Query(ctx, listSkpMenungguByKehadiran, kehadiranIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSkpMenungguByKehadiranRow{}
+	for rows.Next() {
+		var i ListSkpMenungguByKehadiranRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.KehadiranID,
+			&i.SkpIntervensiID,
+			&i.Nama,
+			&i.Status,
+			&i.Narasi,
+			&i.WaktuTindakan,
+			&i.LevelMinimalID,
+			&i.DiajukanAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSkpMenungguReview = `-- name: ListSkpMenungguReview :many
+SELECT id
+FROM kehadiran_skp
+WHERE kehadiran_id = $1
+  AND COALESCE(locked, false) = false
+  AND (status IS NULL OR status = 'diajukan')
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) ListSkpMenungguReview(ctx context.Context, kehadiranID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listSkpMenungguReview, kehadiranID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewKehadiranSkp = `-- name: ReviewKehadiranSkp :one
+UPDATE kehadiran_skp
+SET
+  status         = $1::varchar,
+  catatan_review = $2,
+  level_id       = $3,
+  locked         = ($1::varchar <> 'revisi'),
+  reviewer_id    = $4,
+  reviewed_by    = $5,
+  reviewed_at    = now(),
+  updated_by     = $5,
+  updated_at     = now()
+WHERE id = $6
+  AND kehadiran_id = $7
+  AND COALESCE(locked, false) = false
+  AND (status IS NULL OR status = 'diajukan')
+  AND is_active = TRUE
+  AND deleted_at IS NULL
+RETURNING id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
+`
+
+type ReviewKehadiranSkpParams struct {
+	Status        string     `json:"status"`
+	CatatanReview *string    `json:"catatan_review"`
+	LevelID       *uuid.UUID `json:"level_id"`
+	ReviewerID    *uuid.UUID `json:"reviewer_id"`
+	ReviewedBy    *string    `json:"reviewed_by"`
+	ID            uuid.UUID  `json:"id"`
+	KehadiranID   uuid.UUID  `json:"kehadiran_id"`
+}
+
+func (q *Queries) ReviewKehadiranSkp(ctx context.Context, arg ReviewKehadiranSkpParams) (KehadiranSkp, error) {
+	row := q.db.QueryRow(ctx, reviewKehadiranSkp,
+		arg.Status,
+		arg.CatatanReview,
+		arg.LevelID,
+		arg.ReviewerID,
+		arg.ReviewedBy,
+		arg.ID,
+		arg.KehadiranID,
+	)
+	var i KehadiranSkp
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranID,
+		&i.SkpIntervensiID,
+		&i.UserID,
+		&i.Status,
+		&i.IsActive,
+		&i.Locked,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Narasi,
+		&i.InisialPasien,
+		&i.KelompokUsia,
+		&i.KamarBed,
+		&i.WaktuTindakan,
+		&i.CatatanReview,
+		&i.ReviewerID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.LevelID,
+	)
+	return i, err
+}
+
 const skpKehadiranID = `-- name: SkpKehadiranID :many
 SELECT skp_intervensi_id
 FROM kehadiran_skp
@@ -652,6 +1631,9 @@ inserted AS (
         i.user_id,
         i.actor
     FROM input_data i
+    JOIN skp_intervensi si
+      ON si.id = i.skp_intervensi_id
+     AND si.is_active = true            -- intervensi nonaktif tidak bisa dipilih lagi
     LEFT JOIN kehadiran_skp k
       ON k.kehadiran_id = i.kehadiran_id 
      AND k.skp_intervensi_id = i.skp_intervensi_id
@@ -659,7 +1641,7 @@ inserted AS (
        OR k.locked = false             -- atau baris belum terkunci
     ON CONFLICT (kehadiran_id, skp_intervensi_id)
     DO NOTHING
-    RETURNING kehadiran_skp.id, kehadiran_skp.kehadiran_id, kehadiran_skp.skp_intervensi_id, kehadiran_skp.user_id, kehadiran_skp.status, kehadiran_skp.is_active, kehadiran_skp.locked, kehadiran_skp.deleted_by, kehadiran_skp.deleted_at, kehadiran_skp.updated_note, kehadiran_skp.updated_by, kehadiran_skp.updated_at, kehadiran_skp.created_by, kehadiran_skp.created_at
+    RETURNING kehadiran_skp.id, kehadiran_skp.kehadiran_id, kehadiran_skp.skp_intervensi_id, kehadiran_skp.user_id, kehadiran_skp.status, kehadiran_skp.is_active, kehadiran_skp.locked, kehadiran_skp.deleted_by, kehadiran_skp.deleted_at, kehadiran_skp.updated_note, kehadiran_skp.updated_by, kehadiran_skp.updated_at, kehadiran_skp.created_by, kehadiran_skp.created_at, kehadiran_skp.narasi, kehadiran_skp.inisial_pasien, kehadiran_skp.kelompok_usia, kehadiran_skp.kamar_bed, kehadiran_skp.waktu_tindakan, kehadiran_skp.catatan_review, kehadiran_skp.reviewer_id, kehadiran_skp.reviewed_by, kehadiran_skp.reviewed_at, kehadiran_skp.level_id
 ),
 
 deleted AS (
@@ -667,12 +1649,12 @@ deleted AS (
     WHERE k.kehadiran_id = $2
       AND k.locked = false
       AND k.skp_intervensi_id NOT IN (SELECT skp_intervensi_id FROM input_data)
-    RETURNING k.id, k.kehadiran_id, k.skp_intervensi_id, k.user_id, k.status, k.is_active, k.locked, k.deleted_by, k.deleted_at, k.updated_note, k.updated_by, k.updated_at, k.created_by, k.created_at
+    RETURNING k.id, k.kehadiran_id, k.skp_intervensi_id, k.user_id, k.status, k.is_active, k.locked, k.deleted_by, k.deleted_at, k.updated_note, k.updated_by, k.updated_at, k.created_by, k.created_at, k.narasi, k.inisial_pasien, k.kelompok_usia, k.kamar_bed, k.waktu_tindakan, k.catatan_review, k.reviewer_id, k.reviewed_by, k.reviewed_at, k.level_id
 )
 
-SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at FROM inserted
+SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id FROM inserted
 UNION ALL
-SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at FROM deleted
+SELECT id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id FROM deleted
 `
 
 type SyncKehadiranSkpParams struct {
@@ -697,6 +1679,16 @@ type SyncKehadiranSkpRow struct {
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	CreatedBy       *string            `json:"created_by"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Narasi          *string            `json:"narasi"`
+	InisialPasien   *string            `json:"inisial_pasien"`
+	KelompokUsia    *string            `json:"kelompok_usia"`
+	KamarBed        *string            `json:"kamar_bed"`
+	WaktuTindakan   pgtype.Timestamptz `json:"waktu_tindakan"`
+	CatatanReview   *string            `json:"catatan_review"`
+	ReviewerID      *uuid.UUID         `json:"reviewer_id"`
+	ReviewedBy      *string            `json:"reviewed_by"`
+	ReviewedAt      pgtype.Timestamptz `json:"reviewed_at"`
+	LevelID         *uuid.UUID         `json:"level_id"`
 }
 
 // 1️⃣ Data input user
@@ -738,6 +1730,16 @@ func (q *Queries) SyncKehadiranSkp(ctx context.Context, arg SyncKehadiranSkpPara
 			&i.UpdatedAt,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.Narasi,
+			&i.InisialPasien,
+			&i.KelompokUsia,
+			&i.KamarBed,
+			&i.WaktuTindakan,
+			&i.CatatanReview,
+			&i.ReviewerID,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.LevelID,
 		); err != nil {
 			return nil, err
 		}
@@ -749,6 +1751,75 @@ func (q *Queries) SyncKehadiranSkp(ctx context.Context, arg SyncKehadiranSkpPara
 	return items, nil
 }
 
+const updateBuktiKehadiranSkp = `-- name: UpdateBuktiKehadiranSkp :one
+UPDATE kehadiran_skp
+SET
+  narasi         = $1,
+  inisial_pasien = $2,
+  kelompok_usia  = $3,
+  kamar_bed      = $4,
+  waktu_tindakan = $5,
+  updated_by     = $6,
+  updated_at     = now()
+WHERE id = $7
+  AND user_id = $8
+  AND COALESCE(locked, false) = false
+  AND deleted_at IS NULL
+RETURNING id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
+`
+
+type UpdateBuktiKehadiranSkpParams struct {
+	Narasi        *string            `json:"narasi"`
+	InisialPasien *string            `json:"inisial_pasien"`
+	KelompokUsia  *string            `json:"kelompok_usia"`
+	KamarBed      *string            `json:"kamar_bed"`
+	WaktuTindakan pgtype.Timestamptz `json:"waktu_tindakan"`
+	UpdatedBy     *string            `json:"updated_by"`
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+}
+
+func (q *Queries) UpdateBuktiKehadiranSkp(ctx context.Context, arg UpdateBuktiKehadiranSkpParams) (KehadiranSkp, error) {
+	row := q.db.QueryRow(ctx, updateBuktiKehadiranSkp,
+		arg.Narasi,
+		arg.InisialPasien,
+		arg.KelompokUsia,
+		arg.KamarBed,
+		arg.WaktuTindakan,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.UserID,
+	)
+	var i KehadiranSkp
+	err := row.Scan(
+		&i.ID,
+		&i.KehadiranID,
+		&i.SkpIntervensiID,
+		&i.UserID,
+		&i.Status,
+		&i.IsActive,
+		&i.Locked,
+		&i.DeletedBy,
+		&i.DeletedAt,
+		&i.UpdatedNote,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Narasi,
+		&i.InisialPasien,
+		&i.KelompokUsia,
+		&i.KamarBed,
+		&i.WaktuTindakan,
+		&i.CatatanReview,
+		&i.ReviewerID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.LevelID,
+	)
+	return i, err
+}
+
 const updateKehadiranSkp = `-- name: UpdateKehadiranSkp :one
 UPDATE kehadiran_skp
 SET
@@ -759,7 +1830,7 @@ SET
   updated_at   = now()
 WHERE id = $5
   AND deleted_at IS NULL
-RETURNING id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at
+RETURNING id, kehadiran_id, skp_intervensi_id, user_id, status, is_active, locked, deleted_by, deleted_at, updated_note, updated_by, updated_at, created_by, created_at, narasi, inisial_pasien, kelompok_usia, kamar_bed, waktu_tindakan, catatan_review, reviewer_id, reviewed_by, reviewed_at, level_id
 `
 
 type UpdateKehadiranSkpParams struct {
@@ -794,6 +1865,16 @@ func (q *Queries) UpdateKehadiranSkp(ctx context.Context, arg UpdateKehadiranSkp
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Narasi,
+		&i.InisialPasien,
+		&i.KelompokUsia,
+		&i.KamarBed,
+		&i.WaktuTindakan,
+		&i.CatatanReview,
+		&i.ReviewerID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.LevelID,
 	)
 	return i, err
 }
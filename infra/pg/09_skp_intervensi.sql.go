@@ -11,6 +11,364 @@ import (
 	uuid "github.com/gofrs/uuid/v5"
 )
 
+const cekIntervensiDipakai = `-- name: CekIntervensiDipakai :one
+SELECT EXISTS (
+  SELECT 1 FROM kehadiran_skp
+  WHERE skp_intervensi_id = $1
+)
+`
+
+func (q *Queries) CekIntervensiDipakai(ctx context.Context, skpIntervensiID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, cekIntervensiDipakai, skpIntervensiID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const createSkpIntervensi = `-- name: CreateSkpIntervensi :one
+INSERT INTO skp_intervensi (
+  kategori_id, subkategori_id, kode, nama, urutan, versi, akar_id, level_minimal_id, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, versi, akar_id, level_minimal_id, kode
+`
+
+type CreateSkpIntervensiParams struct {
+	KategoriID     uuid.UUID  `json:"kategori_id"`
+	SubkategoriID  uuid.UUID  `json:"subkategori_id"`
+	Kode           *string    `json:"kode"`
+	Nama           string     `json:"nama"`
+	Urutan         int32      `json:"urutan"`
+	Versi          int32      `json:"versi"`
+	AkarID         *uuid.UUID `json:"akar_id"`
+	LevelMinimalID *uuid.UUID `json:"level_minimal_id"`
+	CreatedBy      *string    `json:"created_by"`
+}
+
+func (q *Queries) CreateSkpIntervensi(ctx context.Context, arg CreateSkpIntervensiParams) (SkpIntervensi, error) {
+	row := q.db.QueryRow(ctx, createSkpIntervensi,
+		arg.KategoriID,
+		arg.SubkategoriID,
+		arg.Kode,
+		arg.Nama,
+		arg.Urutan,
+		arg.Versi,
+		arg.AkarID,
+		arg.LevelMinimalID,
+		arg.CreatedBy,
+	)
+	var i SkpIntervensi
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.SubkategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Versi,
+		&i.AkarID,
+		&i.LevelMinimalID,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const createSkpKategori = `-- name: CreateSkpKategori :one
+INSERT INTO skp_kategori (
+  kode, nama, urutan, created_by
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode
+`
+
+type CreateSkpKategoriParams struct {
+	Kode      *string `json:"kode"`
+	Nama      string  `json:"nama"`
+	Urutan    int32   `json:"urutan"`
+	CreatedBy *string `json:"created_by"`
+}
+
+func (q *Queries) CreateSkpKategori(ctx context.Context, arg CreateSkpKategoriParams) (SkpKategori, error) {
+	row := q.db.QueryRow(ctx, createSkpKategori,
+		arg.Kode,
+		arg.Nama,
+		arg.Urutan,
+		arg.CreatedBy,
+	)
+	var i SkpKategori
+	err := row.Scan(
+		&i.ID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const createSkpLevel = `-- name: CreateSkpLevel :one
+INSERT INTO skp_level (
+  kode, nama, urutan, deskripsi, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, kode, nama, urutan, deskripsi, is_active, updated_by, updated_at, created_by, created_at
+`
+
+type CreateSkpLevelParams struct {
+	Kode      string  `json:"kode"`
+	Nama      string  `json:"nama"`
+	Urutan    int32   `json:"urutan"`
+	Deskripsi *string `json:"deskripsi"`
+	CreatedBy *string `json:"created_by"`
+}
+
+func (q *Queries) CreateSkpLevel(ctx context.Context, arg CreateSkpLevelParams) (SkpLevel, error) {
+	row := q.db.QueryRow(ctx, createSkpLevel,
+		arg.Kode,
+		arg.Nama,
+		arg.Urutan,
+		arg.Deskripsi,
+		arg.CreatedBy,
+	)
+	var i SkpLevel
+	err := row.Scan(
+		&i.ID,
+		&i.Kode,
+		&i.Nama,
+		&i.Urutan,
+		&i.Deskripsi,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createSkpSubkategori = `-- name: CreateSkpSubkategori :one
+INSERT INTO skp_subkategori (
+  kategori_id, kode, nama, urutan, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, kategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode
+`
+
+type CreateSkpSubkategoriParams struct {
+	KategoriID uuid.UUID `json:"kategori_id"`
+	Kode       *string   `json:"kode"`
+	Nama       string    `json:"nama"`
+	Urutan     int32     `json:"urutan"`
+	CreatedBy  *string   `json:"created_by"`
+}
+
+func (q *Queries) CreateSkpSubkategori(ctx context.Context, arg CreateSkpSubkategoriParams) (SkpSubkategori, error) {
+	row := q.db.QueryRow(ctx, createSkpSubkategori,
+		arg.KategoriID,
+		arg.Kode,
+		arg.Nama,
+		arg.Urutan,
+		arg.CreatedBy,
+	)
+	var i SkpSubkategori
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const getSkpIntervensi = `-- name: GetSkpIntervensi :one
+SELECT id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, versi, akar_id, level_minimal_id, kode FROM skp_intervensi
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetSkpIntervensi(ctx context.Context, id uuid.UUID) (SkpIntervensi, error) {
+	row := q.db.QueryRow(ctx, getSkpIntervensi, id)
+	var i SkpIntervensi
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.SubkategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Versi,
+		&i.AkarID,
+		&i.LevelMinimalID,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const getSkpLevel = `-- name: GetSkpLevel :one
+SELECT id, kode, nama, urutan, deskripsi, is_active, updated_by, updated_at, created_by, created_at FROM skp_level
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetSkpLevel(ctx context.Context, id uuid.UUID) (SkpLevel, error) {
+	row := q.db.QueryRow(ctx, getSkpLevel, id)
+	var i SkpLevel
+	err := row.Scan(
+		&i.ID,
+		&i.Kode,
+		&i.Nama,
+		&i.Urutan,
+		&i.Deskripsi,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSkpSubkategori = `-- name: GetSkpSubkategori :one
+SELECT id, kategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode FROM skp_subkategori
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetSkpSubkategori(ctx context.Context, id uuid.UUID) (SkpSubkategori, error) {
+	row := q.db.QueryRow(ctx, getSkpSubkategori, id)
+	var i SkpSubkategori
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const listKatalogSkp = `-- name: ListKatalogSkp :many
+SELECT
+    k.id AS kategori_id,
+    k.kode AS kategori_kode,
+    k.nama AS kategori_nama,
+    k.urutan AS kategori_urutan,
+    k.is_active AS kategori_aktif,
+    s.id AS subkategori_id,
+    s.kode AS subkategori_kode,
+    s.nama AS subkategori_nama,
+    s.urutan AS subkategori_urutan,
+    s.is_active AS subkategori_aktif,
+    i.id AS intervensi_id,
+    i.kode AS intervensi_kode,
+    i.nama AS intervensi_nama,
+    i.urutan AS intervensi_urutan,
+    i.is_active AS intervensi_aktif,
+    i.versi AS intervensi_versi,
+    i.akar_id AS intervensi_akar_id,
+    i.level_minimal_id AS intervensi_level_minimal_id
+FROM
+    public.skp_kategori k
+LEFT JOIN
+    public.skp_subkategori s ON s.kategori_id = k.id
+LEFT JOIN
+    public.skp_intervensi i ON i.subkategori_id = s.id
+WHERE
+    ($1::boolean IS NULL
+     OR (k.is_active = $1::boolean
+         AND (s.id IS NULL OR s.is_active = $1::boolean)
+         AND (i.id IS NULL OR i.is_active = $1::boolean)))
+ORDER BY
+    k.urutan, k.nama, s.urutan, s.nama, i.urutan, i.nama, i.versi
+`
+
+type ListKatalogSkpRow struct {
+	KategoriID               uuid.UUID  `json:"kategori_id"`
+	KategoriKode             *string    `json:"kategori_kode"`
+	KategoriNama             string     `json:"kategori_nama"`
+	KategoriUrutan           int32      `json:"kategori_urutan"`
+	KategoriAktif            bool       `json:"kategori_aktif"`
+	SubkategoriID            *uuid.UUID `json:"subkategori_id"`
+	SubkategoriKode          *string    `json:"subkategori_kode"`
+	SubkategoriNama          *string    `json:"subkategori_nama"`
+	SubkategoriUrutan        *int32     `json:"subkategori_urutan"`
+	SubkategoriAktif         *bool      `json:"subkategori_aktif"`
+	IntervensiID             *uuid.UUID `json:"intervensi_id"`
+	IntervensiKode           *string    `json:"intervensi_kode"`
+	IntervensiNama           *string    `json:"intervensi_nama"`
+	IntervensiUrutan         *int32     `json:"intervensi_urutan"`
+	IntervensiAktif          *bool      `json:"intervensi_aktif"`
+	IntervensiVersi          *int32     `json:"intervensi_versi"`
+	IntervensiAkarID         *uuid.UUID `json:"intervensi_akar_id"`
+	IntervensiLevelMinimalID *uuid.UUID `json:"intervensi_level_minimal_id"`
+}
+
+func (q *Queries) ListKatalogSkp(ctx context.Context, isActive *bool) ([]ListKatalogSkpRow, error) {
+	rows, err := q.db.Query(ctx, listKatalogSkp, isActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListKatalogSkpRow{}
+	for rows.Next() {
+		var i ListKatalogSkpRow
+		if err := rows.Scan(
+			&i.KategoriID,
+			&i.KategoriKode,
+			&i.KategoriNama,
+			&i.KategoriUrutan,
+			&i.KategoriAktif,
+			&i.SubkategoriID,
+			&i.SubkategoriKode,
+			&i.SubkategoriNama,
+			&i.SubkategoriUrutan,
+			&i.SubkategoriAktif,
+			&i.IntervensiID,
+			&i.IntervensiKode,
+			&i.IntervensiNama,
+			&i.IntervensiUrutan,
+			&i.IntervensiAktif,
+			&i.IntervensiVersi,
+			&i.IntervensiAkarID,
+			&i.IntervensiLevelMinimalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listKategoriSubkategoriIntervensi = `-- name: ListKategoriSubkategoriIntervensi :many
 SELECT
     k.id AS kategori_id,
@@ -25,8 +383,12 @@ JOIN
     public.skp_subkategori s ON i.subkategori_id = s.id
 JOIN
     public.skp_kategori k ON i.kategori_id = k.id
+WHERE
+    i.is_active = TRUE
+    AND s.is_active = TRUE
+    AND k.is_active = TRUE
 ORDER BY
-    k.nama, s.nama, i.nama
+    k.urutan, k.nama, s.urutan, s.nama, i.urutan, i.nama
 `
 
 type ListKategoriSubkategoriIntervensiRow struct {
@@ -64,3 +426,469 @@ func (q *Queries) ListKategoriSubkategoriIntervensi(ctx context.Context) ([]List
 	}
 	return items, nil
 }
+
+const listSemuaSkpKategori = `-- name: ListSemuaSkpKategori :many
+SELECT id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode FROM skp_kategori
+ORDER BY urutan, nama
+`
+
+func (q *Queries) ListSemuaSkpKategori(ctx context.Context) ([]SkpKategori, error) {
+	rows, err := q.db.Query(ctx, listSemuaSkpKategori)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SkpKategori{}
+	for rows.Next() {
+		var i SkpKategori
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nama,
+			&i.Urutan,
+			&i.IsActive,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Kode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSemuaSkpSubkategori = `-- name: ListSemuaSkpSubkategori :many
+SELECT id, kategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode FROM skp_subkategori
+ORDER BY urutan, nama
+`
+
+func (q *Queries) ListSemuaSkpSubkategori(ctx context.Context) ([]SkpSubkategori, error) {
+	rows, err := q.db.Query(ctx, listSemuaSkpSubkategori)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SkpSubkategori{}
+	for rows.Next() {
+		var i SkpSubkategori
+		if err := rows.Scan(
+			&i.ID,
+			&i.KategoriID,
+			&i.Nama,
+			&i.Urutan,
+			&i.IsActive,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Kode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSkpIntervensiTerbaru = `-- name: ListSkpIntervensiTerbaru :many
+SELECT DISTINCT ON (COALESCE(akar_id, id))
+  id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at,
+  created_by, created_at, versi, akar_id, level_minimal_id, kode
+FROM skp_intervensi
+ORDER BY COALESCE(akar_id, id), versi DESC
+`
+
+func (q *Queries) ListSkpIntervensiTerbaru(ctx context.Context) ([]SkpIntervensi, error) {
+	rows, err := q.db.Query(ctx, listSkpIntervensiTerbaru)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SkpIntervensi{}
+	for rows.Next() {
+		var i SkpIntervensi
+		if err := rows.Scan(
+			&i.ID,
+			&i.KategoriID,
+			&i.SubkategoriID,
+			&i.Nama,
+			&i.Urutan,
+			&i.IsActive,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Versi,
+			&i.AkarID,
+			&i.LevelMinimalID,
+			&i.Kode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSkpLevel = `-- name: ListSkpLevel :many
+SELECT id, kode, nama, urutan, deskripsi, is_active, updated_by, updated_at, created_by, created_at FROM skp_level
+WHERE ($1::boolean IS NULL OR is_active = $1::boolean)
+ORDER BY urutan, nama
+`
+
+func (q *Queries) ListSkpLevel(ctx context.Context, isActive *bool) ([]SkpLevel, error) {
+	rows, err := q.db.Query(ctx, listSkpLevel, isActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SkpLevel{}
+	for rows.Next() {
+		var i SkpLevel
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kode,
+			&i.Nama,
+			&i.Urutan,
+			&i.Deskripsi,
+			&i.IsActive,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pindahkanTargetIntervensi = `-- name: PindahkanTargetIntervensi :exec
+UPDATE skp_target
+SET skp_intervensi_id = $1,
+    updated_by = $2,
+    updated_at = now()
+WHERE skp_intervensi_id = $3
+  AND deleted_at IS NULL
+`
+
+type PindahkanTargetIntervensiParams struct {
+	IntervensiBaru uuid.UUID `json:"intervensi_baru"`
+	UpdatedBy      *string   `json:"updated_by"`
+	IntervensiLama uuid.UUID `json:"intervensi_lama"`
+}
+
+func (q *Queries) PindahkanTargetIntervensi(ctx context.Context, arg PindahkanTargetIntervensiParams) error {
+	_, err := q.db.Exec(ctx, pindahkanTargetIntervensi, arg.IntervensiBaru, arg.UpdatedBy, arg.IntervensiLama)
+	return err
+}
+
+const setLevelMinimalIntervensi = `-- name: SetLevelMinimalIntervensi :one
+UPDATE skp_intervensi
+SET
+  level_minimal_id = $1,
+  updated_by       = $2,
+  updated_at       = now()
+WHERE id = $3
+RETURNING id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, versi, akar_id, level_minimal_id, kode
+`
+
+type SetLevelMinimalIntervensiParams struct {
+	LevelMinimalID *uuid.UUID `json:"level_minimal_id"`
+	UpdatedBy      *string    `json:"updated_by"`
+	ID             uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) SetLevelMinimalIntervensi(ctx context.Context, arg SetLevelMinimalIntervensiParams) (SkpIntervensi, error) {
+	row := q.db.QueryRow(ctx, setLevelMinimalIntervensi, arg.LevelMinimalID, arg.UpdatedBy, arg.ID)
+	var i SkpIntervensi
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.SubkategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Versi,
+		&i.AkarID,
+		&i.LevelMinimalID,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const updateSkpIntervensi = `-- name: UpdateSkpIntervensi :one
+UPDATE skp_intervensi
+SET
+  kategori_id    = COALESCE($1, kategori_id),
+  subkategori_id = COALESCE($2, subkategori_id),
+  kode           = COALESCE($3, kode),
+  nama           = COALESCE($4, nama),
+  is_active      = COALESCE($5, is_active),
+  updated_by     = $6,
+  updated_at     = now()
+WHERE id = $7
+RETURNING id, kategori_id, subkategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, versi, akar_id, level_minimal_id, kode
+`
+
+type UpdateSkpIntervensiParams struct {
+	KategoriID    *uuid.UUID `json:"kategori_id"`
+	SubkategoriID *uuid.UUID `json:"subkategori_id"`
+	Kode          *string    `json:"kode"`
+	Nama          *string    `json:"nama"`
+	IsActive      *bool      `json:"is_active"`
+	UpdatedBy     *string    `json:"updated_by"`
+	ID            uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) UpdateSkpIntervensi(ctx context.Context, arg UpdateSkpIntervensiParams) (SkpIntervensi, error) {
+	row := q.db.QueryRow(ctx, updateSkpIntervensi,
+		arg.KategoriID,
+		arg.SubkategoriID,
+		arg.Kode,
+		arg.Nama,
+		arg.IsActive,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	var i SkpIntervensi
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.SubkategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Versi,
+		&i.AkarID,
+		&i.LevelMinimalID,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const updateSkpKategori = `-- name: UpdateSkpKategori :one
+UPDATE skp_kategori
+SET
+  kode       = COALESCE($1, kode),
+  nama       = COALESCE($2, nama),
+  is_active  = COALESCE($3, is_active),
+  updated_by = $4,
+  updated_at = now()
+WHERE id = $5
+RETURNING id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode
+`
+
+type UpdateSkpKategoriParams struct {
+	Kode      *string   `json:"kode"`
+	Nama      *string   `json:"nama"`
+	IsActive  *bool     `json:"is_active"`
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateSkpKategori(ctx context.Context, arg UpdateSkpKategoriParams) (SkpKategori, error) {
+	row := q.db.QueryRow(ctx, updateSkpKategori,
+		arg.Kode,
+		arg.Nama,
+		arg.IsActive,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	var i SkpKategori
+	err := row.Scan(
+		&i.ID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const updateSkpLevel = `-- name: UpdateSkpLevel :one
+UPDATE skp_level
+SET
+  nama       = COALESCE($1, nama),
+  urutan     = COALESCE($2, urutan),
+  deskripsi  = COALESCE($3, deskripsi),
+  is_active  = COALESCE($4, is_active),
+  updated_by = $5,
+  updated_at = now()
+WHERE id = $6
+RETURNING id, kode, nama, urutan, deskripsi, is_active, updated_by, updated_at, created_by, created_at
+`
+
+type UpdateSkpLevelParams struct {
+	Nama      *string   `json:"nama"`
+	Urutan    *int32    `json:"urutan"`
+	Deskripsi *string   `json:"deskripsi"`
+	IsActive  *bool     `json:"is_active"`
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateSkpLevel(ctx context.Context, arg UpdateSkpLevelParams) (SkpLevel, error) {
+	row := q.db.QueryRow(ctx, updateSkpLevel,
+		arg.Nama,
+		arg.Urutan,
+		arg.Deskripsi,
+		arg.IsActive,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	var i SkpLevel
+	err := row.Scan(
+		&i.ID,
+		&i.Kode,
+		&i.Nama,
+		&i.Urutan,
+		&i.Deskripsi,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateSkpSubkategori = `-- name: UpdateSkpSubkategori :one
+UPDATE skp_subkategori
+SET
+  kode       = COALESCE($1, kode),
+  nama       = COALESCE($2, nama),
+  is_active  = COALESCE($3, is_active),
+  updated_by = $4,
+  updated_at = now()
+WHERE id = $5
+RETURNING id, kategori_id, nama, urutan, is_active, updated_by, updated_at, created_by, created_at, kode
+`
+
+type UpdateSkpSubkategoriParams struct {
+	Kode      *string   `json:"kode"`
+	Nama      *string   `json:"nama"`
+	IsActive  *bool     `json:"is_active"`
+	UpdatedBy *string   `json:"updated_by"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateSkpSubkategori(ctx context.Context, arg UpdateSkpSubkategoriParams) (SkpSubkategori, error) {
+	row := q.db.QueryRow(ctx, updateSkpSubkategori,
+		arg.Kode,
+		arg.Nama,
+		arg.IsActive,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	var i SkpSubkategori
+	err := row.Scan(
+		&i.ID,
+		&i.KategoriID,
+		&i.Nama,
+		&i.Urutan,
+		&i.IsActive,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Kode,
+	)
+	return i, err
+}
+
+const urutkanSkpIntervensi = `-- name: UrutkanSkpIntervensi :execrows
+UPDATE skp_intervensi t
+SET urutan = u.urutan,
+    updated_by = $1,
+    updated_at = now()
+FROM unnest($2::uuid[], $3::int[]) AS u(id, urutan)
+WHERE t.id = u.id
+`
+
+type UrutkanSkpIntervensiParams struct {
+	UpdatedBy *string     `json:"updated_by"`
+	Ids       []uuid.UUID `json:"ids"`
+	Urutan    []int32     `json:"urutan"`
+}
+
+func (q *Queries) UrutkanSkpIntervensi(ctx context.Context, arg UrutkanSkpIntervensiParams) (int64, error) {
+	result, err := q.db.Exec(ctx, urutkanSkpIntervensi, arg.UpdatedBy, arg.Ids, arg.Urutan)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const urutkanSkpKategori = `-- name: UrutkanSkpKategori :execrows
+UPDATE skp_kategori t
+SET urutan = u.urutan,
+    updated_by = $1,
+    updated_at = now()
+FROM unnest($2::uuid[], $3::int[]) AS u(id, urutan)
+WHERE t.id = u.id
+`
+
+type UrutkanSkpKategoriParams struct {
+	UpdatedBy *string     `json:"updated_by"`
+	Ids       []uuid.UUID `json:"ids"`
+	Urutan    []int32     `json:"urutan"`
+}
+
+func (q *Queries) UrutkanSkpKategori(ctx context.Context, arg UrutkanSkpKategoriParams) (int64, error) {
+	result, err := q.db.Exec(ctx, urutkanSkpKategori, arg.UpdatedBy, arg.Ids, arg.Urutan)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const urutkanSkpSubkategori = `-- name: UrutkanSkpSubkategori :execrows
+UPDATE skp_subkategori t
+SET urutan = u.urutan,
+    updated_by = $1,
+    updated_at = now()
+FROM unnest($2::uuid[], $3::int[]) AS u(id, urutan)
+WHERE t.id = u.id
+`
+
+type UrutkanSkpSubkategoriParams struct {
+	UpdatedBy *string     `json:"updated_by"`
+	Ids       []uuid.UUID `json:"ids"`
+	Urutan    []int32     `json:"urutan"`
+}
+
+func (q *Queries) UrutkanSkpSubkategori(ctx context.Context, arg UrutkanSkpSubkategoriParams) (int64, error) {
+	result, err := q.db.Exec(ctx, urutkanSkpSubkategori, arg.UpdatedBy, arg.Ids, arg.Urutan)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
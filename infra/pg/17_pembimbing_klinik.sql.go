@@ -11,6 +11,30 @@ import (
 	uuid "github.com/gofrs/uuid/v5"
 )
 
+const cekPembimbingKlinikRuangan = `-- name: CekPembimbingKlinikRuangan :one
+SELECT EXISTS (
+  SELECT 1
+  FROM pembimbing_klinik pk
+  JOIN ruangan r ON r.kontrak_id = pk.kontrak_id
+  WHERE r.id = $1
+    AND pk.user_id = $2
+    AND pk.is_active = true
+    AND pk.deleted_at IS NULL
+)
+`
+
+type CekPembimbingKlinikRuanganParams struct {
+	RuanganID uuid.UUID `json:"ruangan_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) CekPembimbingKlinikRuangan(ctx context.Context, arg CekPembimbingKlinikRuanganParams) (bool, error) {
+	row := q.db.QueryRow(ctx, cekPembimbingKlinikRuangan, arg.RuanganID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const createPembimbingKlinik = `-- name: CreatePembimbingKlinik :one
 INSERT INTO pembimbing_klinik (
     fasilitas_id, kontrak_id,user_id, created_by
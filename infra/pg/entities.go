@@ -11,6 +11,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type DelegasiPembimbing struct {
+	ID           uuid.UUID          `json:"id"`
+	KontrakID    uuid.UUID          `json:"kontrak_id"`
+	PembimbingID uuid.UUID          `json:"pembimbing_id"`
+	DelegasiID   uuid.UUID          `json:"delegasi_id"`
+	TglMulai     pgtype.Date        `json:"tgl_mulai"`
+	TglSelesai   pgtype.Date        `json:"tgl_selesai"`
+	Alasan       *string            `json:"alasan"`
+	DicabutBy    *string            `json:"dicabut_by"`
+	DicabutAt    pgtype.Timestamptz `json:"dicabut_at"`
+	CreatedBy    *string            `json:"created_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type FasilitasKesehatan struct {
 	ID            uuid.UUID          `json:"id"`
 	Nama          string             `json:"nama"`
@@ -40,6 +54,22 @@ type FasilitasKesehatan struct {
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 }
 
+type JadwalDinasMahasiswa struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	RuanganID   uuid.UUID          `json:"ruangan_id"`
+	ShiftID     uuid.UUID          `json:"shift_id"`
+	TglDinas    pgtype.Date        `json:"tgl_dinas"`
+	IsActive    bool               `json:"is_active"`
+	DeletedBy   *string            `json:"deleted_by"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote *string            `json:"updated_note"`
+	UpdatedBy   *string            `json:"updated_by"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Kabupaten struct {
 	ID          uuid.UUID          `json:"id"`
 	Nama        string             `json:"nama"`
@@ -75,6 +105,27 @@ type Kehadiran struct {
 	Status           *string            `json:"status"`
 	CreatedBy        *string            `json:"created_by"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	Latitude         *float64           `json:"latitude"`
+	Longitude        *float64           `json:"longitude"`
+	Akurasi          *float64           `json:"akurasi"`
+	JarakMeter       *float64           `json:"jarak_meter"`
+	StatusLokasi     *string            `json:"status_lokasi"`
+	JamPulang        pgtype.Timestamptz `json:"jam_pulang"`
+	DurasiMenit      *int32             `json:"durasi_menit"`
+	PulangAwal       *bool              `json:"pulang_awal"`
+	JadwalID         *uuid.UUID         `json:"jadwal_id"`
+	PenempatanID     *uuid.UUID         `json:"penempatan_id"`
+}
+
+type KehadiranKeputusan struct {
+	ID                   uuid.UUID          `json:"id"`
+	KehadiranID          uuid.UUID          `json:"kehadiran_id"`
+	Status               string             `json:"status"`
+	ReviewerID           uuid.UUID          `json:"reviewer_id"`
+	ReviewedBy           *string            `json:"reviewed_by"`
+	AtasNamaID           *uuid.UUID         `json:"atas_nama_id"`
+	DelegasiPembimbingID *uuid.UUID         `json:"delegasi_pembimbing_id"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 }
 
 type KehadiranSkp struct {
@@ -92,6 +143,41 @@ type KehadiranSkp struct {
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	CreatedBy       *string            `json:"created_by"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Narasi          *string            `json:"narasi"`
+	InisialPasien   *string            `json:"inisial_pasien"`
+	KelompokUsia    *string            `json:"kelompok_usia"`
+	KamarBed        *string            `json:"kamar_bed"`
+	WaktuTindakan   pgtype.Timestamptz `json:"waktu_tindakan"`
+	CatatanReview   *string            `json:"catatan_review"`
+	ReviewerID      *uuid.UUID         `json:"reviewer_id"`
+	ReviewedBy      *string            `json:"reviewed_by"`
+	ReviewedAt      pgtype.Timestamptz `json:"reviewed_at"`
+	LevelID         *uuid.UUID         `json:"level_id"`
+}
+
+type KehadiranSkpKeputusan struct {
+	ID                   uuid.UUID          `json:"id"`
+	KehadiranSkpID       uuid.UUID          `json:"kehadiran_skp_id"`
+	Status               string             `json:"status"`
+	CatatanReview        *string            `json:"catatan_review"`
+	ReviewerID           uuid.UUID          `json:"reviewer_id"`
+	ReviewedBy           *string            `json:"reviewed_by"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	LevelID              *uuid.UUID         `json:"level_id"`
+	AtasNamaID           *uuid.UUID         `json:"atas_nama_id"`
+	DelegasiPembimbingID *uuid.UUID         `json:"delegasi_pembimbing_id"`
+}
+
+type KehadiranSkpLampiran struct {
+	ID             uuid.UUID          `json:"id"`
+	KehadiranSkpID uuid.UUID          `json:"kehadiran_skp_id"`
+	LampiranKey    string             `json:"lampiran_key"`
+	LampiranNama   string             `json:"lampiran_nama"`
+	LampiranTipe   string             `json:"lampiran_tipe"`
+	DeletedBy      *string            `json:"deleted_by"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	CreatedBy      *string            `json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 }
 
 type Kontrak struct {
@@ -113,6 +199,29 @@ type Kontrak struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 }
 
+type KoreksiKehadiran struct {
+	ID            uuid.UUID          `json:"id"`
+	KehadiranID   uuid.UUID          `json:"kehadiran_id"`
+	DiajukanOleh  uuid.UUID          `json:"diajukan_oleh"`
+	Presensi      *string            `json:"presensi"`
+	RuanganID     *uuid.UUID         `json:"ruangan_id"`
+	TglKehadiran  pgtype.Date        `json:"tgl_kehadiran"`
+	Alasan        string             `json:"alasan"`
+	Status        string             `json:"status"`
+	CatatanReview *string            `json:"catatan_review"`
+	ReviewerID    *uuid.UUID         `json:"reviewer_id"`
+	ReviewedBy    *string            `json:"reviewed_by"`
+	ReviewedAt    pgtype.Timestamptz `json:"reviewed_at"`
+	IsActive      bool               `json:"is_active"`
+	DeletedBy     *string            `json:"deleted_by"`
+	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote   *string            `json:"updated_note"`
+	UpdatedBy     *string            `json:"updated_by"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy     *string            `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type MataKuliah struct {
 	ID          uuid.UUID          `json:"id"`
 	MataKuliah  string             `json:"mata_kuliah"`
@@ -126,6 +235,28 @@ type MataKuliah struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type Notifikasi struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Jenis     string             `json:"jenis"`
+	Judul     string             `json:"judul"`
+	Pesan     string             `json:"pesan"`
+	RefID     *uuid.UUID         `json:"ref_id"`
+	DibacaAt  pgtype.Timestamptz `json:"dibaca_at"`
+	CreatedBy *string            `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type PembukaanKehadiranSkp struct {
+	ID          uuid.UUID          `json:"id"`
+	KehadiranID uuid.UUID          `json:"kehadiran_id"`
+	Alasan      string             `json:"alasan"`
+	Sebelum     []byte             `json:"sebelum"`
+	DibukaOleh  uuid.UUID          `json:"dibuka_oleh"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type PembimbingKlinik struct {
 	ID          uuid.UUID          `json:"id"`
 	FasilitasID uuid.UUID          `json:"fasilitas_id"`
@@ -141,6 +272,57 @@ type PembimbingKlinik struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type Penempatan struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	FasilitasID      uuid.UUID          `json:"fasilitas_id"`
+	KontrakID        uuid.UUID          `json:"kontrak_id"`
+	RuanganID        uuid.UUID          `json:"ruangan_id"`
+	MataKuliahID     uuid.UUID          `json:"mata_kuliah_id"`
+	PembimbingID     uuid.UUID          `json:"pembimbing_id"`
+	PembimbingKlinik uuid.UUID          `json:"pembimbing_klinik"`
+	TglMulai         pgtype.Date        `json:"tgl_mulai"`
+	TglSelesai       pgtype.Date        `json:"tgl_selesai"`
+	IsActive         bool               `json:"is_active"`
+	DeletedBy        *string            `json:"deleted_by"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote      *string            `json:"updated_note"`
+	UpdatedBy        *string            `json:"updated_by"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy        *string            `json:"created_by"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type PengajuanIzin struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	FasilitasID      uuid.UUID          `json:"fasilitas_id"`
+	KontrakID        uuid.UUID          `json:"kontrak_id"`
+	RuanganID        uuid.UUID          `json:"ruangan_id"`
+	MataKuliahID     uuid.UUID          `json:"mata_kuliah_id"`
+	PembimbingID     uuid.UUID          `json:"pembimbing_id"`
+	PembimbingKlinik uuid.UUID          `json:"pembimbing_klinik"`
+	Jenis            string             `json:"jenis"`
+	TglMulai         pgtype.Date        `json:"tgl_mulai"`
+	TglSelesai       pgtype.Date        `json:"tgl_selesai"`
+	Alasan           string             `json:"alasan"`
+	LampiranKey      *string            `json:"lampiran_key"`
+	LampiranNama     *string            `json:"lampiran_nama"`
+	LampiranTipe     *string            `json:"lampiran_tipe"`
+	Status           string             `json:"status"`
+	CatatanReview    *string            `json:"catatan_review"`
+	ReviewedBy       *string            `json:"reviewed_by"`
+	ReviewedAt       pgtype.Timestamptz `json:"reviewed_at"`
+	IsActive         bool               `json:"is_active"`
+	DeletedBy        *string            `json:"deleted_by"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote      *string            `json:"updated_note"`
+	UpdatedBy        *string            `json:"updated_by"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy        *string            `json:"created_by"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
 type Propinsi struct {
 	ID          uuid.UUID          `json:"id"`
 	Nama        string             `json:"nama"`
@@ -253,6 +435,34 @@ type R7GroupRole struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type ResetPassword struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedBy *string            `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RiwayatKehadiran struct {
+	ID          uuid.UUID          `json:"id"`
+	KehadiranID uuid.UUID          `json:"kehadiran_id"`
+	KoreksiID   *uuid.UUID         `json:"koreksi_id"`
+	Sebelum     []byte             `json:"sebelum"`
+	Sesudah     []byte             `json:"sesudah"`
+	Alasan      *string            `json:"alasan"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RiwayatPassword struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Password  string             `json:"password"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Ruangan struct {
 	ID          uuid.UUID          `json:"id"`
 	FasilitasID uuid.UUID          `json:"fasilitas_id"`
@@ -268,42 +478,114 @@ type Ruangan struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type Shift struct {
+	ID          uuid.UUID          `json:"id"`
+	Kode        string             `json:"kode"`
+	Nama        string             `json:"nama"`
+	JamMulai    pgtype.Time        `json:"jam_mulai"`
+	JamSelesai  pgtype.Time        `json:"jam_selesai"`
+	IsActive    bool               `json:"is_active"`
+	DeletedBy   *string            `json:"deleted_by"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote *string            `json:"updated_note"`
+	UpdatedBy   *string            `json:"updated_by"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type SkpIntervensi struct {
-	ID            uuid.UUID `json:"id"`
-	KategoriID    uuid.UUID `json:"kategori_id"`
-	SubkategoriID uuid.UUID `json:"subkategori_id"`
-	Nama          string    `json:"nama"`
+	ID             uuid.UUID          `json:"id"`
+	KategoriID     uuid.UUID          `json:"kategori_id"`
+	SubkategoriID  uuid.UUID          `json:"subkategori_id"`
+	Nama           string             `json:"nama"`
+	Urutan         int32              `json:"urutan"`
+	IsActive       bool               `json:"is_active"`
+	UpdatedBy      *string            `json:"updated_by"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy      *string            `json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	Versi          int32              `json:"versi"`
+	AkarID         *uuid.UUID         `json:"akar_id"`
+	LevelMinimalID *uuid.UUID         `json:"level_minimal_id"`
+	Kode           *string            `json:"kode"`
 }
 
 type SkpKategori struct {
-	ID   uuid.UUID `json:"id"`
-	Nama string    `json:"nama"`
+	ID        uuid.UUID          `json:"id"`
+	Nama      string             `json:"nama"`
+	Urutan    int32              `json:"urutan"`
+	IsActive  bool               `json:"is_active"`
+	UpdatedBy *string            `json:"updated_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy *string            `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Kode      *string            `json:"kode"`
+}
+
+type SkpLevel struct {
+	ID        uuid.UUID          `json:"id"`
+	Kode      string             `json:"kode"`
+	Nama      string             `json:"nama"`
+	Urutan    int32              `json:"urutan"`
+	Deskripsi *string            `json:"deskripsi"`
+	IsActive  bool               `json:"is_active"`
+	UpdatedBy *string            `json:"updated_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy *string            `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type SkpSubkategori struct {
-	ID         uuid.UUID `json:"id"`
-	KategoriID uuid.UUID `json:"kategori_id"`
-	Nama       string    `json:"nama"`
+	ID         uuid.UUID          `json:"id"`
+	KategoriID uuid.UUID          `json:"kategori_id"`
+	Nama       string             `json:"nama"`
+	Urutan     int32              `json:"urutan"`
+	IsActive   bool               `json:"is_active"`
+	UpdatedBy  *string            `json:"updated_by"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy  *string            `json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	Kode       *string            `json:"kode"`
+}
+
+type SkpTarget struct {
+	ID               uuid.UUID          `json:"id"`
+	MataKuliahID     uuid.UUID          `json:"mata_kuliah_id"`
+	SkpIntervensiID  *uuid.UUID         `json:"skp_intervensi_id"`
+	SkpSubkategoriID *uuid.UUID         `json:"skp_subkategori_id"`
+	JumlahTarget     int32              `json:"jumlah_target"`
+	IsActive         bool               `json:"is_active"`
+	DeletedBy        *string            `json:"deleted_by"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote      *string            `json:"updated_note"`
+	UpdatedBy        *string            `json:"updated_by"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy        *string            `json:"created_by"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 }
 
 type User struct {
-	ID             uuid.UUID          `json:"id"`
-	Nama           string             `json:"nama"`
-	Username       string             `json:"username"`
-	Password       string             `json:"password"`
-	LastActive     pgtype.Timestamptz `json:"last_active"`
-	IsActive       bool               `json:"is_active"`
-	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
-	FailedAttempts *int32             `json:"failed_attempts"`
-	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
-	Refresh        *string            `json:"refresh"`
-	DeletedBy      *string            `json:"deleted_by"`
-	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
-	UpdatedNote    *string            `json:"updated_note"`
-	UpdatedBy      *string            `json:"updated_by"`
-	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
-	CreatedBy      *string            `json:"created_by"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ID                 uuid.UUID          `json:"id"`
+	Nama               string             `json:"nama"`
+	Username           string             `json:"username"`
+	Password           string             `json:"password"`
+	LastActive         pgtype.Timestamptz `json:"last_active"`
+	IsActive           bool               `json:"is_active"`
+	LockedUntil        pgtype.Timestamptz `json:"locked_until"`
+	FailedAttempts     *int32             `json:"failed_attempts"`
+	LastFailedAt       pgtype.Timestamptz `json:"last_failed_at"`
+	Refresh            *string            `json:"refresh"`
+	DeletedBy          *string            `json:"deleted_by"`
+	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
+	UpdatedNote        *string            `json:"updated_note"`
+	UpdatedBy          *string            `json:"updated_by"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy          *string            `json:"created_by"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	Email              *string            `json:"email"`
+	WajibGantiPassword bool               `json:"wajib_ganti_password"`
+	PasswordChangedAt  pgtype.Timestamptz `json:"password_changed_at"`
 }
 
 type UserLog struct {
@@ -320,3 +602,26 @@ type UserLog struct {
 	Meta        []byte           `json:"meta"`
 	Username    *string          `json:"username"`
 }
+
+type UserOidc struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Issuer      string             `json:"issuer"`
+	Subject     string             `json:"subject"`
+	Email       *string            `json:"email"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserSesi struct {
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+	RefreshHash   string             `json:"refresh_hash"`
+	UserAgent     *string            `json:"user_agent"`
+	IpAddress     *netip.Addr        `json:"ip_address"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt    pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt     pgtype.Timestamptz `json:"revoked_at"`
+	RevokedReason *string            `json:"revoked_reason"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
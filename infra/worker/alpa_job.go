@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
+	"e-klinik/pkg/logging"
+	"e-klinik/utils"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const alpaCreatedBy = "system:alpa"
+
+// AlpaJob membuat record kehadiran "alpa" untuk mahasiswa yang ditempatkan atau
+// terjadwal dinas tetapi tidak absen sampai shift-nya selesai. Hari penempatan tanpa
+// jadwal dinas ikut dihitung (sampai akhir hari) bila jadwal tidak wajib dan mahasiswa
+// tidak punya jadwal sama sekali selama penempatan. Aman dijalankan berulang karena
+// insert memakai ON CONFLICT pada uq_kehadiran_per_hari.
+type AlpaJob struct {
+	db          *pg.Queries
+	logger      logging.Logger
+	interval    time.Duration
+	toleransi   time.Duration
+	tanpaJadwal bool
+}
+
+func NewAlpaJob(postgre *pkg.Postgres, cfg *config.Config, logger logging.Logger) *AlpaJob {
+	interval := time.Duration(cfg.Kehadiran.IntervalAlpaMenit) * time.Minute
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	return &AlpaJob{
+		db:          pg.New(postgre.Pool),
+		logger:      logger,
+		interval:    interval,
+		toleransi:   time.Duration(cfg.Kehadiran.ToleransiAlpaMenit) * time.Minute,
+		tanpaJadwal: !cfg.Kehadiran.WajibJadwal,
+	}
+}
+
+// Run memproses penempatan dan jadwal dinas pada rentang tanggal [tglAwal, tglAkhir] yang
+// shift-nya sudah selesai (ditambah toleransi) dan mengembalikan jumlah record alpa yang dibuat.
+// Jadwal dinas tanpa penempatan maupun kehadiran sebelumnya tidak bisa dibuatkan record karena
+// pembimbingnya tidak diketahui; jumlahnya dicatat di log agar penempatannya dilengkapi.
+func (j *AlpaJob) Run(ctx context.Context, tglAwal, tglAkhir time.Time) (int64, error) {
+	awal := pgtype.Date{Valid: true, Time: tglAwal}
+	akhir := pgtype.Date{Valid: true, Time: tglAkhir}
+	batas := pgtype.Timestamptz{Valid: true, Time: time.Now().Add(-j.toleransi)}
+
+	n, err := j.db.GenerateAlpaKehadiran(ctx, pg.GenerateAlpaKehadiranParams{
+		CreatedBy:    utils.StringPtr(alpaCreatedBy),
+		TglAwal:      awal,
+		TglAkhir:     akhir,
+		BatasSelesai: batas,
+		TanpaJadwal:  j.tanpaJadwal,
+	})
+	if err != nil {
+		return 0, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed generate alpa kehadiran")
+	}
+
+	lewat, err := j.db.CountJadwalAlpaTanpaPenempatan(ctx, pg.CountJadwalAlpaTanpaPenempatanParams{
+		TglAwal:      awal,
+		TglAkhir:     akhir,
+		BatasSelesai: batas,
+	})
+	if err != nil {
+		return n, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count jadwal tanpa penempatan")
+	}
+	if lewat > 0 {
+		j.logger.Warn(logging.Postgres, logging.Insert, fmt.Sprintf("%d jadwal dinas tanpa penempatan tidak dibuatkan record alpa", lewat), nil)
+	}
+	return n, nil
+}
+
+// Start menjalankan Run secara periodik untuk kemarin dan hari ini (shift malam
+// baru selesai keesokan hari) sampai ctx dibatalkan.
+func (j *AlpaJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info(logging.Postgres, logging.Shutdown, "Alpa job stopped", nil)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *AlpaJob) tick(ctx context.Context) {
+	hariIni, err := utils.GetJakartaDateObject()
+	if err != nil {
+		j.logger.Error(logging.Postgres, logging.Insert, err.Error(), nil)
+		return
+	}
+
+	n, err := j.Run(ctx, hariIni.AddDate(0, 0, -1), hariIni)
+	if err != nil {
+		j.logger.Error(logging.Postgres, logging.Insert, err.Error(), nil)
+		return
+	}
+	if n > 0 {
+		j.logger.Info(logging.Postgres, logging.Insert, fmt.Sprintf("Generated %d alpa kehadiran", n), nil)
+	}
+}
@@ -12,21 +12,26 @@ import (
 )
 
 type Initialized struct {
-	ActorHandler        *handler.ActorHandlerImpl
-	AuthHandler         *handler.AuthHandlerImpl
-	FasilitasHandler    *handler.FasilitasHandlerImpl
-	KehadiranHandler    *handler.KehadiranHandlerImpl
-	KontrakHandler      *handler.KontrakHandlerImpl
-	MataKuliahHandler   *handler.MataKuliahHandlerImpl
-	RuanganHandler      *handler.RuanganHandlerImpl
-	SkpHandler          *handler.SkpHandlerImpl
-	SkpKehadiranHandler *handler.SkpKehadiranHandlerImpl
-	SummaryHandler      *handler.SummaryHandlerImpl
-	UserHandler         *handler.UserHandlerImpl
-	PermissionHandler   *handler.PermissionHandlerImpl
+	ActorHandler            *handler.ActorHandlerImpl
+	AuthHandler             *handler.AuthHandlerImpl
+	FasilitasHandler        *handler.FasilitasHandlerImpl
+	JadwalDinasHandler      *handler.JadwalDinasHandlerImpl
+	KehadiranHandler        *handler.KehadiranHandlerImpl
+	KontrakHandler          *handler.KontrakHandlerImpl
+	KoreksiKehadiranHandler *handler.KoreksiKehadiranHandlerImpl
+	MataKuliahHandler       *handler.MataKuliahHandlerImpl
+	NotifikasiHandler       *handler.NotifikasiHandlerImpl
+	PenempatanHandler       *handler.PenempatanHandlerImpl
+	PengajuanIzinHandler    *handler.PengajuanIzinHandlerImpl
+	RuanganHandler          *handler.RuanganHandlerImpl
+	SkpHandler              *handler.SkpHandlerImpl
+	SkpKehadiranHandler     *handler.SkpKehadiranHandlerImpl
+	SummaryHandler          *handler.SummaryHandlerImpl
+	UserHandler             *handler.UserHandlerImpl
+	PermissionHandler       *handler.PermissionHandlerImpl
 }
 
-func NewApiRouter(cfg *config.Config, h *Initialized, cb *casbin.Enforcer, rdb *pkg.RedisCache) *pkg.Server {
+func NewApiRouter(cfg *config.Config, h *Initialized, cb *casbin.Enforcer, rdb *pkg.RedisCache, sesi *pkg.SesiStore) *pkg.Server {
 
 	// arangoC := pkg.NewArangoDatabase(cfg)
 	gin.SetMode("debug")
@@ -45,7 +50,7 @@ func NewApiRouter(cfg *config.Config, h *Initialized, cb *casbin.Enforcer, rdb *
 		auth := web.Group("/auth")
 		router.Auth(auth, h.AuthHandler)
 		main := web.Group("/main")
-		main.Use(middleware.JwtAuth(cfg.JWT.AccessTokenSecret))
+		main.Use(middleware.JwtAuth(cfg.JWT.AccessTokenSecret, sesi))
 		fasilitas := main.Group("/fasilitas")
 		router.Fasilitas(fasilitas, h.FasilitasHandler)
 		kontrak := main.Group("/kontrak")
@@ -54,8 +59,16 @@ func NewApiRouter(cfg *config.Config, h *Initialized, cb *casbin.Enforcer, rdb *
 		router.Ruangan(ruangan, h.RuanganHandler)
 		mataKuliah := main.Group("/mata-kuliah")
 		router.MataKuliah(mataKuliah, h.MataKuliahHandler)
+		penempatan := main.Group("/penempatan")
+		router.Penempatan(penempatan, h.PenempatanHandler)
 		kehadiran := main.Group("/kehadiran")
 		router.Kehadiran(kehadiran, h.KehadiranHandler)
+		jadwalDinas := main.Group("/jadwal-dinas")
+		router.JadwalDinas(jadwalDinas, h.JadwalDinasHandler)
+		pengajuanIzin := main.Group("/izin")
+		router.PengajuanIzin(pengajuanIzin, h.PengajuanIzinHandler)
+		koreksiKehadiran := main.Group("/koreksi-kehadiran")
+		router.KoreksiKehadiran(koreksiKehadiran, h.KoreksiKehadiranHandler)
 		kehadiranSkp := main.Group("/kehadiran-skp")
 		router.KehadiranSkp(kehadiranSkp, h.SkpKehadiranHandler)
 		skp := main.Group("/skp")
@@ -68,6 +81,8 @@ func NewApiRouter(cfg *config.Config, h *Initialized, cb *casbin.Enforcer, rdb *
 		router.Summary(summary, h.SummaryHandler)
 		permission := main.Group("/permissions")
 		router.Permission(permission, h.PermissionHandler)
+		notifikasi := main.Group("/notifikasi")
+		router.Notifikasi(notifikasi, h.NotifikasiHandler)
 
 	}
 
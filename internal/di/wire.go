@@ -14,6 +14,7 @@ import (
 
 	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
+	"github.com/minio/minio-go/v7"
 	"github.com/streadway/amqp"
 )
 
@@ -51,6 +52,16 @@ var usecaseSet = wire.NewSet(
 	wire.Bind(new(usecase.ActorUsecase), new(*usecase.ActorUsecaseImpl)),
 	usecase.NewSummaryUsecase,
 	wire.Bind(new(usecase.SummaryUsecase), new(*usecase.SummaryUsecaseImpl)),
+	usecase.NewJadwalDinasUsecase,
+	wire.Bind(new(usecase.JadwalDinasUsecase), new(*usecase.JadwalDinasUsecaseImpl)),
+	usecase.NewPengajuanIzinUsecase,
+	wire.Bind(new(usecase.PengajuanIzinUsecase), new(*usecase.PengajuanIzinUsecaseImpl)),
+	usecase.NewKoreksiKehadiranUsecase,
+	wire.Bind(new(usecase.KoreksiKehadiranUsecase), new(*usecase.KoreksiKehadiranUsecaseImpl)),
+	usecase.NewPenempatanUsecase,
+	wire.Bind(new(usecase.PenempatanUsecase), new(*usecase.PenempatanUsecaseImpl)),
+	usecase.NewNotifikasiUsecase,
+	wire.Bind(new(usecase.NotifikasiUsecase), new(*usecase.NotifikasiUsecaseImpl)),
 )
 
 var handlerSet = wire.NewSet(
@@ -78,15 +89,26 @@ var handlerSet = wire.NewSet(
 	wire.Bind(new(handler.SummaryHandler), new(*handler.SummaryHandlerImpl)),
 	handler.NewPermissionHandler,
 	wire.Bind(new(handler.PermissionHandler), new(*handler.PermissionHandlerImpl)),
+	handler.NewJadwalDinasHandler,
+	wire.Bind(new(handler.JadwalDinasHandler), new(*handler.JadwalDinasHandlerImpl)),
+	handler.NewPengajuanIzinHandler,
+	wire.Bind(new(handler.PengajuanIzinHandler), new(*handler.PengajuanIzinHandlerImpl)),
+	handler.NewKoreksiKehadiranHandler,
+	wire.Bind(new(handler.KoreksiKehadiranHandler), new(*handler.KoreksiKehadiranHandlerImpl)),
+	handler.NewPenempatanHandler,
+	wire.Bind(new(handler.PenempatanHandler), new(*handler.PenempatanHandlerImpl)),
+	handler.NewNotifikasiHandler,
+	wire.Bind(new(handler.NotifikasiHandler), new(*handler.NotifikasiHandlerImpl)),
 )
 
 // InitServer is the injector entry po int.
-func Injector(cfg *config.Config, ch *amqp.Channel, pg *pkg.Postgres, cache *pkg.RedisCache, casbin *casbin.Enforcer) *pkg.Server {
+func Injector(cfg *config.Config, ch *amqp.Channel, pg *pkg.Postgres, cache *pkg.RedisCache, casbin *casbin.Enforcer, s3 *minio.Client) *pkg.Server {
 	wire.Build(
 		// repositorySet,
 		usecaseSet,
 		handlerSet,
 		worker.NewQueueService,
+		pkg.NewSesiStore,
 		api.NewApiRouter,
 		wire.Struct(new(api.Initialized), "*"),
 	)
@@ -15,56 +15,73 @@ import (
 	"e-klinik/pkg"
 	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
+	"github.com/minio/minio-go/v7"
 	"github.com/streadway/amqp"
 )
 
 // Injectors from wire.go:
 
 // InitServer is the injector entry po int.
-func Injector(cfg *config.Config, ch *amqp.Channel, pg *pkg.Postgres, cache *pkg.RedisCache, casbin2 *casbin.Enforcer) *pkg.Server {
+func Injector(cfg *config.Config, ch *amqp.Channel, pg *pkg.Postgres, cache *pkg.RedisCache, casbin2 *casbin.Enforcer, s3 *minio.Client) *pkg.Server {
 	producerService := worker.NewQueueService(ch)
 	actorUsecaseImpl := usecase.NewActorUsecase(pg, producerService, cache)
 	actorHandlerImpl := handler.NewActorHandler(actorUsecaseImpl, cfg)
-	userUsecaseImpl := usecase.NewUserUsecase(pg, cfg, cache, casbin2)
+	sesiStore := pkg.NewSesiStore(cache, cfg)
+	userUsecaseImpl := usecase.NewUserUsecase(pg, cfg, cache, casbin2, sesiStore)
 	authHandlerImpl := handler.NewAuthHandler(userUsecaseImpl, cfg)
 	fasilitasUsecaseImpl := usecase.NewFasilitasUseCase(pg, producerService, cache)
 	fasilitasHandlerImpl := handler.NewFasilitasHandler(fasilitasUsecaseImpl, cfg)
-	kehadiranUsecaseImpl := usecase.NewKehadiranUsecase(pg, producerService, cache)
+	jadwalDinasUsecaseImpl := usecase.NewJadwalDinasUsecase(pg, producerService, cache)
+	jadwalDinasHandlerImpl := handler.NewJadwalDinasHandler(jadwalDinasUsecaseImpl, cfg)
+	kehadiranUsecaseImpl := usecase.NewKehadiranUsecase(pg, cfg, producerService, cache)
 	kehadiranHandlerImpl := handler.NewKehadiranHandler(kehadiranUsecaseImpl, cfg)
 	kontrakUsecaseImpl := usecase.NewKontrakUsecase(pg, producerService, cache)
 	kontrakHandlerImpl := handler.NewKontrakHandler(kontrakUsecaseImpl, cfg)
+	koreksiKehadiranUsecaseImpl := usecase.NewKoreksiKehadiranUsecase(pg, producerService, cache)
+	koreksiKehadiranHandlerImpl := handler.NewKoreksiKehadiranHandler(koreksiKehadiranUsecaseImpl, cfg)
 	mataKuliahUsecaseImpl := usecase.NewMataKuliahUsecase(pg, producerService, cache)
 	mataKuliahHandlerImpl := handler.NewMataKuliahHandler(mataKuliahUsecaseImpl, cfg)
+	notifikasiUsecaseImpl := usecase.NewNotifikasiUsecase(pg, producerService, cache)
+	notifikasiHandlerImpl := handler.NewNotifikasiHandler(notifikasiUsecaseImpl, cfg)
+	penempatanUsecaseImpl := usecase.NewPenempatanUsecase(pg, producerService, cache)
+	penempatanHandlerImpl := handler.NewPenempatanHandler(penempatanUsecaseImpl, cfg)
+	pengajuanIzinUsecaseImpl := usecase.NewPengajuanIzinUsecase(pg, cfg, producerService, cache, s3)
+	pengajuanIzinHandlerImpl := handler.NewPengajuanIzinHandler(pengajuanIzinUsecaseImpl, cfg)
 	ruanganUsecaseImpl := usecase.NewRuanganUsecase(pg, producerService, cache)
 	ruanganHandlerImpl := handler.NewRuanganHandler(ruanganUsecaseImpl, cfg)
 	skpUsecaseImpl := usecase.NewSkpUsecase(pg, producerService, cache)
 	skpHandlerImpl := handler.NewSkpHandler(skpUsecaseImpl, cfg)
-	skpKehadiranUsecaseImpl := usecase.NewSkpKehadiranUsecase(pg, producerService, cache)
+	skpKehadiranUsecaseImpl := usecase.NewSkpKehadiranUsecase(pg, cfg, producerService, cache, s3)
 	skpKehadiranHandlerImpl := handler.NewSkpKehadiranHandler(skpKehadiranUsecaseImpl, cfg)
-	summaryUsecaseImpl := usecase.NewSummaryUsecase(pg, producerService, cache)
+	summaryUsecaseImpl := usecase.NewSummaryUsecase(pg, cfg, producerService, cache)
 	summaryHandlerImpl := handler.NewSummaryHandler(summaryUsecaseImpl, cfg)
 	userHandlerImpl := handler.NewUserHandler(userUsecaseImpl, cfg)
 	permissionHandlerImpl := handler.NewPermissionHandler(userUsecaseImpl, cfg)
 	initialized := &api.Initialized{
-		ActorHandler:        actorHandlerImpl,
-		AuthHandler:         authHandlerImpl,
-		FasilitasHandler:    fasilitasHandlerImpl,
-		KehadiranHandler:    kehadiranHandlerImpl,
-		KontrakHandler:      kontrakHandlerImpl,
-		MataKuliahHandler:   mataKuliahHandlerImpl,
-		RuanganHandler:      ruanganHandlerImpl,
-		SkpHandler:          skpHandlerImpl,
-		SkpKehadiranHandler: skpKehadiranHandlerImpl,
-		SummaryHandler:      summaryHandlerImpl,
-		UserHandler:         userHandlerImpl,
-		PermissionHandler:   permissionHandlerImpl,
+		ActorHandler:            actorHandlerImpl,
+		AuthHandler:             authHandlerImpl,
+		FasilitasHandler:        fasilitasHandlerImpl,
+		JadwalDinasHandler:      jadwalDinasHandlerImpl,
+		KehadiranHandler:        kehadiranHandlerImpl,
+		KontrakHandler:          kontrakHandlerImpl,
+		KoreksiKehadiranHandler: koreksiKehadiranHandlerImpl,
+		MataKuliahHandler:       mataKuliahHandlerImpl,
+		NotifikasiHandler:       notifikasiHandlerImpl,
+		PenempatanHandler:       penempatanHandlerImpl,
+		PengajuanIzinHandler:    pengajuanIzinHandlerImpl,
+		RuanganHandler:          ruanganHandlerImpl,
+		SkpHandler:              skpHandlerImpl,
+		SkpKehadiranHandler:     skpKehadiranHandlerImpl,
+		SummaryHandler:          summaryHandlerImpl,
+		UserHandler:             userHandlerImpl,
+		PermissionHandler:       permissionHandlerImpl,
 	}
-	server := api.NewApiRouter(cfg, initialized, casbin2, cache)
+	server := api.NewApiRouter(cfg, initialized, casbin2, cache, sesiStore)
 	return server
 }
 
 // wire.go:
 
-var usecaseSet = wire.NewSet(usecase.NewUserUsecase, wire.Bind(new(usecase.UserUsecase), new(*usecase.UserUsecaseImpl)), usecase.NewFasilitasUseCase, wire.Bind(new(usecase.FasilitasUsecase), new(*usecase.FasilitasUsecaseImpl)), usecase.NewKontrakUsecase, wire.Bind(new(usecase.KontrakUsecase), new(*usecase.KontrakUsecaseImpl)), usecase.NewRuanganUsecase, wire.Bind(new(usecase.RuanganUsecase), new(*usecase.RuanganUsecaseImpl)), usecase.NewMataKuliahUsecase, wire.Bind(new(usecase.MataKuliahUsecase), new(*usecase.MataKuliahUsecaseImpl)), usecase.NewKehadiranUsecase, wire.Bind(new(usecase.KehadiranUsecase), new(*usecase.KehadiranUsecaseImpl)), usecase.NewSkpKehadiranUsecase, wire.Bind(new(usecase.SkpKehadiranUsecase), new(*usecase.SkpKehadiranUsecaseImpl)), usecase.NewSkpUsecase, wire.Bind(new(usecase.SkpUsecase), new(*usecase.SkpUsecaseImpl)), usecase.NewActorUsecase, wire.Bind(new(usecase.ActorUsecase), new(*usecase.ActorUsecaseImpl)), usecase.NewSummaryUsecase, wire.Bind(new(usecase.SummaryUsecase), new(*usecase.SummaryUsecaseImpl)))
+var usecaseSet = wire.NewSet(usecase.NewUserUsecase, wire.Bind(new(usecase.UserUsecase), new(*usecase.UserUsecaseImpl)), usecase.NewFasilitasUseCase, wire.Bind(new(usecase.FasilitasUsecase), new(*usecase.FasilitasUsecaseImpl)), usecase.NewKontrakUsecase, wire.Bind(new(usecase.KontrakUsecase), new(*usecase.KontrakUsecaseImpl)), usecase.NewRuanganUsecase, wire.Bind(new(usecase.RuanganUsecase), new(*usecase.RuanganUsecaseImpl)), usecase.NewMataKuliahUsecase, wire.Bind(new(usecase.MataKuliahUsecase), new(*usecase.MataKuliahUsecaseImpl)), usecase.NewKehadiranUsecase, wire.Bind(new(usecase.KehadiranUsecase), new(*usecase.KehadiranUsecaseImpl)), usecase.NewSkpKehadiranUsecase, wire.Bind(new(usecase.SkpKehadiranUsecase), new(*usecase.SkpKehadiranUsecaseImpl)), usecase.NewSkpUsecase, wire.Bind(new(usecase.SkpUsecase), new(*usecase.SkpUsecaseImpl)), usecase.NewActorUsecase, wire.Bind(new(usecase.ActorUsecase), new(*usecase.ActorUsecaseImpl)), usecase.NewSummaryUsecase, wire.Bind(new(usecase.SummaryUsecase), new(*usecase.SummaryUsecaseImpl)), usecase.NewJadwalDinasUsecase, wire.Bind(new(usecase.JadwalDinasUsecase), new(*usecase.JadwalDinasUsecaseImpl)), usecase.NewPengajuanIzinUsecase, wire.Bind(new(usecase.PengajuanIzinUsecase), new(*usecase.PengajuanIzinUsecaseImpl)), usecase.NewKoreksiKehadiranUsecase, wire.Bind(new(usecase.KoreksiKehadiranUsecase), new(*usecase.KoreksiKehadiranUsecaseImpl)), usecase.NewPenempatanUsecase, wire.Bind(new(usecase.PenempatanUsecase), new(*usecase.PenempatanUsecaseImpl)), usecase.NewNotifikasiUsecase, wire.Bind(new(usecase.NotifikasiUsecase), new(*usecase.NotifikasiUsecaseImpl)))
 
-var handlerSet = wire.NewSet(handler.NewAuthHandler, wire.Bind(new(handler.AuthHandler), new(*handler.AuthHandlerImpl)), handler.NewUserHandler, wire.Bind(new(handler.UserHandler), new(*handler.UserHandlerImpl)), handler.NewFasilitasHandler, wire.Bind(new(handler.FasilitasHandler), new(*handler.FasilitasHandlerImpl)), handler.NewKontrakHandler, wire.Bind(new(handler.KontrakHandler), new(*handler.KontrakHandlerImpl)), handler.NewRuanganHandler, wire.Bind(new(handler.RuanganHandler), new(*handler.RuanganHandlerImpl)), handler.NewMataKuliahHandler, wire.Bind(new(handler.MataKuliahHandler), new(*handler.MataKuliahHandlerImpl)), handler.NewKehadiranHandler, wire.Bind(new(handler.KehadiranHandler), new(*handler.KehadiranHandlerImpl)), handler.NewSkpKehadiranHandler, wire.Bind(new(handler.SkpKehadiranHandler), new(*handler.SkpKehadiranHandlerImpl)), handler.NewSkpHandler, wire.Bind(new(handler.SkpHandler), new(*handler.SkpHandlerImpl)), handler.NewActorHandler, wire.Bind(new(handler.ActorHandler), new(*handler.ActorHandlerImpl)), handler.NewSummaryHandler, wire.Bind(new(handler.SummaryHandler), new(*handler.SummaryHandlerImpl)), handler.NewPermissionHandler, wire.Bind(new(handler.PermissionHandler), new(*handler.PermissionHandlerImpl)))
+var handlerSet = wire.NewSet(handler.NewAuthHandler, wire.Bind(new(handler.AuthHandler), new(*handler.AuthHandlerImpl)), handler.NewUserHandler, wire.Bind(new(handler.UserHandler), new(*handler.UserHandlerImpl)), handler.NewFasilitasHandler, wire.Bind(new(handler.FasilitasHandler), new(*handler.FasilitasHandlerImpl)), handler.NewKontrakHandler, wire.Bind(new(handler.KontrakHandler), new(*handler.KontrakHandlerImpl)), handler.NewRuanganHandler, wire.Bind(new(handler.RuanganHandler), new(*handler.RuanganHandlerImpl)), handler.NewMataKuliahHandler, wire.Bind(new(handler.MataKuliahHandler), new(*handler.MataKuliahHandlerImpl)), handler.NewKehadiranHandler, wire.Bind(new(handler.KehadiranHandler), new(*handler.KehadiranHandlerImpl)), handler.NewSkpKehadiranHandler, wire.Bind(new(handler.SkpKehadiranHandler), new(*handler.SkpKehadiranHandlerImpl)), handler.NewSkpHandler, wire.Bind(new(handler.SkpHandler), new(*handler.SkpHandlerImpl)), handler.NewActorHandler, wire.Bind(new(handler.ActorHandler), new(*handler.ActorHandlerImpl)), handler.NewSummaryHandler, wire.Bind(new(handler.SummaryHandler), new(*handler.SummaryHandlerImpl)), handler.NewPermissionHandler, wire.Bind(new(handler.PermissionHandler), new(*handler.PermissionHandlerImpl)), handler.NewJadwalDinasHandler, wire.Bind(new(handler.JadwalDinasHandler), new(*handler.JadwalDinasHandlerImpl)), handler.NewPengajuanIzinHandler, wire.Bind(new(handler.PengajuanIzinHandler), new(*handler.PengajuanIzinHandlerImpl)), handler.NewKoreksiKehadiranHandler, wire.Bind(new(handler.KoreksiKehadiranHandler), new(*handler.KoreksiKehadiranHandlerImpl)), handler.NewPenempatanHandler, wire.Bind(new(handler.PenempatanHandler), new(*handler.PenempatanHandlerImpl)), handler.NewNotifikasiHandler, wire.Bind(new(handler.NotifikasiHandler), new(*handler.NotifikasiHandlerImpl)))
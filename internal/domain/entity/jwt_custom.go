@@ -13,10 +13,19 @@ type JwtCustomClaims struct {
 }
 
 type JwtCustomRefreshClaims struct {
-	Username string `json:"username"`
-	Nama     string `json:"nama"`
-	Role     string `json:"role"`
-	Session  string `json:"session"`
+	Username      string `json:"username"`
+	Nama          string `json:"nama"`
+	Role          string `json:"role"`
+	Session       string `json:"session"`
+	GantiPassword bool   `json:"ganti_password,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JwtQrKehadiranClaims adalah isi token QR absen yang ditampilkan pembimbing klinik.
+type JwtQrKehadiranClaims struct {
+	RuanganID        string `json:"ruangan_id"`
+	PembimbingKlinik string `json:"pembimbing_klinik"`
+	Tgl              string `json:"tgl"`
 	jwt.RegisteredClaims
 }
 
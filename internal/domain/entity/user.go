@@ -10,6 +10,8 @@ type User struct {
 	Nama     string `json:"nama"`
 	Role     string `json:"role"`
 	Session  string `json:"session,omitempty"`
+	// GantiPassword menandai password sementara; token hanya boleh dipakai untuk mengganti password.
+	GantiPassword bool `json:"ganti_password,omitempty"`
 }
 type UserCreated struct {
 	AppID        string   `json:"app_id"`
@@ -132,6 +132,17 @@ type SearchKehadiran struct {
 	Offset       int32   `form:"offset" json:"offset"`
 	Limit        int32   `form:"limit" json:"limit"`
 }
+
+type CreateKehadiran struct {
+	Presensi  string    `json:"presensi"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	Akurasi   *float64  `json:"akurasi"`
+	QrToken   string    `json:"qr_token"`
+	UserID    uuid.UUID `json:"-"`
+	CreatedBy *string   `json:"-"`
+}
+
 type SearchKehadiranSkp struct {
 	Page            int32   `form:"page" json:"page"`
 	Status          *string `json:"status"`
@@ -190,6 +201,7 @@ type UpdateUser struct {
 	IsActive    *bool     `json:"is_active"`
 	Password    *string   `json:"password"`
 	Refresh     *string   `json:"refresh"`
+	Email       *string   `json:"email"`
 	UpdatedNote *string   `json:"updated_note"`
 	UpdatedBy   *string   `json:"updated_by"`
 	ID          uuid.UUID `json:"id"`
@@ -210,16 +222,46 @@ type Refresh struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// Perangkat adalah identitas perangkat yang dicatat pada sesi login.
+type Perangkat struct {
+	UserAgent string
+	IpAddress string
+}
+
+type GantiPassword struct {
+	PasswordLama string  `json:"password_lama"`
+	PasswordBaru string  `json:"password_baru"`
+	UpdatedBy    *string `json:"updated_by"`
+}
+
+type ResetPassword struct {
+	Token        string `json:"token"`
+	PasswordBaru string `json:"password_baru"`
+}
+
 type SearchRekapKehadiranMahasiswa struct {
 	UserID   string `form:"user_id" json:"user_id"`
 	TglAwal  string `form:"tgl_awal" json:"tgl_awal"`
 	TglAkhir string `form:"tgl_akhir" json:"tgl_akhir"`
 }
 
+// ApproveKehadiranSkp memuat keputusan pembimbing. SkpKehadiranID adalah tindakan yang
+// disetujui dengan tingkat LevelID; tindakan dengan tingkat berbeda, ditolak atau
+// dikembalikan untuk revisi dikirim lewat Keputusan.
 type ApproveKehadiranSkp struct {
-	UpdatedBy      *string     `json:"updated_by"`
-	SkpKehadiranID []uuid.UUID `json:"skp_kehadiran_id"`
-	KehadiranID    uuid.UUID   `json:"kehadiran_id"`
+	UpdatedBy      *string        `json:"updated_by"`
+	SkpKehadiranID []uuid.UUID    `json:"skp_kehadiran_id"`
+	LevelID        *uuid.UUID     `json:"level_id"`
+	Keputusan      []KeputusanSkp `json:"keputusan"`
+	KehadiranID    uuid.UUID      `json:"kehadiran_id"`
+	ReviewerID     uuid.UUID      `json:"-"`
+}
+
+type KeputusanSkp struct {
+	ID      uuid.UUID  `json:"id"`
+	Status  string     `json:"status"`
+	LevelID *uuid.UUID `json:"level_id"`
+	Alasan  *string    `json:"alasan"`
 }
 
 type SearchSkpTercapai struct {
@@ -227,3 +269,310 @@ type SearchSkpTercapai struct {
 	TglAwal  string `form:"tgl_awal" json:"tgl_awal"`
 	TglAkhir string `form:"tgl_akhir" json:"tgl_akhir"`
 }
+
+type CreateShift struct {
+	Kode       string  `json:"kode"`
+	Nama       string  `json:"nama"`
+	JamMulai   string  `json:"jam_mulai"`
+	JamSelesai string  `json:"jam_selesai"`
+	CreatedBy  *string `json:"-"`
+}
+
+type UpdateShift struct {
+	Nama       *string   `json:"nama"`
+	JamMulai   *string   `json:"jam_mulai"`
+	JamSelesai *string   `json:"jam_selesai"`
+	IsActive   *bool     `json:"is_active"`
+	UpdatedBy  *string   `json:"-"`
+	ID         uuid.UUID `json:"-"`
+}
+
+type CreateJadwalDinas struct {
+	UserIDs    []uuid.UUID `json:"user_ids"`
+	RuanganID  uuid.UUID   `json:"ruangan_id"`
+	ShiftID    uuid.UUID   `json:"shift_id"`
+	TglMulai   string      `json:"tgl_mulai"`
+	TglSelesai string      `json:"tgl_selesai"`
+	CreatedBy  *string     `json:"-"`
+}
+
+type SearchJadwalDinas struct {
+	Page      int32  `form:"page" json:"page"`
+	UserID    string `form:"user_id" json:"user_id"`
+	RuanganID string `form:"ruangan_id" json:"ruangan_id"`
+	TglAwal   string `form:"tgl_awal" json:"tgl_awal"`
+	TglAkhir  string `form:"tgl_akhir" json:"tgl_akhir"`
+	Offset    int32  `form:"offset" json:"offset"`
+	Limit     int32  `form:"limit" json:"limit"`
+}
+
+type CreatePengajuanIzin struct {
+	Jenis      string    `form:"jenis" json:"jenis"`
+	TglMulai   string    `form:"tgl_mulai" json:"tgl_mulai"`
+	TglSelesai string    `form:"tgl_selesai" json:"tgl_selesai"`
+	Alasan     string    `form:"alasan" json:"alasan"`
+	UserID     uuid.UUID `form:"-" json:"-"`
+	CreatedBy  *string   `form:"-" json:"-"`
+}
+
+type SearchPengajuanIzin struct {
+	Page       int32  `form:"page" json:"page"`
+	UserID     string `form:"user_id" json:"user_id"`
+	ReviewerID string `form:"reviewer_id" json:"reviewer_id"`
+	Status     string `form:"status" json:"status"`
+	Offset     int32  `form:"offset" json:"offset"`
+	Limit      int32  `form:"limit" json:"limit"`
+	// PemanggilID membatasi hasil ke pengajuan milik atau yang direview pemanggil; nil untuk admin/koordinator.
+	PemanggilID *uuid.UUID `form:"-" json:"-"`
+}
+
+type ReviewPengajuanIzin struct {
+	Status        string    `json:"status"`
+	CatatanReview *string   `json:"catatan_review"`
+	ID            uuid.UUID `json:"-"`
+	ReviewerID    uuid.UUID `json:"-"`
+	ReviewedBy    *string   `json:"-"`
+}
+
+type CreateKoreksiKehadiran struct {
+	KehadiranID  uuid.UUID  `json:"kehadiran_id"`
+	Presensi     *string    `json:"presensi"`
+	RuanganID    *uuid.UUID `json:"ruangan_id"`
+	TglKehadiran *string    `json:"tgl_kehadiran"`
+	Alasan       string     `json:"alasan"`
+	DiajukanOleh uuid.UUID  `json:"-"`
+	CreatedBy    *string    `json:"-"`
+}
+
+type SearchKoreksiKehadiran struct {
+	Page         int32  `form:"page" json:"page"`
+	KehadiranID  string `form:"kehadiran_id" json:"kehadiran_id"`
+	DiajukanOleh string `form:"diajukan_oleh" json:"diajukan_oleh"`
+	ReviewerID   string `form:"reviewer_id" json:"reviewer_id"`
+	Status       string `form:"status" json:"status"`
+	Offset       int32  `form:"offset" json:"offset"`
+	Limit        int32  `form:"limit" json:"limit"`
+	// PemanggilID membatasi hasil ke koreksi atas kehadiran milik, yang diajukan, atau yang dibimbing pemanggil; nil untuk admin/koordinator.
+	PemanggilID *uuid.UUID `form:"-" json:"-"`
+}
+
+type ReviewKoreksiKehadiran struct {
+	Status        string    `json:"status"`
+	CatatanReview *string   `json:"catatan_review"`
+	ID            uuid.UUID `json:"-"`
+	ReviewerID    uuid.UUID `json:"-"`
+	ReviewedBy    *string   `json:"-"`
+}
+
+type ImportKehadiran struct {
+	KontrakID string  `form:"kontrak_id" json:"kontrak_id"`
+	DryRun    bool    `form:"dry_run" json:"dry_run"`
+	CreatedBy *string `form:"-" json:"-"`
+}
+
+type EksporRekapKehadiran struct {
+	Lingkup  string `form:"lingkup" json:"lingkup"`
+	ID       string `form:"id" json:"id"`
+	TglAwal  string `form:"tgl_awal" json:"tgl_awal"`
+	TglAkhir string `form:"tgl_akhir" json:"tgl_akhir"`
+	Format   string `form:"format" json:"format"`
+}
+
+type CreatePenempatan struct {
+	UserID           uuid.UUID `json:"user_id"`
+	KontrakID        uuid.UUID `json:"kontrak_id"`
+	RuanganID        uuid.UUID `json:"ruangan_id"`
+	MataKuliahID     uuid.UUID `json:"mata_kuliah_id"`
+	PembimbingID     uuid.UUID `json:"pembimbing_id"`
+	PembimbingKlinik uuid.UUID `json:"pembimbing_klinik"`
+	TglMulai         string    `json:"tgl_mulai"`
+	TglSelesai       string    `json:"tgl_selesai"`
+	CreatedBy        *string   `json:"-"`
+}
+
+type UpdatePenempatan struct {
+	KontrakID        uuid.UUID `json:"kontrak_id"`
+	RuanganID        uuid.UUID `json:"ruangan_id"`
+	MataKuliahID     uuid.UUID `json:"mata_kuliah_id"`
+	PembimbingID     uuid.UUID `json:"pembimbing_id"`
+	PembimbingKlinik uuid.UUID `json:"pembimbing_klinik"`
+	TglMulai         string    `json:"tgl_mulai"`
+	TglSelesai       string    `json:"tgl_selesai"`
+	IsActive         *bool     `json:"is_active"`
+	UpdatedNote      *string   `json:"updated_note"`
+	ID               uuid.UUID `json:"-"`
+	UpdatedBy        *string   `json:"-"`
+}
+
+type SearchPenempatan struct {
+	Page         int32  `form:"page" json:"page"`
+	UserID       string `form:"user_id" json:"user_id"`
+	KontrakID    string `form:"kontrak_id" json:"kontrak_id"`
+	RuanganID    string `form:"ruangan_id" json:"ruangan_id"`
+	PembimbingID string `form:"pembimbing_id" json:"pembimbing_id"`
+	Tgl          string `form:"tgl" json:"tgl"`
+	Offset       int32  `form:"offset" json:"offset"`
+	Limit        int32  `form:"limit" json:"limit"`
+	// PemanggilID membatasi hasil ke penempatan milik atau yang dibimbing pemanggil; nil untuk admin/koordinator.
+	PemanggilID *uuid.UUID `form:"-" json:"-"`
+}
+
+type CreateSkpTarget struct {
+	MataKuliahID     uuid.UUID  `json:"mata_kuliah_id"`
+	SkpIntervensiID  *uuid.UUID `json:"skp_intervensi_id"`
+	SkpSubkategoriID *uuid.UUID `json:"skp_subkategori_id"`
+	JumlahTarget     int32      `json:"jumlah_target"`
+	CreatedBy        *string    `json:"-"`
+}
+
+type SearchSkpTarget struct {
+	Page         int32  `form:"page" json:"page"`
+	MataKuliahID string `form:"mata_kuliah_id" json:"mata_kuliah_id"`
+	Offset       int32  `form:"offset" json:"offset"`
+	Limit        int32  `form:"limit" json:"limit"`
+}
+
+type SearchProgresSkp struct {
+	UserID       string `form:"user_id" json:"user_id"`
+	MataKuliahID string `form:"mata_kuliah_id" json:"mata_kuliah_id"`
+}
+
+type CreateSkpKategori struct {
+	Kode      *string `json:"kode"`
+	Nama      string  `json:"nama"`
+	Urutan    int32   `json:"urutan"`
+	CreatedBy *string `json:"-"`
+}
+
+type CreateSkpSubkategori struct {
+	KategoriID uuid.UUID `json:"kategori_id"`
+	Kode       *string   `json:"kode"`
+	Nama       string    `json:"nama"`
+	Urutan     int32     `json:"urutan"`
+	CreatedBy  *string   `json:"-"`
+}
+
+type CreateSkpIntervensi struct {
+	SubkategoriID  uuid.UUID  `json:"subkategori_id"`
+	Kode           *string    `json:"kode"`
+	Nama           string     `json:"nama"`
+	Urutan         int32      `json:"urutan"`
+	LevelMinimalID *uuid.UUID `json:"level_minimal_id"`
+	CreatedBy      *string    `json:"-"`
+}
+
+type UpdateSkpIntervensi struct {
+	SubkategoriID *uuid.UUID `json:"subkategori_id"`
+	Kode          *string    `json:"kode"`
+	Nama          *string    `json:"nama"`
+	IsActive      *bool      `json:"is_active"`
+	ID            uuid.UUID  `json:"-"`
+	UpdatedBy     *string    `json:"-"`
+}
+
+type ItemUrutanSkp struct {
+	ID     uuid.UUID `json:"id"`
+	Urutan int32     `json:"urutan"`
+}
+
+type UrutkanSkp struct {
+	Item      []ItemUrutanSkp `json:"item"`
+	UpdatedBy *string         `json:"-"`
+}
+
+type SearchKatalogSkp struct {
+	IsActive *bool `form:"is_active" json:"is_active"`
+}
+
+type BuktiKehadiranSkp struct {
+	Narasi        *string   `json:"narasi"`
+	InisialPasien *string   `json:"inisial_pasien"`
+	KelompokUsia  *string   `json:"kelompok_usia"`
+	KamarBed      *string   `json:"kamar_bed"`
+	WaktuTindakan *string   `json:"waktu_tindakan"`
+	ID            uuid.UUID `json:"-"`
+	UserID        uuid.UUID `json:"-"`
+	UpdatedBy     *string   `json:"-"`
+}
+
+type CreateSkpLevel struct {
+	Kode      string  `json:"kode"`
+	Nama      string  `json:"nama"`
+	Urutan    int32   `json:"urutan"`
+	Deskripsi *string `json:"deskripsi"`
+	CreatedBy *string `json:"-"`
+}
+
+type SetLevelMinimalSkp struct {
+	LevelMinimalID *uuid.UUID `json:"level_minimal_id"`
+	ID             uuid.UUID  `json:"-"`
+	UpdatedBy      *string    `json:"-"`
+}
+
+type SearchInboxApproval struct {
+	Page         int32     `form:"page" json:"page"`
+	UserID       string    `form:"user_id" json:"user_id"`
+	RuanganID    string    `form:"ruangan_id" json:"ruangan_id"`
+	TglAwal      string    `form:"tgl_awal" json:"tgl_awal"`
+	TglAkhir     string    `form:"tgl_akhir" json:"tgl_akhir"`
+	LewatSla     *bool     `form:"lewat_sla" json:"lewat_sla"`
+	Offset       int32     `form:"offset" json:"offset"`
+	Limit        int32     `form:"limit" json:"limit"`
+	PembimbingID uuid.UUID `form:"-" json:"-"`
+}
+
+// BatchKeputusanSkp menerapkan satu keputusan ke seluruh tindakan yang menunggu review
+// pada beberapa kehadiran.
+type BatchKeputusanSkp struct {
+	KehadiranID []uuid.UUID `json:"kehadiran_id"`
+	Status      string      `json:"status"`
+	LevelID     *uuid.UUID  `json:"level_id"`
+	Alasan      *string     `json:"alasan"`
+	ReviewerID  uuid.UUID   `json:"-"`
+	UpdatedBy   *string     `json:"-"`
+}
+
+type BukaKunciSkp struct {
+	Alasan      string    `json:"alasan"`
+	KehadiranID uuid.UUID `json:"-"`
+	DibukaOleh  uuid.UUID `json:"-"`
+	UpdatedBy   *string   `json:"-"`
+}
+
+type SearchNotifikasi struct {
+	Page        int32     `form:"page" json:"page"`
+	BelumDibaca *bool     `form:"belum_dibaca" json:"belum_dibaca"`
+	Offset      int32     `form:"offset" json:"offset"`
+	Limit       int32     `form:"limit" json:"limit"`
+	UserID      uuid.UUID `form:"-" json:"-"`
+}
+
+type ImportKatalogSkp struct {
+	DryRun    bool    `form:"dry_run" json:"dry_run"`
+	UpdatedBy *string `form:"-" json:"-"`
+}
+
+type EksporKatalogSkp struct {
+	Format string `form:"format" json:"format"`
+}
+
+// CreateDelegasiPembimbing mendelegasikan persetujuan PembimbingID ke DelegasiID pada satu
+// kontrak selama tgl_mulai s.d. tgl_selesai (YYYY-MM-DD, inklusif).
+type CreateDelegasiPembimbing struct {
+	KontrakID    uuid.UUID `json:"kontrak_id"`
+	DelegasiID   uuid.UUID `json:"delegasi_id"`
+	TglMulai     string    `json:"tgl_mulai"`
+	TglSelesai   string    `json:"tgl_selesai"`
+	Alasan       *string   `json:"alasan"`
+	PembimbingID uuid.UUID `json:"-"`
+	CreatedBy    *string   `json:"-"`
+}
+
+type SearchDelegasiPembimbing struct {
+	Page      int32     `form:"page" json:"page"`
+	KontrakID string    `form:"kontrak_id" json:"kontrak_id"`
+	Aktif     *bool     `form:"aktif" json:"aktif"`
+	Offset    int32     `form:"offset" json:"offset"`
+	Limit     int32     `form:"limit" json:"limit"`
+	UserID    uuid.UUID `form:"-" json:"-"`
+}
@@ -1,5 +1,7 @@
 package resp
 
+import "e-klinik/infra/pg"
+
 type User struct {
 	ID                 string `json:"id"`
 	Username           string `json:"username"`
@@ -8,9 +10,16 @@ type User struct {
 	AccessToken        string `json:"accessToken"`
 	RefreshToken       string `json:"refreshToken"`
 	AccessTokenExpires int64  `json:"accessTokenExpires"`
+	WajibGantiPassword bool   `json:"wajibGantiPassword"`
 }
 
 type RefreshToken struct {
 	Token string `json:"token"`
 	Exp   int64  `json:"exp"`
 }
+
+// SesiUser adalah sesi perangkat aktif; SaatIni menandai sesi yang sedang dipakai.
+type SesiUser struct {
+	pg.ListUserSesiAktifRow
+	SaatIni bool `json:"saat_ini"`
+}
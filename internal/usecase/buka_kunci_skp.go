@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PembukaanKehadiranSkp adalah bentuk respons riwayat pembukaan dengan snapshot JSON apa adanya.
+type PembukaanKehadiranSkp struct {
+	ID          uuid.UUID          `json:"id"`
+	KehadiranID uuid.UUID          `json:"kehadiran_id"`
+	Alasan      string             `json:"alasan"`
+	Sebelum     json.RawMessage    `json:"sebelum"`
+	DibukaOleh  uuid.UUID          `json:"dibuka_oleh"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// snapshotPembukaan adalah isi kolom sebelum: kehadiran beserta seluruh tindakan SKP-nya,
+// termasuk keputusan terakhir yang akan dihapus saat dibuka.
+type snapshotPembukaan struct {
+	Kehadiran pg.Kehadiran      `json:"kehadiran"`
+	Skp       []pg.KehadiranSkp `json:"skp"`
+}
+
+// BukaKunciSkp membuka kembali SKP sebuah kehadiran yang sudah diputuskan. Kunci, status dan
+// keputusan terakhir setiap tindakan direset sehingga mahasiswa dapat mengubah tindakan dan
+// pembimbing dapat mereview ulang. Keadaan sebelum dibuka disimpan ke riwayat yang tidak
+// dapat diubah, lalu mahasiswa dan pembimbing diberi notifikasi.
+func (mu *SkpKehadiranUsecaseImpl) BukaKunciSkp(c context.Context, arg request.BukaKunciSkp) (any, error) {
+	alasan := strings.TrimSpace(arg.Alasan)
+	if alasan == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "alasan wajib diisi")
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		return bukaKunciSkp(c, qtx, arg, alasan)
+	})
+}
+
+// bukaKunciSkp menjalankan pembukaan di dalam transaksi qtx: menolak SKP yang belum dikunci,
+// menyimpan snapshot, mereset tindakan dan status kehadiran, lalu mengirim notifikasi.
+func bukaKunciSkp(c context.Context, qtx *pg.Queries, arg request.BukaKunciSkp, alasan string) (any, error) {
+	kehadiran, err := qtx.GetKehadiran(c, arg.KehadiranID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran not found")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+
+	skp, err := qtx.ListKehadiranSkpByKehadiran(c, arg.KehadiranID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran skp")
+	}
+	terkunci := kehadiran.Status != nil
+	for _, s := range skp {
+		terkunci = terkunci || utils.DerefBool(s.Locked)
+	}
+	if !terkunci {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "SKP kehadiran ini belum dikunci")
+	}
+
+	sebelum, err := json.Marshal(snapshotPembukaan{Kehadiran: kehadiran, Skp: skp})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed encode pembukaan skp")
+	}
+	pembukaan, err := qtx.CreatePembukaanKehadiranSkp(c, pg.CreatePembukaanKehadiranSkpParams{
+		KehadiranID: arg.KehadiranID,
+		Alasan:      alasan,
+		Sebelum:     sebelum,
+		DibukaOleh:  arg.DibukaOleh,
+		CreatedBy:   arg.UpdatedBy,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create pembukaan skp")
+	}
+
+	if _, err := qtx.BukaKunciKehadiranSkp(c, pg.BukaKunciKehadiranSkpParams{
+		Alasan:      &alasan,
+		UpdatedBy:   arg.UpdatedBy,
+		KehadiranID: arg.KehadiranID,
+	}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed buka kunci kehadiran skp")
+	}
+	if _, err := qtx.BukaStatusKehadiran(c, pg.BukaStatusKehadiranParams{
+		Alasan:    &alasan,
+		UpdatedBy: arg.UpdatedBy,
+		ID:        arg.KehadiranID,
+	}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update kehadiran")
+	}
+
+	// 🔔 Mahasiswa dan kedua pembimbing perlu tahu SKP harus ditinjau ulang
+	pesan := fmt.Sprintf("SKP kehadiran tanggal %s dibuka kembali untuk ditinjau ulang. Alasan: %s",
+		kehadiran.TglKehadiran.Time.Format("2006-01-02"), alasan)
+	if err := kirimNotifikasi(c, qtx,
+		[]uuid.UUID{kehadiran.UserID, kehadiran.PembimbingID, kehadiran.PembimbingKlinik},
+		notifikasiSkpDibuka, "SKP dibuka kembali", pesan, &kehadiran.ID, arg.UpdatedBy,
+	); err != nil {
+		return nil, err
+	}
+
+	return PembukaanKehadiranSkp{
+		ID:          pembukaan.ID,
+		KehadiranID: pembukaan.KehadiranID,
+		Alasan:      pembukaan.Alasan,
+		Sebelum:     json.RawMessage(pembukaan.Sebelum),
+		DibukaOleh:  pembukaan.DibukaOleh,
+		CreatedBy:   pembukaan.CreatedBy,
+		CreatedAt:   pembukaan.CreatedAt,
+	}, nil
+}
+
+// ListPembukaanSkp mengembalikan riwayat pembukaan SKP sebuah kehadiran.
+func (mu *SkpKehadiranUsecaseImpl) ListPembukaanSkp(c context.Context, kehadiranID uuid.UUID) (any, error) {
+	rows, err := mu.db.ListPembukaanKehadiranSkp(c, kehadiranID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get pembukaan skp")
+	}
+
+	res := make([]PembukaanKehadiranSkp, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, PembukaanKehadiranSkp{
+			ID:          r.ID,
+			KehadiranID: r.KehadiranID,
+			Alasan:      r.Alasan,
+			Sebelum:     json.RawMessage(r.Sebelum),
+			DibukaOleh:  r.DibukaOleh,
+			CreatedBy:   r.CreatedBy,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+	return res, nil
+}
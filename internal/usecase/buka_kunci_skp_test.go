@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB menjawab query berdasarkan nama sqlc di baris pertama SQL dan mencatat setiap panggilan.
+type fakeDB struct {
+	kehadiran pg.Kehadiran
+	skp       []pg.KehadiranSkp
+	panggilan map[string][]panggilanDB
+}
+
+type panggilanDB struct {
+	sql  string
+	args []any
+}
+
+func namaQuery(sql string) string {
+	baris, _, _ := strings.Cut(sql, "\n")
+	f := strings.Fields(baris)
+	if len(f) < 3 {
+		return ""
+	}
+	return f[2]
+}
+
+func (f *fakeDB) catat(sql string, args []any) {
+	f.panggilan[namaQuery(sql)] = append(f.panggilan[namaQuery(sql)], panggilanDB{sql: sql, args: args})
+}
+
+func (f *fakeDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.catat(sql, args)
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (f *fakeDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	f.catat(sql, args)
+	if namaQuery(sql) != "ListKehadiranSkpByKehadiran" {
+		return nil, errors.New("query tidak dikenal: " + namaQuery(sql))
+	}
+	baris := make([]any, len(f.skp))
+	for i := range f.skp {
+		baris[i] = f.skp[i]
+	}
+	return &fakeRows{baris: baris, idx: -1}, nil
+}
+
+func (f *fakeDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	f.catat(sql, args)
+	switch namaQuery(sql) {
+	case "GetKehadiran", "BukaStatusKehadiran":
+		return fakeRow{nilai: f.kehadiran}
+	case "CreatePembukaanKehadiranSkp":
+		return fakeRow{nilai: pg.PembukaanKehadiranSkp{
+			ID:          uuid.Must(uuid.NewV4()),
+			KehadiranID: args[0].(uuid.UUID),
+			Alasan:      args[1].(string),
+			Sebelum:     args[2].([]byte),
+			DibukaOleh:  args[3].(uuid.UUID),
+		}}
+	}
+	return fakeRow{err: errors.New("query tidak dikenal: " + namaQuery(sql))}
+}
+
+// fakeRow mengisi tujuan Scan dari field struct sesuai urutan, sama dengan urutan kolom sqlc.
+type fakeRow struct {
+	nilai any
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	v := reflect.ValueOf(r.nilai)
+	for i := range dest {
+		reflect.ValueOf(dest[i]).Elem().Set(v.Field(i))
+	}
+	return nil
+}
+
+type fakeRows struct {
+	baris []any
+	idx   int
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.NewCommandTag("SELECT") }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) Next() bool                                   { r.idx++; return r.idx < len(r.baris) }
+func (r *fakeRows) Scan(dest ...any) error                       { return fakeRow{nilai: r.baris[r.idx]}.Scan(dest...) }
+func (r *fakeRows) Values() ([]any, error)                       { return nil, nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func TestBukaKunciSkp(t *testing.T) {
+	disetujui := "disetujui"
+	terkunci := true
+	terbuka := false
+	kehadiranID := uuid.Must(uuid.NewV4())
+	kehadiran := pg.Kehadiran{
+		ID:               kehadiranID,
+		UserID:           uuid.Must(uuid.NewV4()),
+		PembimbingID:     uuid.Must(uuid.NewV4()),
+		PembimbingKlinik: uuid.Must(uuid.NewV4()),
+	}
+	dikunci := kehadiran
+	dikunci.Status = &disetujui
+	skp := func(locked *bool) []pg.KehadiranSkp {
+		return []pg.KehadiranSkp{{ID: uuid.Must(uuid.NewV4()), KehadiranID: kehadiranID, Locked: locked}}
+	}
+
+	tests := []struct {
+		name      string
+		kehadiran pg.Kehadiran
+		skp       []pg.KehadiranSkp
+		wantErr   bool
+	}{
+		{"belum dikunci", kehadiran, skp(&terbuka), true},
+		{"belum dikunci tanpa tindakan", kehadiran, nil, true},
+		{"status kehadiran sudah diputuskan", dikunci, skp(&terbuka), false},
+		{"tindakan terkunci", kehadiran, skp(&terkunci), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{kehadiran: tt.kehadiran, skp: tt.skp, panggilan: map[string][]panggilanDB{}}
+			arg := request.BukaKunciSkp{KehadiranID: kehadiranID, DibukaOleh: uuid.Must(uuid.NewV4())}
+			_, err := bukaKunciSkp(context.Background(), pg.New(db), arg, "salah input level")
+
+			if tt.wantErr {
+				var e *pkg.AppError
+				if !errors.As(err, &e) || e.Code != pkg.ErrorCodeConflict {
+					t.Fatalf("bukaKunciSkp() error = %v, want conflict", err)
+				}
+				for _, tulis := range []string{"CreatePembukaanKehadiranSkp", "BukaKunciKehadiranSkp", "BukaStatusKehadiran", "CreateNotifikasi"} {
+					if len(db.panggilan[tulis]) > 0 {
+						t.Errorf("%s dipanggil padahal SKP belum dikunci", tulis)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bukaKunciSkp() error = %v", err)
+			}
+
+			snapshot := db.panggilan["CreatePembukaanKehadiranSkp"]
+			if len(snapshot) != 1 {
+				t.Fatalf("CreatePembukaanKehadiranSkp dipanggil %d kali, want 1", len(snapshot))
+			}
+			var sebelum snapshotPembukaan
+			if err := json.Unmarshal(snapshot[0].args[2].([]byte), &sebelum); err != nil {
+				t.Fatalf("snapshot bukan JSON valid: %v", err)
+			}
+			if sebelum.Kehadiran.ID != kehadiranID || len(sebelum.Skp) != len(tt.skp) {
+				t.Errorf("snapshot = %+v, want kehadiran %s dengan %d tindakan", sebelum, kehadiranID, len(tt.skp))
+			}
+
+			reset := db.panggilan["BukaKunciKehadiranSkp"]
+			if len(reset) != 1 {
+				t.Fatalf("BukaKunciKehadiranSkp dipanggil %d kali, want 1", len(reset))
+			}
+			for _, kolom := range []string{`locked\s*=\s*false`, `status\s*=\s*NULL`, `level_id\s*=\s*NULL`, `reviewer_id\s*=\s*NULL`} {
+				if !regexp.MustCompile(kolom).MatchString(reset[0].sql) {
+					t.Errorf("BukaKunciKehadiranSkp tidak mereset %s", kolom)
+				}
+			}
+			if len(db.panggilan["BukaStatusKehadiran"]) != 1 {
+				t.Errorf("BukaStatusKehadiran dipanggil %d kali, want 1", len(db.panggilan["BukaStatusKehadiran"]))
+			}
+
+			notif := db.panggilan["CreateNotifikasi"]
+			if len(notif) != 1 {
+				t.Fatalf("CreateNotifikasi dipanggil %d kali, want 1", len(notif))
+			}
+			want := []uuid.UUID{kehadiran.UserID, kehadiran.PembimbingID, kehadiran.PembimbingKlinik}
+			if got := notif[0].args[5]; !reflect.DeepEqual(got, want) {
+				t.Errorf("penerima notifikasi = %v, want %v", got, want)
+			}
+		})
+	}
+}
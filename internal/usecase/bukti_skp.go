@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"mime/multipart"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maksLampiranSkp membatasi jumlah foto/dokumen pada satu tindakan SKP.
+const maksLampiranSkp = 5
+
+// maksNarasiSkp membatasi panjang narasi tindakan.
+const maksNarasiSkp = 2000
+
+// kelompokUsiaValid adalah kelompok usia pasien yang boleh dicatat.
+var kelompokUsiaValid = map[string]bool{
+	"neonatus": true,
+	"bayi":     true,
+	"anak":     true,
+	"remaja":   true,
+	"dewasa":   true,
+	"lansia":   true,
+}
+
+// polaInisialPasien hanya menerima 1-3 huruf agar nama lengkap pasien tidak tersimpan.
+var polaInisialPasien = regexp.MustCompile(`^[A-Z]{1,3}$`)
+
+// LampiranSkp adalah lampiran bukti tindakan beserta URL unduh sementara.
+type LampiranSkp struct {
+	ID        uuid.UUID          `json:"id"`
+	Nama      string             `json:"nama"`
+	Tipe      string             `json:"tipe"`
+	Url       string             `json:"url"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// IntervensiKehadiranSkp adalah tindakan SKP pada satu kehadiran lengkap dengan buktinya
+// dan riwayat keputusan pembimbing, ditampilkan sebelum persetujuan.
+type IntervensiKehadiranSkp struct {
+	pg.IntervensiKehadiranIDRow
+	Lampiran []LampiranSkp              `json:"lampiran"`
+	Riwayat  []pg.KehadiranSkpKeputusan `json:"riwayat"`
+}
+
+// SimpanBuktiSkp menyimpan narasi, identitas pasien anonim dan waktu tindakan pada
+// kehadiran_skp milik mahasiswa yang belum dikunci pembimbing.
+func (mu *SkpKehadiranUsecaseImpl) SimpanBuktiSkp(c context.Context, arg request.BuktiKehadiranSkp) (any, error) {
+	ks, err := mu.db.GetKehadiranSkp(c, arg.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran skp tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran skp")
+	}
+	if ks.UserID != arg.UserID {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pemilik tindakan ini")
+	}
+	if utils.DerefBool(ks.Locked) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah direview pembimbing")
+	}
+
+	params := pg.UpdateBuktiKehadiranSkpParams{
+		UpdatedBy: arg.UpdatedBy,
+		ID:        ks.ID,
+		UserID:    arg.UserID,
+	}
+
+	narasi := strings.TrimSpace(utils.DerefString(arg.Narasi))
+	if narasi == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "narasi tindakan wajib diisi")
+	}
+	if utf8.RuneCountInString(narasi) > maksNarasiSkp {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "narasi maksimal 2000 karakter")
+	}
+	params.Narasi = &narasi
+
+	if arg.InisialPasien != nil && strings.TrimSpace(*arg.InisialPasien) != "" {
+		inisial := strings.ToUpper(strings.NewReplacer(".", "", " ", "").Replace(*arg.InisialPasien))
+		if !polaInisialPasien.MatchString(inisial) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "inisial pasien hanya boleh 1-3 huruf, jangan isi nama lengkap")
+		}
+		params.InisialPasien = &inisial
+	}
+	if arg.KelompokUsia != nil && *arg.KelompokUsia != "" {
+		kelompok := strings.ToLower(strings.TrimSpace(*arg.KelompokUsia))
+		if !kelompokUsiaValid[kelompok] {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kelompok_usia harus neonatus, bayi, anak, remaja, dewasa atau lansia")
+		}
+		params.KelompokUsia = &kelompok
+	}
+	if arg.KamarBed != nil && strings.TrimSpace(*arg.KamarBed) != "" {
+		kamar := strings.TrimSpace(*arg.KamarBed)
+		if utf8.RuneCountInString(kamar) > 50 {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kamar_bed maksimal 50 karakter")
+		}
+		params.KamarBed = &kamar
+	}
+
+	if arg.WaktuTindakan == nil || *arg.WaktuTindakan == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "waktu_tindakan wajib diisi")
+	}
+	waktu, err := time.Parse(time.RFC3339, *arg.WaktuTindakan)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format waktu_tindakan harus RFC3339")
+	}
+	if waktu.After(time.Now()) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "waktu_tindakan tidak boleh di masa depan")
+	}
+
+	// Tindakan harus terjadi pada hari dinas tersebut; shift malam boleh berlanjut ke esok hari
+	kehadiran, err := mu.db.GetKehadiran(c, ks.KehadiranID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+	hari := truncHari(waktu)
+	if hari.Before(kehadiran.TglKehadiran.Time) || hari.After(kehadiran.TglKehadiran.Time.AddDate(0, 0, 1)) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "waktu_tindakan harus pada tanggal kehadiran")
+	}
+	params.WaktuTindakan = pgtype.Timestamptz{Valid: true, Time: waktu}
+
+	res, err := mu.db.UpdateBuktiKehadiranSkp(c, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah direview pembimbing")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed simpan bukti skp")
+	}
+	return res, nil
+}
+
+// UnggahLampiranSkp menambahkan foto/dokumen bukti ke object storage.
+func (mu *SkpKehadiranUsecaseImpl) UnggahLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID, createdBy *string, berkas *multipart.FileHeader) (any, error) {
+	ks, err := mu.db.GetKehadiranSkp(c, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran skp tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran skp")
+	}
+	if ks.UserID != userID {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pemilik tindakan ini")
+	}
+	if utils.DerefBool(ks.Locked) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah direview pembimbing")
+	}
+
+	jumlah, err := mu.db.CountKehadiranSkpLampiran(c, ks.ID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count lampiran skp")
+	}
+	if jumlah >= maksLampiranSkp {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lampiran maksimal 5 berkas per tindakan")
+	}
+
+	// 📎 Unggah lampiran ke object storage
+	l, err := simpanLampiran(c, mu.s3, mu.cfg.Minio.Bucket1, "skp/"+userID.String(), berkas)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := mu.db.CreateKehadiranSkpLampiran(c, pg.CreateKehadiranSkpLampiranParams{
+		KehadiranSkpID: ks.ID,
+		LampiranKey:    l.Key,
+		LampiranNama:   l.Nama,
+		LampiranTipe:   l.Tipe,
+		CreatedBy:      createdBy,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create lampiran skp")
+	}
+	return res, nil
+}
+
+func (mu *SkpKehadiranUsecaseImpl) HapusLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID, deletedBy *string) error {
+	l, err := mu.db.GetKehadiranSkpLampiran(c, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pkg.ExposeError(pkg.ErrorCodeNotFound, "lampiran skp tidak ditemukan")
+		}
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get lampiran skp")
+	}
+	if l.UserID != userID {
+		return pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pemilik lampiran ini")
+	}
+	if utils.DerefBool(l.Locked) {
+		return pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah direview pembimbing")
+	}
+
+	if err := mu.db.DeleteKehadiranSkpLampiran(c, pg.DeleteKehadiranSkpLampiranParams{
+		ID:        id,
+		DeletedBy: deletedBy,
+	}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed delete lampiran skp")
+	}
+	return nil
+}
+
+// GetLampiranSkp mengembalikan URL unduh sementara untuk mahasiswa pemilik atau pembimbingnya.
+func (mu *SkpKehadiranUsecaseImpl) GetLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error) {
+	l, err := mu.db.GetKehadiranSkpLampiran(c, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "lampiran skp tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get lampiran skp")
+	}
+	if userID != l.UserID && userID != l.PembimbingID && userID != l.PembimbingKlinik {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda tidak berhak melihat lampiran ini")
+	}
+
+	url, err := urlLampiran(c, mu.s3, mu.cfg.Minio.Bucket1, l.LampiranKey, l.LampiranNama)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"url": url}, nil
+}
+
+// lampiranPerSkp mengelompokkan lampiran per kehadiran_skp lengkap dengan URL unduhnya.
+func (mu *SkpKehadiranUsecaseImpl) lampiranPerSkp(c context.Context, ids []uuid.UUID) (map[uuid.UUID][]LampiranSkp, error) {
+	rows, err := mu.db.ListKehadiranSkpLampiran(c, ids)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get lampiran skp")
+	}
+
+	res := make(map[uuid.UUID][]LampiranSkp, len(ids))
+	for _, r := range rows {
+		url, err := urlLampiran(c, mu.s3, mu.cfg.Minio.Bucket1, r.LampiranKey, r.LampiranNama)
+		if err != nil {
+			return nil, err
+		}
+		res[r.KehadiranSkpID] = append(res[r.KehadiranSkpID], LampiranSkp{
+			ID:        r.ID,
+			Nama:      r.LampiranNama,
+			Tipe:      r.LampiranTipe,
+			Url:       url,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return res, nil
+}
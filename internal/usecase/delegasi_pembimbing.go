@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maksHariDelegasi membatasi lama satu delegasi agar tidak menjadi pengalihan permanen.
+const maksHariDelegasi = 90
+
+// BuatDelegasi mendelegasikan persetujuan SKP dan kehadiran seorang pembimbing klinik ke
+// pembimbing lain yang terdaftar pada kontrak yang sama. Selama rentang tanggal delegasi,
+// penerima melihat antrean persetujuan pembimbing asal dan dapat memutuskannya.
+func (mu *SkpKehadiranUsecaseImpl) BuatDelegasi(c context.Context, arg request.CreateDelegasiPembimbing) (any, error) {
+	if arg.KontrakID == uuid.Nil || arg.DelegasiID == uuid.Nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kontrak_id dan delegasi_id wajib diisi")
+	}
+	if arg.DelegasiID == arg.PembimbingID {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "delegasi tidak boleh kepada diri sendiri")
+	}
+	tglMulai, err := time.Parse("2006-01-02", arg.TglMulai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_mulai harus YYYY-MM-DD")
+	}
+	tglSelesai, err := time.Parse("2006-01-02", arg.TglSelesai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_selesai harus YYYY-MM-DD")
+	}
+	if tglSelesai.Before(tglMulai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_selesai tidak boleh sebelum tgl_mulai")
+	}
+	if tglSelesai.Sub(tglMulai) > maksHariDelegasi*24*time.Hour {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, fmt.Sprintf("delegasi maksimal %d hari", maksHariDelegasi))
+	}
+	// Bandingkan dengan tanggal hari ini di Jakarta, bukan batas hari UTC
+	tgl, err := utils.GetJakartaDateObject()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed get jakarta time")
+	}
+	hariIni := time.Date(tgl.Year(), tgl.Month(), tgl.Day(), 0, 0, 0, 0, time.UTC)
+	if tglSelesai.Before(hariIni) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_selesai sudah lewat")
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		for _, id := range []uuid.UUID{arg.PembimbingID, arg.DelegasiID} {
+			terdaftar, err := qtx.CekPembimbingKontrak(c, pg.CekPembimbingKontrakParams{KontrakID: arg.KontrakID, UserID: id})
+			if err != nil {
+				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check pembimbing klinik")
+			}
+			if !terdaftar {
+				return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "pembimbing dan penerima delegasi harus terdaftar sebagai pembimbing klinik pada kontrak ini")
+			}
+		}
+
+		mulai := pgtype.Date{Time: tglMulai, Valid: true}
+		selesai := pgtype.Date{Time: tglSelesai, Valid: true}
+		bentrok, err := qtx.CekDelegasiBentrok(c, pg.CekDelegasiBentrokParams{
+			KontrakID:    arg.KontrakID,
+			PembimbingID: arg.PembimbingID,
+			TglSelesai:   selesai,
+			TglMulai:     mulai,
+		})
+		if err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check delegasi")
+		}
+		if bentrok {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "sudah ada delegasi pada rentang tanggal tersebut")
+		}
+
+		res, err := qtx.CreateDelegasiPembimbing(c, pg.CreateDelegasiPembimbingParams{
+			KontrakID:    arg.KontrakID,
+			PembimbingID: arg.PembimbingID,
+			DelegasiID:   arg.DelegasiID,
+			TglMulai:     mulai,
+			TglSelesai:   selesai,
+			Alasan:       arg.Alasan,
+			CreatedBy:    arg.CreatedBy,
+		})
+		if err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create delegasi")
+		}
+
+		pesan := fmt.Sprintf("Anda menerima delegasi persetujuan pembimbing klinik untuk %s s.d. %s.",
+			tglMulai.Format("2006-01-02"), tglSelesai.Format("2006-01-02"))
+		if err := kirimNotifikasi(c, qtx, []uuid.UUID{res.DelegasiID},
+			notifikasiDelegasiDibuat, "Delegasi persetujuan", pesan, &res.ID, arg.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// ListDelegasi menampilkan delegasi yang diberikan maupun diterima pengguna.
+func (mu *SkpKehadiranUsecaseImpl) ListDelegasi(c context.Context, arg request.SearchDelegasiPembimbing) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	params := pg.ListDelegasiPembimbingParams{
+		UserID: arg.UserID,
+		Aktif:  arg.Aktif,
+		Limit:  arg.Limit,
+		Offset: arg.Offset,
+	}
+	if arg.KontrakID != "" {
+		id := uuid.FromStringOrNil(arg.KontrakID)
+		params.KontrakID = &id
+	}
+
+	res, err := mu.db.ListDelegasiPembimbing(c, params)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get delegasi")
+	}
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountDelegasiPembimbing(c, pg.CountDelegasiPembimbingParams{
+		UserID:    params.UserID,
+		KontrakID: params.KontrakID,
+		Aktif:     params.Aktif,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count delegasi")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+// CabutDelegasi mengakhiri delegasi sebelum waktunya. userID diisi untuk pembimbing asal atau
+// penerima delegasi; nil untuk koordinator yang boleh mencabut delegasi siapa pun.
+func (mu *SkpKehadiranUsecaseImpl) CabutDelegasi(c context.Context, id uuid.UUID, userID *uuid.UUID, oleh *string) (any, error) {
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		d, err := qtx.GetDelegasiPembimbing(c, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "delegasi tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get delegasi")
+		}
+		if userID != nil && *userID != d.PembimbingID && *userID != d.DelegasiID {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pemberi atau penerima delegasi ini")
+		}
+
+		res, err := qtx.CabutDelegasiPembimbing(c, pg.CabutDelegasiPembimbingParams{DicabutBy: oleh, ID: id})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "delegasi sudah dicabut")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cabut delegasi")
+		}
+
+		if err := kirimNotifikasi(c, qtx, []uuid.UUID{res.PembimbingID, res.DelegasiID},
+			notifikasiDelegasiDicabut, "Delegasi dicabut", "Delegasi persetujuan pembimbing klinik telah dicabut.", &res.ID, oleh,
+		); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// penyetujuKehadiran memastikan reviewer berhak memutuskan kehadiran: pembimbing akademik,
+// pembimbing klinik, atau penerima delegasi aktif dari pembimbing klinik pada kontrak yang
+// sama. Untuk penerima delegasi, delegasi yang dipakai dikembalikan agar dicatat pada keputusan.
+func penyetujuKehadiran(c context.Context, qtx *pg.Queries, kehadiran pg.Kehadiran, reviewerID uuid.UUID) (*pg.DelegasiPembimbing, error) {
+	if kehadiran.PembimbingID == reviewerID || kehadiran.PembimbingKlinik == reviewerID {
+		return nil, nil
+	}
+	d, err := qtx.GetDelegasiAktif(c, pg.GetDelegasiAktifParams{
+		KontrakID:    kehadiran.KontrakID,
+		PembimbingID: kehadiran.PembimbingKlinik,
+		DelegasiID:   reviewerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pembimbing pada kehadiran ini")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get delegasi")
+	}
+	return &d, nil
+}
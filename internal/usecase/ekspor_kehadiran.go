@@ -0,0 +1,477 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+)
+
+// Lingkup rekap yang dapat diekspor.
+const (
+	lingkupMahasiswa = "mahasiswa"
+	lingkupRuangan   = "ruangan"
+	lingkupFasilitas = "fasilitas"
+)
+
+const (
+	// ukuranBatchEkspor adalah jumlah baris kehadiran yang dibaca per query,
+	// sehingga ekspor besar tidak pernah dimuat sekaligus ke memori.
+	ukuranBatchEkspor = 1000
+	// maksBarisEksporPdf membatasi PDF karena dokumen PDF disusun utuh di memori.
+	maksBarisEksporPdf = 10000
+	maksHariEkspor     = 366
+)
+
+const (
+	tipeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	tipePdf  = "application/pdf"
+)
+
+var namaBulan = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+var kolomRekapEkspor = []string{"No", "Username", "Nama", "Hadir", "Izin", "Sakit", "Alpa", "Total", "Jam Dinas", "Pulang Awal"}
+
+var kolomDetailEkspor = []string{"No", "Tanggal", "Username", "Nama", "Ruangan", "Shift", "Presensi", "Masuk", "Pulang", "Jam Dinas", "Pulang Awal"}
+
+// eksporKehadiran menampung hasil validasi lingkup beserta data ringkas yang
+// dibutuhkan sebelum berkas mulai ditulis ke klien.
+type eksporKehadiran struct {
+	lingkup    string
+	subjek     string
+	slug       string
+	tglAwal    time.Time
+	tglAkhir   time.Time
+	params     pg.ListKehadiranEksporParams
+	rekap      []pg.RekapKehadiranEksporRow
+	pembimbing []string
+}
+
+// EksporRekapKehadiran menulis rekap kehadiran per mahasiswa, ruangan, atau fasilitas
+// dalam format XLSX atau PDF. Validasi dan seluruh query selesai sebelum tulis dipanggil,
+// sehingga header lampiran baru dikirim ketika berkas sudah pasti dapat dibuat.
+func (mu *SummaryUsecaseImpl) EksporRekapKehadiran(c context.Context, arg request.EksporRekapKehadiran, tulis func(nama, tipe string) io.Writer) error {
+	format := strings.ToLower(strings.TrimSpace(arg.Format))
+	if format == "" {
+		format = "xlsx"
+	}
+	if format != "xlsx" && format != "pdf" {
+		return pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format harus xlsx atau pdf")
+	}
+
+	e, err := mu.siapkanEksporKehadiran(c, arg)
+	if err != nil {
+		return err
+	}
+
+	nama := fmt.Sprintf("rekap-kehadiran-%s-%s-%s", e.slug, e.tglAwal.Format("20060102"), e.tglAkhir.Format("20060102"))
+	if format == "pdf" {
+		var total int64
+		for _, r := range e.rekap {
+			total += r.TotalSemua
+		}
+		if total > maksBarisEksporPdf {
+			return pkg.ExposeError(pkg.ErrorCodeInvalidArgument, fmt.Sprintf("data terlalu besar untuk PDF (%d baris), gunakan format xlsx", total))
+		}
+		return mu.tulisEksporPdf(c, e, func() io.Writer { return tulis(nama+".pdf", tipePdf) })
+	}
+	return mu.tulisEksporXlsx(c, e, func() io.Writer { return tulis(nama+".xlsx", tipeXlsx) })
+}
+
+func (mu *SummaryUsecaseImpl) siapkanEksporKehadiran(c context.Context, arg request.EksporRekapKehadiran) (*eksporKehadiran, error) {
+	tglAwal, err := time.Parse("2006-01-02", arg.TglAwal)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_awal harus YYYY-MM-DD")
+	}
+	tglAkhir, err := time.Parse("2006-01-02", arg.TglAkhir)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_akhir harus YYYY-MM-DD")
+	}
+	if tglAkhir.Before(tglAwal) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_akhir tidak boleh sebelum tgl_awal")
+	}
+	if tglAkhir.Sub(tglAwal) >= maksHariEkspor*24*time.Hour {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "periode ekspor maksimal 366 hari")
+	}
+
+	id, err := uuid.FromString(arg.ID)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "id tidak valid")
+	}
+
+	e := &eksporKehadiran{
+		lingkup:  strings.ToLower(strings.TrimSpace(arg.Lingkup)),
+		tglAwal:  tglAwal,
+		tglAkhir: tglAkhir,
+		params: pg.ListKehadiranEksporParams{
+			TglAwal:  pgtype.Date{Valid: true, Time: tglAwal},
+			TglAkhir: pgtype.Date{Valid: true, Time: tglAkhir},
+			Limit:    ukuranBatchEkspor,
+		},
+	}
+
+	switch e.lingkup {
+	case lingkupMahasiswa:
+		u, err := mu.db.GetUserByID(c, id)
+		if err != nil {
+			return nil, errEksporTidakDitemukan(err, "mahasiswa")
+		}
+		e.subjek = fmt.Sprintf("Mahasiswa: %s (%s)", u.Nama, u.Username)
+		e.slug = u.Username
+		e.params.UserID = &id
+	case lingkupRuangan:
+		r, err := mu.db.GetRuanganById(c, id)
+		if err != nil {
+			return nil, errEksporTidakDitemukan(err, "ruangan")
+		}
+		e.subjek = "Ruangan: " + r.NamaRuangan
+		if r.RumahSakit != nil {
+			e.subjek += " - " + *r.RumahSakit
+		}
+		e.slug = "ruangan"
+		e.params.RuanganID = &id
+	case lingkupFasilitas:
+		f, err := mu.db.GetFasilitasKesehatan(c, id)
+		if err != nil {
+			return nil, errEksporTidakDitemukan(err, "fasilitas")
+		}
+		e.subjek = "Fasilitas: " + f.Nama
+		e.slug = "fasilitas"
+		e.params.FasilitasID = &id
+	default:
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lingkup harus mahasiswa, ruangan, atau fasilitas")
+	}
+
+	e.rekap, err = mu.db.RekapKehadiranEkspor(c, pg.RekapKehadiranEksporParams{
+		TglAwal:     e.params.TglAwal,
+		TglAkhir:    e.params.TglAkhir,
+		UserID:      e.params.UserID,
+		RuanganID:   e.params.RuanganID,
+		FasilitasID: e.params.FasilitasID,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get rekap kehadiran ekspor")
+	}
+
+	e.pembimbing, err = mu.db.GetPembimbingEkspor(c, pg.GetPembimbingEksporParams{
+		TglAwal:     e.params.TglAwal,
+		TglAkhir:    e.params.TglAkhir,
+		UserID:      e.params.UserID,
+		RuanganID:   e.params.RuanganID,
+		FasilitasID: e.params.FasilitasID,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get pembimbing ekspor")
+	}
+
+	return e, nil
+}
+
+func errEksporTidakDitemukan(err error, nama string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pkg.ExposeError(pkg.ErrorCodeNotFound, nama+" tidak ditemukan")
+	}
+	return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get "+nama)
+}
+
+// tiapKehadiranEkspor membaca detail kehadiran per batch dengan keyset (tgl_kehadiran, id).
+func (mu *SummaryUsecaseImpl) tiapKehadiranEkspor(c context.Context, e *eksporKehadiran, fn func(pg.ListKehadiranEksporRow) error) error {
+	params := e.params
+	for {
+		rows, err := mu.db.ListKehadiranEkspor(c, params)
+		if err != nil {
+			return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran ekspor")
+		}
+		for _, r := range rows {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		if len(rows) < int(params.Limit) {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		params.SetelahTgl = last.TglKehadiran
+		params.SetelahID = &last.ID
+	}
+}
+
+// tulisEksporXlsx menyusun workbook dengan stream writer, yang menampung baris di berkas
+// sementara alih-alih di memori. buka baru dipanggil setelah seluruh detail terbaca.
+func (mu *SummaryUsecaseImpl) tulisEksporXlsx(c context.Context, e *eksporKehadiran, buka func() io.Writer) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", "Rekap"); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create xlsx")
+	}
+	if _, err := f.NewSheet("Detail"); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create xlsx")
+	}
+	tebal, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create xlsx")
+	}
+	judul := func(v string) excelize.Cell { return excelize.Cell{StyleID: tebal, Value: v} }
+	header := func(kolom []string) []any {
+		out := make([]any, len(kolom))
+		for i, k := range kolom {
+			out[i] = judul(k)
+		}
+		return out
+	}
+
+	// 📄 Sheet Rekap: kop, ringkasan per mahasiswa, dan blok tanda tangan
+	sw, err := f.NewStreamWriter("Rekap")
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create xlsx")
+	}
+	baris := 0
+	tambah := func(sw *excelize.StreamWriter, vals ...any) error {
+		baris++
+		cell, _ := excelize.CoordinatesToCellName(1, baris)
+		return sw.SetRow(cell, vals)
+	}
+
+	isi := [][]any{
+		{judul(mu.cfg.Ekspor.Institusi)},
+		{judul("REKAP KEHADIRAN PRAKTIK KLINIK")},
+		{e.subjek},
+		{"Periode: " + periodeEkspor(e.tglAwal, e.tglAkhir)},
+		nil,
+		header(kolomRekapEkspor),
+	}
+	for i, r := range e.rekap {
+		isi = append(isi, []any{i + 1, r.Username, r.NamaMahasiswa, r.TotalHadir, r.TotalIzin, r.TotalSakit, r.TotalAlpa, r.TotalSemua, jamDinas(r.TotalMenitDinas), r.TotalPulangAwal})
+	}
+	isi = append(isi, nil, nil)
+	for _, ttd := range mu.blokTandaTangan(e) {
+		isi = append(isi, []any{nil, ttd[0], nil, nil, nil, nil, ttd[1]})
+	}
+	for _, vals := range isi {
+		if err := tambah(sw, vals...); err != nil {
+			return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write xlsx")
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write xlsx")
+	}
+
+	// 📄 Sheet Detail: satu baris per kehadiran, dialirkan per batch
+	sw, err = f.NewStreamWriter("Detail")
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create xlsx")
+	}
+	baris = 0
+	if err := tambah(sw, header(kolomDetailEkspor)...); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write xlsx")
+	}
+	no := 0
+	err = mu.tiapKehadiranEkspor(c, e, func(r pg.ListKehadiranEksporRow) error {
+		no++
+		return tambah(sw, barisDetailEkspor(no, r)...)
+	})
+	if err != nil {
+		return err
+	}
+	if err := sw.Flush(); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write xlsx")
+	}
+
+	if err := f.Write(buka()); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write xlsx")
+	}
+	return nil
+}
+
+// tulisEksporPdf menyusun dokumen PDF utuh di memori; buka baru dipanggil setelah
+// dokumen selesai, sehingga error query detail masih dapat dikirim sebagai JSON.
+func (mu *SummaryUsecaseImpl) tulisEksporPdf(c context.Context, e *eksporKehadiran, buka func() io.Writer) error {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(12, 12, 12)
+	pdf.SetAutoPageBreak(false, 12)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	_, tinggiHalaman := pdf.GetPageSize()
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	tabel := func(kolom []string, lebar []float64, baris [][]string) {
+		kepala := func() {
+			pdf.SetFont("Arial", "B", 8)
+			pdf.SetFillColor(230, 230, 230)
+			for i, k := range kolom {
+				pdf.CellFormat(lebar[i], 7, tr(k), "1", 0, "C", true, 0, "")
+			}
+			pdf.Ln(-1)
+			pdf.SetFont("Arial", "", 8)
+		}
+		kepala()
+		for _, b := range baris {
+			if pdf.GetY()+6 > tinggiHalaman-15 {
+				pdf.AddPage()
+				kepala()
+			}
+			for i, v := range b {
+				align := "L"
+				if i == 0 || i >= 5 {
+					align = "C"
+				}
+				pdf.CellFormat(lebar[i], 6, tr(v), "1", 0, align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 7, tr(mu.cfg.Ekspor.Institusi), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 7, "REKAP KEHADIRAN PRAKTIK KLINIK", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, tr(e.subjek), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Periode: "+periodeEkspor(e.tglAwal, e.tglAkhir)), "", 1, "C", false, 0, "")
+	pdf.Line(12, pdf.GetY()+1, 285, pdf.GetY()+1)
+	pdf.Ln(4)
+
+	rekap := make([][]string, 0, len(e.rekap))
+	for i, r := range e.rekap {
+		rekap = append(rekap, []string{
+			fmt.Sprint(i + 1), r.Username, r.NamaMahasiswa,
+			fmt.Sprint(r.TotalHadir), fmt.Sprint(r.TotalIzin), fmt.Sprint(r.TotalSakit), fmt.Sprint(r.TotalAlpa),
+			fmt.Sprint(r.TotalSemua), fmt.Sprintf("%.2f", jamDinas(r.TotalMenitDinas)), fmt.Sprint(r.TotalPulangAwal),
+		})
+	}
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 7, "Ringkasan", "", 1, "L", false, 0, "")
+	tabel(kolomRekapEkspor, []float64{10, 35, 80, 18, 18, 18, 18, 18, 25, 25}, rekap)
+
+	var detail [][]string
+	no := 0
+	err := mu.tiapKehadiranEkspor(c, e, func(r pg.ListKehadiranEksporRow) error {
+		no++
+		vals := barisDetailEkspor(no, r)
+		s := make([]string, len(vals))
+		for i, v := range vals {
+			s[i] = fmt.Sprint(v)
+		}
+		detail = append(detail, s)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 7, "Detail Kehadiran", "", 1, "L", false, 0, "")
+	tabel(kolomDetailEkspor, []float64{10, 22, 30, 55, 45, 16, 18, 16, 16, 20, 20}, detail)
+
+	// ✍️ Blok tanda tangan pembimbing dan koordinator
+	if pdf.GetY()+45 > tinggiHalaman-15 {
+		pdf.AddPage()
+	}
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 10)
+	for _, ttd := range mu.blokTandaTangan(e) {
+		pdf.SetX(20)
+		pdf.CellFormat(110, 6, tr(ttd[0]), "", 0, "C", false, 0, "")
+		pdf.SetX(170)
+		pdf.CellFormat(110, 6, tr(ttd[1]), "", 1, "C", false, 0, "")
+	}
+
+	if err := pdf.Error(); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create pdf")
+	}
+	if err := pdf.Output(buka()); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write pdf")
+	}
+	return nil
+}
+
+// blokTandaTangan menyusun baris-baris blok tanda tangan dua kolom:
+// pembimbing di kiri dan koordinator di kanan.
+func (mu *SummaryUsecaseImpl) blokTandaTangan(e *eksporKehadiran) [][2]string {
+	pembimbing := "(.................................)"
+	if len(e.pembimbing) == 1 {
+		pembimbing = "( " + e.pembimbing[0] + " )"
+	}
+	koordinator := "(.................................)"
+	if mu.cfg.Ekspor.NamaKoordinator != "" {
+		koordinator = "( " + mu.cfg.Ekspor.NamaKoordinator + " )"
+	}
+	nip := ""
+	if mu.cfg.Ekspor.NipKoordinator != "" {
+		nip = "NIP. " + mu.cfg.Ekspor.NipKoordinator
+	}
+	tempat := tanggalIndonesia(time.Now())
+	if mu.cfg.Ekspor.Kota != "" {
+		tempat = mu.cfg.Ekspor.Kota + ", " + tempat
+	}
+
+	return [][2]string{
+		{"", tempat},
+		{"Pembimbing", mu.cfg.Ekspor.JabatanKoordinator},
+		{"", ""},
+		{"", ""},
+		{"", ""},
+		{pembimbing, koordinator},
+		{"", nip},
+	}
+}
+
+func barisDetailEkspor(no int, r pg.ListKehadiranEksporRow) []any {
+	jam := func(t pgtype.Timestamptz) string {
+		if !t.Valid {
+			return "-"
+		}
+		if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+			return t.Time.In(loc).Format("15:04")
+		}
+		return t.Time.Format("15:04")
+	}
+	shift := "-"
+	if r.JadwalDinas != nil {
+		shift = *r.JadwalDinas
+	}
+	durasi := 0.0
+	if r.DurasiMenit != nil {
+		durasi = jamDinas(int64(*r.DurasiMenit))
+	}
+	pulangAwal := "-"
+	if r.PulangAwal != nil && *r.PulangAwal {
+		pulangAwal = "Ya"
+	}
+	masuk := "-"
+	if r.Presensi == "hadir" {
+		masuk = jam(r.JamMasuk)
+	}
+
+	return []any{no, r.TglKehadiran.Time.Format("2006-01-02"), r.Username, r.NamaMahasiswa, r.NamaRuangan, shift, r.Presensi, masuk, jam(r.JamPulang), durasi, pulangAwal}
+}
+
+func jamDinas(menit int64) float64 {
+	return float64(menit*100/60) / 100
+}
+
+func tanggalIndonesia(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), namaBulan[t.Month()-1], t.Year())
+}
+
+func periodeEkspor(awal, akhir time.Time) string {
+	return tanggalIndonesia(awal) + " s.d. " + tanggalIndonesia(akhir)
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+)
+
+// Format tanggal yang diterima pada kolom "date" berkas impor. Sel tanggal XLSX dibaca
+// sebagai nomor seri Excel dan dikonversi oleh parseTanggalImport.
+var formatTanggalImport = []string{"2006-01-02", "02/01/2006", "2/1/2006", "02-01-2006"}
+
+// HasilImportBaris adalah laporan validasi per baris berkas impor.
+// Nomor baris mengikuti nomor baris di berkas (header = baris 1).
+type HasilImportBaris struct {
+	Baris    int      `json:"baris"`
+	Username string   `json:"username"`
+	Tanggal  string   `json:"tanggal"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+type HasilImportKehadiran struct {
+	DryRun   bool               `json:"dry_run"`
+	Total    int                `json:"total"`
+	Valid    int                `json:"valid"`
+	Gagal    int                `json:"gagal"`
+	Disimpan int                `json:"disimpan"`
+	Baris    []HasilImportBaris `json:"baris"`
+}
+
+type barisImportKehadiran struct {
+	laporan *HasilImportBaris
+	params  pg.CreateKehadiranParams
+}
+
+// ImportKehadiran memvalidasi setiap baris berkas CSV/XLSX (username, date, presensi,
+// ruangan, shift) terhadap users, kontrak, dan ruangan. Mata kuliah dan pembimbing diambil
+// dari penempatan aktif mahasiswa pada tanggal baris tersebut. Pada dry-run hanya laporan
+// yang dikembalikan; selain itu baris valid disimpan dalam satu transaksi.
+func (mu *KehadiranUsecaseImpl) ImportKehadiran(c context.Context, arg request.ImportKehadiran, berkas *multipart.FileHeader) (any, error) {
+	kontrakID, err := uuid.FromString(arg.KontrakID)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kontrak_id tidak valid")
+	}
+
+	rows, err := bacaTabelImport(berkas)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := indeksKolom(rows[0], "username", "date", "presensi", "ruangan", "shift")
+	if err != nil {
+		return nil, err
+	}
+
+	kontrak, err := mu.db.GetKontrakByID(c, kontrakID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kontrak tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kontrak")
+	}
+
+	ruangan, err := mu.db.GetRuanganBYKontrak(c, pg.GetRuanganBYKontrakParams{KontrakID: kontrak.ID, FasilitasID: kontrak.FasilitasID})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get ruangan kontrak")
+	}
+	ruanganByNama := make(map[string]uuid.UUID, len(ruangan))
+	for _, r := range ruangan {
+		ruanganByNama[strings.ToLower(strings.TrimSpace(r.NamaRuangan))] = r.ID
+		ruanganByNama[r.ID.String()] = r.ID
+	}
+
+	aktif := true
+	shifts, err := mu.db.ListShift(c, &aktif)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get shift")
+	}
+	shiftValid := make(map[string]bool, len(shifts))
+	for _, s := range shifts {
+		shiftValid[s.Kode] = true
+	}
+
+	var usernames []string
+	for _, row := range rows[1:] {
+		if u := nilaiKolom(row, idx, "username"); u != "" {
+			usernames = append(usernames, u)
+		}
+	}
+	users, err := mu.db.GetUsersByUsernames(c, usernames)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get users")
+	}
+	userByUsername := make(map[string]pg.GetUsersByUsernamesRow, len(users))
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, u := range users {
+		userByUsername[u.Username] = u
+		userIDs = append(userIDs, u.ID)
+	}
+
+	hariIni, err := utils.GetJakartaDateObject()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get jakarta time")
+	}
+
+	hasil := HasilImportKehadiran{DryRun: arg.DryRun, Baris: make([]HasilImportBaris, 0, len(rows)-1)}
+	var kandidat []barisImportKehadiran
+	var tglAwal, tglAkhir time.Time
+	diFile := make(map[string]int)
+
+	for i, row := range rows[1:] {
+		username := nilaiKolom(row, idx, "username")
+		tanggal := nilaiKolom(row, idx, "date")
+		if username == "" && tanggal == "" && nilaiKolom(row, idx, "presensi") == "" {
+			continue
+		}
+
+		hasil.Baris = append(hasil.Baris, HasilImportBaris{Baris: i + 2, Username: username, Tanggal: tanggal})
+		lap := &hasil.Baris[len(hasil.Baris)-1]
+		salah := func(msg string) { lap.Errors = append(lap.Errors, msg) }
+
+		params := pg.CreateKehadiranParams{
+			FasilitasID: kontrak.FasilitasID,
+			KontrakID:   kontrak.ID,
+			CreatedBy:   arg.CreatedBy,
+		}
+
+		user, ok := userByUsername[username]
+		switch {
+		case username == "":
+			salah("username wajib diisi")
+		case !ok:
+			salah("username tidak ditemukan")
+		case !user.IsActive:
+			salah("user tidak aktif")
+		default:
+			params.UserID = user.ID
+		}
+
+		tgl, tglErr := parseTanggalImport(tanggal)
+		if tglErr != nil {
+			salah("format date harus YYYY-MM-DD atau DD/MM/YYYY")
+		} else {
+			params.TglKehadiran = pgtype.Date{Valid: true, Time: tgl}
+			switch {
+			case tgl.After(hariIni):
+				salah("date tidak boleh di masa depan")
+			case kontrak.PeriodeMulai.Valid && tgl.Before(truncHari(kontrak.PeriodeMulai.Time)):
+				salah("date sebelum periode kontrak")
+			case kontrak.PeriodeSelesai.Valid && tgl.After(truncHari(kontrak.PeriodeSelesai.Time)):
+				salah("date setelah periode kontrak")
+			}
+		}
+
+		var ruanganPenempatan uuid.UUID
+		if !params.UserID.IsNil() && tglErr == nil {
+			penempatan, err := mu.db.GetPenempatanAktif(c, pg.GetPenempatanAktifParams{
+				UserID: params.UserID,
+				Tgl:    params.TglKehadiran,
+			})
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				salah("mahasiswa tidak memiliki penempatan aktif pada date tersebut")
+			case err != nil:
+				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get penempatan aktif")
+			case penempatan.KontrakID != kontrak.ID:
+				salah("penempatan mahasiswa pada date tersebut bukan untuk kontrak ini")
+			default:
+				params.MataKuliahID = penempatan.MataKuliahID
+				params.PembimbingID = penempatan.PembimbingID
+				params.PembimbingKlinik = penempatan.PembimbingKlinik
+				params.PenempatanID = &penempatan.ID
+				ruanganPenempatan = penempatan.RuanganID
+			}
+		}
+
+		presensi := strings.ToLower(nilaiKolom(row, idx, "presensi"))
+		if !presensiValid[presensi] {
+			salah("presensi harus hadir, izin, sakit, atau alpa")
+		}
+		params.Presensi = presensi
+
+		if id, ok := ruanganByNama[strings.ToLower(nilaiKolom(row, idx, "ruangan"))]; ok {
+			params.RuanganID = id
+		} else {
+			salah("ruangan tidak ditemukan pada kontrak")
+		}
+
+		shift := strings.ToLower(nilaiKolom(row, idx, "shift"))
+		if shift != "" && !shiftValid[shift] {
+			salah("shift tidak dikenal")
+		}
+
+		// Ruangan dan shift harus sesuai jadwal dinas tanggal tersebut, atau penempatan bila
+		// mahasiswa tidak terjadwal; jadwal_dinas hanya diisi dari roster.
+		if params.PenempatanID != nil && !params.RuanganID.IsNil() {
+			jadwal, err := mu.jadwalDinas(c, params.UserID, tgl)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case jadwal != nil:
+				if jadwal.RuanganID != params.RuanganID {
+					salah("ruangan tidak sesuai jadwal dinas")
+				}
+				if shift != "" && shift != strings.ToLower(jadwal.KodeShift) {
+					salah("shift tidak sesuai jadwal dinas")
+				}
+				params.JadwalDinas = &jadwal.KodeShift
+				params.JadwalID = &jadwal.ID
+			case shift != "":
+				salah("mahasiswa tidak memiliki jadwal dinas pada date tersebut, kosongkan shift")
+			case params.RuanganID != ruanganPenempatan:
+				salah("ruangan tidak sesuai penempatan")
+			}
+		}
+
+		if !params.UserID.IsNil() && tglErr == nil {
+			kunci := params.UserID.String() + "|" + tgl.Format("2006-01-02")
+			if b, dup := diFile[kunci]; dup {
+				salah("duplikat dengan baris " + strconv.Itoa(b))
+			} else {
+				diFile[kunci] = lap.Baris
+			}
+		}
+
+		if len(lap.Errors) == 0 {
+			kandidat = append(kandidat, barisImportKehadiran{laporan: lap, params: params})
+			if tglAwal.IsZero() || tgl.Before(tglAwal) {
+				tglAwal = tgl
+			}
+			if tgl.After(tglAkhir) {
+				tglAkhir = tgl
+			}
+		}
+	}
+
+	// Tolak baris yang bentrok dengan kehadiran yang sudah tercatat
+	if len(kandidat) > 0 {
+		ada, err := mu.db.ListTanggalKehadiranUsers(c, pg.ListTanggalKehadiranUsersParams{
+			UserIds:  userIDs,
+			TglAwal:  pgtype.Date{Valid: true, Time: tglAwal},
+			TglAkhir: pgtype.Date{Valid: true, Time: tglAkhir},
+		})
+		if err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check kehadiran")
+		}
+		sudah := make(map[string]bool, len(ada))
+		for _, k := range ada {
+			sudah[k.UserID.String()+"|"+k.TglKehadiran.Time.Format("2006-01-02")] = true
+		}
+
+		valid := kandidat[:0]
+		for _, k := range kandidat {
+			if sudah[k.params.UserID.String()+"|"+k.params.TglKehadiran.Time.Format("2006-01-02")] {
+				k.laporan.Errors = append(k.laporan.Errors, "kehadiran pada tanggal tersebut sudah ada")
+				continue
+			}
+			valid = append(valid, k)
+		}
+		kandidat = valid
+	}
+
+	for i := range hasil.Baris {
+		hasil.Baris[i].Valid = len(hasil.Baris[i].Errors) == 0
+	}
+	hasil.Total = len(hasil.Baris)
+	hasil.Valid = len(kandidat)
+	hasil.Gagal = hasil.Total - hasil.Valid
+
+	if arg.DryRun || len(kandidat) == 0 {
+		return hasil, nil
+	}
+
+	disimpan, err := utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (int, error) {
+		for _, k := range kandidat {
+			if _, err := qtx.CreateKehadiran(c, k.params); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return 0, pkg.ExposeError(pkg.ErrorCodeConflict, "kehadiran baris "+strconv.Itoa(k.laporan.Baris)+" sudah ada")
+				}
+				return 0, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed import kehadiran baris "+strconv.Itoa(k.laporan.Baris))
+			}
+		}
+		return len(kandidat), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	hasil.Disimpan = disimpan
+
+	return hasil, nil
+}
+
+func parseTanggalImport(s string) (time.Time, error) {
+	if seri, err := strconv.ParseFloat(s, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(seri, false)
+		if err != nil || seri < 1 || t.Year() > 9999 {
+			return time.Time{}, errors.New("nomor seri tanggal tidak valid")
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	var err error
+	for _, f := range formatTanggalImport {
+		var t time.Time
+		if t, err = time.Parse(f, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// truncHari mengambil tanggal kalender Asia/Jakarta dari sebuah timestamp.
+func truncHari(t time.Time) time.Time {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err == nil {
+		t = t.In(loc)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTanggalImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		nilai   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"iso", "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"hari/bulan/tahun", "02/01/2024", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"tanpa nol di depan", "2/1/2024", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"nomor seri excel", "45293", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"nomor seri dengan jam", "45293.75", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"nomor seri nol", "0", time.Time{}, true},
+		{"nomor seri terlalu besar", "20240102", time.Time{}, true},
+		{"teks", "besok", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTanggalImport(tt.nilai)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTanggalImport(%q) error = %v, wantErr %v", tt.nilai, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTanggalImport(%q) = %v, want %v", tt.nilai, got, tt.want)
+			}
+		})
+	}
+}
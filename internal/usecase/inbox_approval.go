@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maksBatchKeputusan membatasi jumlah kehadiran dalam satu keputusan massal.
+const maksBatchKeputusan = 100
+
+// InboxApproval adalah satu kehadiran yang menunggu keputusan pembimbing beserta tindakan
+// SKP yang belum diputuskan.
+type InboxApproval struct {
+	pg.ListInboxApprovalRow
+	Skp []pg.ListSkpMenungguByKehadiranRow `json:"skp"`
+}
+
+// InboxApproval menampilkan kehadiran yang menunggu keputusan pembimbing, diurutkan dari
+// yang paling lama menunggu. Item yang menunggu lebih lama dari SLA ditandai lewat_sla.
+func (mu *SkpKehadiranUsecaseImpl) InboxApproval(c context.Context, arg request.SearchInboxApproval) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	cparams := pg.CountInboxApprovalParams{
+		PembimbingID: arg.PembimbingID,
+		SlaJam:       int32(mu.cfg.Kehadiran.SlaApprovalJam),
+		LewatSla:     arg.LewatSla,
+	}
+	if arg.UserID != "" {
+		id := uuid.FromStringOrNil(arg.UserID)
+		cparams.UserID = &id
+	}
+	if arg.RuanganID != "" {
+		id := uuid.FromStringOrNil(arg.RuanganID)
+		cparams.RuanganID = &id
+	}
+	if arg.TglAwal != "" {
+		tgl, err := time.Parse("2006-01-02", arg.TglAwal)
+		if err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_awal harus YYYY-MM-DD")
+		}
+		cparams.TglAwal = pgtype.Date{Valid: true, Time: tgl}
+	}
+	if arg.TglAkhir != "" {
+		tgl, err := time.Parse("2006-01-02", arg.TglAkhir)
+		if err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_akhir harus YYYY-MM-DD")
+		}
+		cparams.TglAkhir = pgtype.Date{Valid: true, Time: tgl}
+	}
+
+	rows, err := mu.db.ListInboxApproval(c, pg.ListInboxApprovalParams{
+		PembimbingID: cparams.PembimbingID,
+		UserID:       cparams.UserID,
+		RuanganID:    cparams.RuanganID,
+		TglAwal:      cparams.TglAwal,
+		TglAkhir:     cparams.TglAkhir,
+		SlaJam:       cparams.SlaJam,
+		LewatSla:     cparams.LewatSla,
+		Limit:        arg.Limit,
+		Offset:       arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get inbox approval")
+	}
+	if len(rows) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.KehadiranID)
+	}
+	items, err := mu.db.ListSkpMenungguByKehadiran(c, ids)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp menunggu review")
+	}
+	skp := make(map[uuid.UUID][]pg.ListSkpMenungguByKehadiranRow, len(rows))
+	for _, it := range items {
+		skp[it.KehadiranID] = append(skp[it.KehadiranID], it)
+	}
+
+	res := make([]InboxApproval, 0, len(rows))
+	for _, r := range rows {
+		s := skp[r.KehadiranID]
+		if s == nil {
+			s = []pg.ListSkpMenungguByKehadiranRow{}
+		}
+		res = append(res, InboxApproval{ListInboxApprovalRow: r, Skp: s})
+	}
+
+	count, err := mu.db.CountInboxApproval(c, cparams)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count inbox approval")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+// BatchKeputusanSkp menerapkan satu keputusan ke seluruh tindakan yang menunggu review pada
+// beberapa kehadiran sekaligus. Semua kehadiran diproses dalam satu transaksi; bila satu
+// gagal, tidak ada keputusan yang tersimpan.
+func (mu *SkpKehadiranUsecaseImpl) BatchKeputusanSkp(c context.Context, arg request.BatchKeputusanSkp) (any, error) {
+	if len(arg.KehadiranID) == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kehadiran_id wajib diisi")
+	}
+	if len(arg.KehadiranID) > maksBatchKeputusan {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "maksimal 100 kehadiran dalam satu keputusan")
+	}
+
+	levelAktif, err := mu.levelSkpAktif(c)
+	if err != nil {
+		return nil, err
+	}
+	templat, err := validasiKeputusanSkp(request.KeputusanSkp{
+		Status:  arg.Status,
+		LevelID: arg.LevelID,
+		Alasan:  arg.Alasan,
+	}, levelAktif)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		res := make([]pg.Kehadiran, 0, len(arg.KehadiranID))
+		diproses := make(map[uuid.UUID]bool, len(arg.KehadiranID))
+		for _, kehadiranID := range arg.KehadiranID {
+			if diproses[kehadiranID] {
+				continue
+			}
+			diproses[kehadiranID] = true
+
+			menunggu, err := qtx.ListSkpMenungguReview(c, kehadiranID)
+			if err != nil {
+				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp menunggu review")
+			}
+			if len(menunggu) == 0 && templat.Status != statusSkpDisetujui {
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kehadiran "+kehadiranID.String()+" tidak memiliki tindakan untuk diputuskan")
+			}
+
+			keputusan := make(map[uuid.UUID]request.KeputusanSkp, len(menunggu))
+			for _, id := range menunggu {
+				k := templat
+				k.ID = id
+				keputusan[id] = k
+			}
+
+			k, err := terapkanKeputusanSkp(c, qtx, kehadiranID, arg.ReviewerID, arg.UpdatedBy, keputusan)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, k)
+		}
+		return res, nil
+	})
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maksHariJadwalDinas membatasi rentang tanggal sekali penjadwalan.
+const maksHariJadwalDinas = 92
+
+type JadwalDinasUsecase interface {
+	AddShift(c context.Context, arg request.CreateShift) (any, error)
+	ListShift(c context.Context, isActive *bool) (any, error)
+	UpdateShift(c context.Context, arg request.UpdateShift) (any, error)
+	DeleteShift(c context.Context, arg pg.DeleteShiftParams) error
+	AssignJadwalDinas(c context.Context, arg request.CreateJadwalDinas) (any, error)
+	ListJadwalDinas(c context.Context, arg request.SearchJadwalDinas) (any, error)
+	DeleteJadwalDinas(c context.Context, arg pg.DeleteJadwalDinasParams) error
+}
+
+type JadwalDinasUsecaseImpl struct {
+	worker *worker.ProducerService
+	db     *pg.Queries
+	pg     *pkg.Postgres
+	cache  *pkg.RedisCache
+}
+
+func NewJadwalDinasUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *JadwalDinasUsecaseImpl {
+	return &JadwalDinasUsecaseImpl{
+		db:     pg.New(postgre.Pool),
+		pg:     postgre,
+		worker: worker,
+		cache:  cache,
+	}
+}
+
+func (mu *JadwalDinasUsecaseImpl) AddShift(c context.Context, arg request.CreateShift) (any, error) {
+	kode := strings.ToLower(strings.TrimSpace(arg.Kode))
+	if kode == "" || strings.TrimSpace(arg.Nama) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kode dan nama shift wajib diisi")
+	}
+
+	jamMulai, err := utils.ParseJam(arg.JamMulai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format jam_mulai harus HH:MM")
+	}
+	jamSelesai, err := utils.ParseJam(arg.JamSelesai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format jam_selesai harus HH:MM")
+	}
+
+	res, err := mu.db.CreateShift(c, pg.CreateShiftParams{
+		Kode:       kode,
+		Nama:       arg.Nama,
+		JamMulai:   jamMulai,
+		JamSelesai: jamSelesai,
+		CreatedBy:  arg.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode shift sudah digunakan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to create shift")
+	}
+	return res, nil
+}
+
+func (mu *JadwalDinasUsecaseImpl) ListShift(c context.Context, isActive *bool) (any, error) {
+	res, err := mu.db.ListShift(c, isActive)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get shift list")
+	}
+	return res, nil
+}
+
+func (mu *JadwalDinasUsecaseImpl) UpdateShift(c context.Context, arg request.UpdateShift) (any, error) {
+	params := pg.UpdateShiftPartialParams{
+		Nama:      arg.Nama,
+		IsActive:  arg.IsActive,
+		UpdatedBy: arg.UpdatedBy,
+		ID:        arg.ID,
+	}
+
+	var err error
+	if arg.JamMulai != nil {
+		if params.JamMulai, err = utils.ParseJam(*arg.JamMulai); err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format jam_mulai harus HH:MM")
+		}
+	}
+	if arg.JamSelesai != nil {
+		if params.JamSelesai, err = utils.ParseJam(*arg.JamSelesai); err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format jam_selesai harus HH:MM")
+		}
+	}
+
+	res, err := mu.db.UpdateShiftPartial(c, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "shift not found")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update shift")
+	}
+	return res, nil
+}
+
+func (mu *JadwalDinasUsecaseImpl) DeleteShift(c context.Context, arg pg.DeleteShiftParams) error {
+	if err := mu.db.DeleteShift(c, arg); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed delete shift")
+	}
+	return nil
+}
+
+// AssignJadwalDinas menjadwalkan satu atau beberapa mahasiswa pada ruangan dan shift
+// untuk setiap tanggal dalam rentang. Jadwal yang sudah ada pada tanggal yang sama ditimpa.
+func (mu *JadwalDinasUsecaseImpl) AssignJadwalDinas(c context.Context, arg request.CreateJadwalDinas) (any, error) {
+	if len(arg.UserIDs) == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "user_ids wajib diisi")
+	}
+	if arg.RuanganID.IsNil() || arg.ShiftID.IsNil() {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "ruangan_id dan shift_id wajib diisi")
+	}
+
+	tglMulai, err := time.Parse("2006-01-02", arg.TglMulai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_mulai harus YYYY-MM-DD")
+	}
+	tglSelesai := tglMulai
+	if arg.TglSelesai != "" {
+		if tglSelesai, err = time.Parse("2006-01-02", arg.TglSelesai); err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_selesai harus YYYY-MM-DD")
+		}
+	}
+	if tglSelesai.Before(tglMulai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_selesai tidak boleh sebelum tgl_mulai")
+	}
+	if tglSelesai.Sub(tglMulai) > maksHariJadwalDinas*24*time.Hour {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "rentang jadwal dinas maksimal 92 hari")
+	}
+
+	res, err := mu.db.UpsertJadwalDinasRentang(c, pg.UpsertJadwalDinasRentangParams{
+		RuanganID:  arg.RuanganID,
+		ShiftID:    arg.ShiftID,
+		CreatedBy:  arg.CreatedBy,
+		UserIds:    arg.UserIDs,
+		TglMulai:   pgtype.Date{Valid: true, Time: tglMulai},
+		TglSelesai: pgtype.Date{Valid: true, Time: tglSelesai},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "ruangan atau shift tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to create jadwal dinas")
+	}
+	return res, nil
+}
+
+func (mu *JadwalDinasUsecaseImpl) ListJadwalDinas(c context.Context, arg request.SearchJadwalDinas) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	var cparams pg.CountJadwalDinasParams
+	if arg.UserID != "" {
+		id := uuid.FromStringOrNil(arg.UserID)
+		cparams.UserID = &id
+	}
+	if arg.RuanganID != "" {
+		id := uuid.FromStringOrNil(arg.RuanganID)
+		cparams.RuanganID = &id
+	}
+	if arg.TglAwal != "" {
+		cparams.TglAwal.Scan(arg.TglAwal)
+	}
+	if arg.TglAkhir != "" {
+		cparams.TglAkhir.Scan(arg.TglAkhir)
+	}
+
+	res, err := mu.db.ListJadwalDinas(c, pg.ListJadwalDinasParams{
+		UserID:    cparams.UserID,
+		RuanganID: cparams.RuanganID,
+		TglAwal:   cparams.TglAwal,
+		TglAkhir:  cparams.TglAkhir,
+		Limit:     arg.Limit,
+		Offset:    arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get jadwal dinas list")
+	}
+
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountJadwalDinas(c, cparams)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to count jadwal dinas")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+func (mu *JadwalDinasUsecaseImpl) DeleteJadwalDinas(c context.Context, arg pg.DeleteJadwalDinasParams) error {
+	if err := mu.db.DeleteJadwalDinas(c, arg); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed delete jadwal dinas")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"e-klinik/config"
 	"e-klinik/infra/pg"
 	"e-klinik/infra/worker"
 	"e-klinik/internal/domain/request"
@@ -9,8 +10,12 @@ import (
 	"e-klinik/pkg"
 	"e-klinik/utils"
 	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -18,7 +23,9 @@ import (
 )
 
 type KehadiranUsecase interface {
-	AddKehadiran(c context.Context, arg pg.CreateKehadiranParams) (any, error)
+	AddKehadiran(c context.Context, arg request.CreateKehadiran) (any, error)
+	CheckoutKehadiran(c context.Context, userID uuid.UUID, updatedBy string) (any, error)
+	GenerateQrKehadiran(c context.Context, ruanganID uuid.UUID, pembimbingKlinik uuid.UUID) (any, error)
 	ListKehadiran(c context.Context, arg request.SearchKehadiran) (any, error)
 	UpdateKehadiran(c context.Context, arg pg.UpdateKehadiranPartialParams) (any, error)
 	DeleteKehadiran(c context.Context, arg pg.DeleteKehadiranParams) error
@@ -26,33 +33,91 @@ type KehadiranUsecase interface {
 	GetKehadiranByPembimbingStatus(c context.Context, arg pg.GetKehadiranByPembimbingUserIdParams) (any, error)
 	GetKehadiranByMahasiswaStatus(c context.Context, arg pg.GetKehadiranByPembimbingUserIdParams) (any, error)
 	ListDistinctUserKehadiran(ctx context.Context, arg request.SearchUserKehadiran) (any, error)
+	ImportKehadiran(c context.Context, arg request.ImportKehadiran, berkas *multipart.FileHeader) (any, error)
 }
 
+// Status lokasi hasil pengecekan geofence saat absen masuk.
+const (
+	statusLokasiDalamRadius    = "dalam_radius"
+	statusLokasiPerbatasan     = "perbatasan"
+	statusLokasiTanpaKoordinat = "koordinat_fasilitas_kosong"
+)
+
 type KehadiranUsecaseImpl struct {
 	worker *worker.ProducerService
 	db     *pg.Queries
 	pg     *pkg.Postgres
+	cfg    *config.Config
 	cache  *pkg.RedisCache
 }
 
-func NewKehadiranUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *KehadiranUsecaseImpl {
+func NewKehadiranUsecase(postgre *pkg.Postgres, cfg *config.Config, worker *worker.ProducerService, cache *pkg.RedisCache) *KehadiranUsecaseImpl {
 	return &KehadiranUsecaseImpl{
 		db:     pg.New(postgre.Pool),
 		pg:     postgre,
+		cfg:    cfg,
 		worker: worker,
 		cache:  cache,
 	}
 }
 
-func (mu *KehadiranUsecaseImpl) AddKehadiran(c context.Context, arg pg.CreateKehadiranParams) (any, error) {
+func (mu *KehadiranUsecaseImpl) AddKehadiran(c context.Context, arg request.CreateKehadiran) (any, error) {
 	tgl, err := utils.GetJakartaDateObject()
 	if err != nil {
 		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get jakarta time")
 	}
 
-	arg.TglKehadiran = pgtype.Date{Valid: true, Time: tgl}
+	var params pg.CreateKehadiranParams
+	if err := copier.Copy(&params, &arg); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to copy kehadiran params")
+	}
+	params.TglKehadiran = pgtype.Date{Valid: true, Time: tgl}
+	if params.Presensi == "" {
+		params.Presensi = "hadir"
+	}
+	// Izin/sakit hanya tercatat lewat pengajuan izin yang disetujui pembimbing
+	if params.Presensi != "hadir" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeBadRequest, "izin atau sakit diajukan melalui pengajuan izin")
+	}
+
+	// 🏥 Fasilitas, kontrak, ruangan, mata kuliah & pembimbing diambil dari penempatan aktif
+	penempatan, err := mu.db.GetPenempatanAktif(c, pg.GetPenempatanAktifParams{
+		UserID: arg.UserID,
+		Tgl:    params.TglKehadiran,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeBadRequest, "Anda belum memiliki penempatan aktif hari ini")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get penempatan aktif")
+	}
+	params.FasilitasID = penempatan.FasilitasID
+	params.KontrakID = penempatan.KontrakID
+	params.RuanganID = penempatan.RuanganID
+	params.MataKuliahID = penempatan.MataKuliahID
+	params.PembimbingID = penempatan.PembimbingID
+	params.PembimbingKlinik = penempatan.PembimbingKlinik
+	params.PenempatanID = &penempatan.ID
+
+	// 🔐 Validasi token QR dari pembimbing klinik
+	if err := mu.cekQrKehadiran(arg.QrToken, &params); err != nil {
+		return nil, err
+	}
+
+	// 📍 Validasi lokasi perangkat terhadap koordinat fasilitas
+	jarak, statusLokasi, err := mu.cekGeofence(c, arg, params.FasilitasID)
+	if err != nil {
+		return nil, err
+	}
+	params.JarakMeter = jarak
+	params.StatusLokasi = &statusLokasi
+
+	// 🗓️ Cocokkan dengan jadwal dinas (roster) hari ini
+	if err := mu.cekJadwalDinas(c, &params); err != nil {
+		return nil, err
+	}
 
-	res, err := mu.db.CreateKehadiran(c, arg)
+	res, err := mu.db.CreateKehadiran(c, params)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		switch {
@@ -68,6 +133,283 @@ func (mu *KehadiranUsecaseImpl) AddKehadiran(c context.Context, arg pg.CreateKeh
 	return res, nil
 }
 
+// cekQrKehadiran memastikan absen disertai token QR yang masih berlaku untuk ruangan
+// penempatan dan tanggal yang sama.
+func (mu *KehadiranUsecaseImpl) cekQrKehadiran(token string, params *pg.CreateKehadiranParams) error {
+	if token == "" {
+		if !mu.cfg.Kehadiran.WajibQr {
+			return nil
+		}
+		return pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "scan QR absen dari pembimbing klinik terlebih dahulu")
+	}
+
+	claims, err := pkg.ParseQrKehadiranToken(token, mu.cfg.JWT.QrKehadiranSecret)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return pkg.ExposeError(pkg.ErrorCodeBadRequest, "QR absen sudah kedaluwarsa, scan ulang QR terbaru")
+		}
+		return pkg.ExposeError(pkg.ErrorCodeBadRequest, "QR absen tidak valid")
+	}
+
+	if claims.RuanganID != params.RuanganID.String() {
+		return pkg.ExposeError(pkg.ErrorCodeBadRequest, "QR absen bukan untuk ruangan ini")
+	}
+	if claims.Tgl != params.TglKehadiran.Time.Format("2006-01-02") {
+		return pkg.ExposeError(pkg.ErrorCodeBadRequest, "QR absen bukan untuk hari ini")
+	}
+	return nil
+}
+
+// GenerateQrKehadiran menerbitkan token QR absen untuk ruangan yang dibimbing pembimbing klinik.
+// Token hanya berlaku 30–60 detik, sehingga layar QR perlu meminta token baru secara berkala.
+func (mu *KehadiranUsecaseImpl) GenerateQrKehadiran(c context.Context, ruanganID uuid.UUID, pembimbingKlinik uuid.UUID) (any, error) {
+	ok, err := mu.db.CekPembimbingKlinikRuangan(c, pg.CekPembimbingKlinikRuanganParams{
+		RuanganID: ruanganID,
+		UserID:    pembimbingKlinik,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check pembimbing klinik")
+	}
+	if !ok {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pembimbing klinik pada ruangan ini")
+	}
+
+	tgl, err := utils.GetJakartaDateObject()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get jakarta time")
+	}
+
+	ttl := time.Duration(min(max(mu.cfg.Kehadiran.QrTtlDetik, 30), 60)) * time.Second
+	token, exp, err := pkg.CreateQrKehadiranToken(ruanganID.String(), pembimbingKlinik.String(), tgl.Format("2006-01-02"), mu.cfg.JWT.QrKehadiranSecret, ttl)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create qr token")
+	}
+
+	return map[string]any{
+		"token":      token,
+		"expires_at": exp,
+		"ttl_detik":  int(ttl.Seconds()),
+		"ruangan_id": ruanganID,
+		"tgl":        tgl.Format("2006-01-02"),
+	}, nil
+}
+
+// cekGeofence menghitung jarak perangkat ke fasilitas dan menolak absen di luar radius.
+// Absen yang hanya masuk radius bila memperhitungkan akurasi GPS tetap diterima
+// dengan status "perbatasan" agar dapat ditinjau koordinator. Fasilitas tanpa koordinat
+// tidak dapat diperiksa, sehingga absennya ditolak kecuali diizinkan lewat konfigurasi.
+// Tanpa akurasi, lokasi tidak dapat dianggap tepat: absen di dalam radius tetap berstatus
+// "perbatasan".
+func (mu *KehadiranUsecaseImpl) cekGeofence(c context.Context, arg request.CreateKehadiran, fasilitasID uuid.UUID) (*float64, string, error) {
+	if arg.Latitude == nil || arg.Longitude == nil {
+		return nil, "", pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lokasi perangkat (latitude, longitude) wajib dikirim")
+	}
+
+	akurasi := 0.0
+	if arg.Akurasi != nil {
+		akurasi = *arg.Akurasi
+	}
+	if akurasi < 0 || akurasi > mu.cfg.Geofence.MaxAkurasiMeter {
+		return nil, "", pkg.ExposeError(pkg.ErrorCodeBadRequest, fmt.Sprintf("akurasi lokasi terlalu rendah (%.0f m), aktifkan GPS lalu coba lagi", akurasi))
+	}
+
+	fasilitas, err := mu.db.GetKoordinatFasilitas(c, fasilitasID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", pkg.ExposeError(pkg.ErrorCodeNotFound, "fasilitas kesehatan tidak ditemukan")
+		}
+		return nil, "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get koordinat fasilitas")
+	}
+
+	if fasilitas.Latitude == nil || fasilitas.Longitude == nil {
+		if !mu.cfg.Geofence.IzinkanTanpaKoordinat {
+			return nil, "", pkg.ExposeError(pkg.ErrorCodeBadRequest, "koordinat fasilitas belum diatur, hubungi admin sebelum absen")
+		}
+		return nil, statusLokasiTanpaKoordinat, nil
+	}
+
+	jarak := utils.HaversineMeter(*arg.Latitude, *arg.Longitude, *fasilitas.Latitude, *fasilitas.Longitude)
+	radius := mu.cfg.Geofence.RadiusMeter
+
+	switch {
+	case jarak <= radius && arg.Akurasi == nil:
+		return &jarak, statusLokasiPerbatasan, nil
+	case jarak <= radius:
+		return &jarak, statusLokasiDalamRadius, nil
+	case jarak-akurasi <= radius:
+		return &jarak, statusLokasiPerbatasan, nil
+	default:
+		return nil, "", pkg.ExposeError(pkg.ErrorCodeBadRequest, fmt.Sprintf("lokasi Anda berada %.0f m dari fasilitas, di luar radius absen %.0f m", jarak, radius))
+	}
+}
+
+// cekJadwalDinas mencocokkan absen masuk dengan jadwal dinas mahasiswa pada tanggal tersebut.
+// Absen setelah tengah malam juga dicocokkan dengan shift malam kemarin yang masih berlangsung;
+// bila cocok, tgl_kehadiran mengikuti tanggal dinas shift tersebut. Bila jadwal ada, ruangan
+// harus sama dan waktu absen harus berada di jendela shift; jadwal_dinas dan jadwal_id kemudian
+// diisi dari roster. Tanpa jadwal, absen ditolak bila KEHADIRAN_WAJIB_JADWAL aktif, selain itu
+// diterima dengan jadwal_dinas dan jadwal_id kosong sebagai penanda.
+func (mu *KehadiranUsecaseImpl) cekJadwalDinas(c context.Context, params *pg.CreateKehadiranParams) error {
+	now := time.Now()
+	tglDinas := params.TglKehadiran.Time
+	jadwal, err := mu.jadwalDinas(c, params.UserID, tglDinas)
+	if err != nil {
+		return err
+	}
+	if jadwal == nil || !mu.dalamJendelaAbsen(tglDinas, *jadwal, now) {
+		kemarin := tglDinas.AddDate(0, 0, -1)
+		malam, err := mu.jadwalDinas(c, params.UserID, kemarin)
+		if err != nil {
+			return err
+		}
+		if malam != nil && mu.dalamJendelaAbsen(kemarin, *malam, now) {
+			jadwal, tglDinas = malam, kemarin
+		}
+	}
+
+	params.JadwalDinas = nil
+	params.JadwalID = nil
+	if jadwal == nil {
+		if mu.cfg.Kehadiran.WajibJadwal {
+			return pkg.ExposeError(pkg.ErrorCodeBadRequest, "Anda tidak memiliki jadwal dinas hari ini")
+		}
+		return nil
+	}
+
+	if jadwal.RuanganID != params.RuanganID {
+		return pkg.ExposeError(pkg.ErrorCodeBadRequest, "ruangan tidak sesuai dengan jadwal dinas hari ini")
+	}
+	if !mu.dalamJendelaAbsen(tglDinas, *jadwal, now) {
+		bukaAbsen, akhir := mu.jendelaAbsen(tglDinas, *jadwal)
+		return pkg.ExposeError(pkg.ErrorCodeBadRequest, fmt.Sprintf("absen shift %s hanya dapat dilakukan pukul %s - %s",
+			jadwal.KodeShift, bukaAbsen.Format("15:04"), akhir.Format("15:04")))
+	}
+
+	params.TglKehadiran = pgtype.Date{Valid: true, Time: tglDinas}
+	params.JadwalDinas = &jadwal.KodeShift
+	params.JadwalID = &jadwal.ID
+	return nil
+}
+
+// jadwalDinas mengambil jadwal dinas mahasiswa pada tanggal tertentu; nil bila tidak ada.
+func (mu *KehadiranUsecaseImpl) jadwalDinas(c context.Context, userID uuid.UUID, tgl time.Time) (*pg.GetJadwalDinasMahasiswaRow, error) {
+	jadwal, err := mu.db.GetJadwalDinasMahasiswa(c, pg.GetJadwalDinasMahasiswaParams{
+		UserID:   userID,
+		TglDinas: pgtype.Date{Valid: true, Time: tgl},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get jadwal dinas")
+	}
+	return &jadwal, nil
+}
+
+// jendelaAbsen mengembalikan rentang waktu absen masuk sebuah jadwal: sejak BatasAwalMasukMenit
+// sebelum shift mulai sampai shift selesai.
+func (mu *KehadiranUsecaseImpl) jendelaAbsen(tglDinas time.Time, jadwal pg.GetJadwalDinasMahasiswaRow) (time.Time, time.Time) {
+	mulai, akhir := waktuShift(tglDinas, jadwal.JamMulai, jadwal.JamSelesai)
+	return mulai.Add(-time.Duration(mu.cfg.Kehadiran.BatasAwalMasukMenit) * time.Minute), akhir
+}
+
+func (mu *KehadiranUsecaseImpl) dalamJendelaAbsen(tglDinas time.Time, jadwal pg.GetJadwalDinasMahasiswaRow, now time.Time) bool {
+	buka, akhir := mu.jendelaAbsen(tglDinas, jadwal)
+	return !now.Before(buka) && !now.After(akhir)
+}
+
+func (mu *KehadiranUsecaseImpl) CheckoutKehadiran(c context.Context, userID uuid.UUID, updatedBy string) (any, error) {
+	tgl, err := utils.GetJakartaDateObject()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get jakarta time")
+	}
+
+	// Shift malam berakhir keesokan hari, jadi absen masuk kemarin masih bisa ditutup
+	kehadiran, err := mu.db.GetKehadiranBelumPulang(c, pg.GetKehadiranBelumPulangParams{
+		UserID:  userID,
+		TglAwal: pgtype.Date{Valid: true, Time: tgl.AddDate(0, 0, -1)},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "tidak ada absen masuk yang belum ditutup")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+
+	now := time.Now()
+
+	// Durasi dihitung paling lama sampai batas pulang agar absen pulang yang terlupa tidak
+	// tercatat sebagai dinas berjam-jam; koreksi jam dilakukan lewat koordinator.
+	var pulangAwal *bool
+	batas := kehadiran.CreatedAt.Time.Add(time.Duration(mu.cfg.Kehadiran.MaksDurasiJam) * time.Hour)
+	shift, err := mu.shiftKehadiran(c, kehadiran)
+	if err != nil {
+		return nil, err
+	}
+	if shift != nil {
+		_, akhir := waktuShift(kehadiran.TglKehadiran.Time, shift.JamMulai, shift.JamSelesai)
+		toleransi := time.Duration(mu.cfg.Kehadiran.ToleransiPulangAwalMenit) * time.Minute
+		pulangAwal = utils.BoolPtr(now.Before(akhir.Add(-toleransi)))
+		batas = akhir.Add(time.Duration(mu.cfg.Kehadiran.BatasPulangLambatMenit) * time.Minute)
+	}
+	pulang := now
+	if pulang.After(batas) {
+		pulang = batas
+	}
+	durasi := int32(max(pulang.Sub(kehadiran.CreatedAt.Time), 0).Minutes())
+
+	res, err := mu.db.CheckoutKehadiran(c, pg.CheckoutKehadiranParams{
+		JamPulang:   pgtype.Timestamptz{Valid: true, Time: now},
+		DurasiMenit: &durasi,
+		PulangAwal:  pulangAwal,
+		UpdatedBy:   &updatedBy,
+		ID:          kehadiran.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "Anda sudah absen pulang")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed checkout kehadiran")
+	}
+
+	return res, nil
+}
+
+// shiftKehadiran mengambil jam shift sebuah kehadiran dari roster (jadwal_id). Teks jadwal_dinas
+// tidak dipakai karena dapat berasal dari klien. Nil bila kehadiran tidak terjadwal.
+func (mu *KehadiranUsecaseImpl) shiftKehadiran(c context.Context, kehadiran pg.Kehadiran) (*pg.GetShiftJadwalDinasRow, error) {
+	if kehadiran.JadwalID == nil {
+		return nil, nil
+	}
+	shift, err := mu.db.GetShiftJadwalDinas(c, *kehadiran.JadwalID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get shift kehadiran")
+	}
+	return &shift, nil
+}
+
+// waktuShift menghitung waktu mulai dan selesai shift (WIB) untuk tanggal dinas tertentu.
+// Shift yang jam selesainya tidak lebih dari jam mulai dianggap berakhir keesokan hari.
+func waktuShift(tgl time.Time, jamMulai, jamSelesai pgtype.Time) (time.Time, time.Time) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.FixedZone("WIB", 7*60*60)
+	}
+
+	mulai := time.Duration(jamMulai.Microseconds) * time.Microsecond
+	selesai := time.Duration(jamSelesai.Microseconds) * time.Microsecond
+
+	awalHari := time.Date(tgl.Year(), tgl.Month(), tgl.Day(), 0, 0, 0, 0, loc)
+	akhir := awalHari.Add(selesai)
+	if selesai <= mulai {
+		akhir = akhir.Add(24 * time.Hour)
+	}
+	return awalHari.Add(mulai), akhir
+}
+
 func (mu *KehadiranUsecaseImpl) ListKehadiran(c context.Context, arg request.SearchKehadiran) (any, error) {
 	if arg.Limit <= 0 {
 		arg.Limit = 10
@@ -113,15 +455,31 @@ func (mu *KehadiranUsecaseImpl) ListKehadiran(c context.Context, arg request.Sea
 	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
 }
 
+// UpdateKehadiran mengubah kehadiran secara langsung dan mencatat snapshot
+// sebelum/sesudahnya ke riwayat_kehadiran.
 func (mu *KehadiranUsecaseImpl) UpdateKehadiran(c context.Context, arg pg.UpdateKehadiranPartialParams) (any, error) {
-	res, err := mu.db.UpdateKehadiranPartial(c, arg)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran not found")
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		sebelum, err := qtx.GetKehadiran(c, arg.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran not found")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
 		}
-		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update kehadiran")
-	}
-	return res, nil
+
+		res, err := qtx.UpdateKehadiranPartial(c, arg)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran not found")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update kehadiran")
+		}
+
+		if err := catatRiwayatKehadiran(c, qtx, sebelum, res, nil, arg.UpdatedNote, arg.UpdatedBy); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
 }
 
 func (mu *KehadiranUsecaseImpl) DeleteKehadiran(c context.Context, arg pg.DeleteKehadiranParams) error {
@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Status koreksi kehadiran.
+const (
+	statusKoreksiMenunggu = "menunggu"
+	statusKoreksiDiterima = "diterima"
+	statusKoreksiDitolak  = "ditolak"
+)
+
+// presensiValid adalah seluruh nilai presensi yang dikenal.
+var presensiValid = map[string]bool{
+	"hadir": true,
+	"izin":  true,
+	"sakit": true,
+	"alpa":  true,
+}
+
+// presensiKoreksi adalah nilai presensi yang boleh diajukan lewat koreksi. Izin dan sakit
+// hanya lewat pengajuan izin, yang mewajibkan lampiran dan review pembimbing.
+var presensiKoreksi = map[string]bool{
+	"hadir": true,
+	"alpa":  true,
+}
+
+type KoreksiKehadiranUsecase interface {
+	AjukanKoreksi(c context.Context, arg request.CreateKoreksiKehadiran) (any, error)
+	ListKoreksi(c context.Context, arg request.SearchKoreksiKehadiran) (any, error)
+	ReviewKoreksi(c context.Context, arg request.ReviewKoreksiKehadiran) (any, error)
+	ListRiwayatKehadiran(c context.Context, kehadiranID uuid.UUID, userID uuid.UUID) (any, error)
+}
+
+type KoreksiKehadiranUsecaseImpl struct {
+	worker *worker.ProducerService
+	db     *pg.Queries
+	pg     *pkg.Postgres
+	cache  *pkg.RedisCache
+}
+
+func NewKoreksiKehadiranUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *KoreksiKehadiranUsecaseImpl {
+	return &KoreksiKehadiranUsecaseImpl{
+		db:     pg.New(postgre.Pool),
+		pg:     postgre,
+		worker: worker,
+		cache:  cache,
+	}
+}
+
+// RiwayatKehadiran adalah bentuk respons riwayat dengan snapshot JSON apa adanya.
+type RiwayatKehadiran struct {
+	ID          uuid.UUID          `json:"id"`
+	KehadiranID uuid.UUID          `json:"kehadiran_id"`
+	KoreksiID   *uuid.UUID         `json:"koreksi_id"`
+	Sebelum     json.RawMessage    `json:"sebelum"`
+	Sesudah     json.RawMessage    `json:"sesudah"`
+	Alasan      *string            `json:"alasan"`
+	CreatedBy   *string            `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// AjukanKoreksi dapat dilakukan oleh mahasiswa pemilik kehadiran atau pembimbingnya.
+func (mu *KoreksiKehadiranUsecaseImpl) AjukanKoreksi(c context.Context, arg request.CreateKoreksiKehadiran) (any, error) {
+	if strings.TrimSpace(arg.Alasan) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "alasan wajib diisi")
+	}
+
+	params := pg.CreateKoreksiKehadiranParams{
+		KehadiranID:  arg.KehadiranID,
+		DiajukanOleh: arg.DiajukanOleh,
+		RuanganID:    arg.RuanganID,
+		Alasan:       strings.TrimSpace(arg.Alasan),
+		CreatedBy:    arg.CreatedBy,
+	}
+	if arg.Presensi != nil {
+		presensi := strings.ToLower(strings.TrimSpace(*arg.Presensi))
+		if !presensiKoreksi[presensi] {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "presensi koreksi harus hadir atau alpa; izin dan sakit diajukan lewat pengajuan izin")
+		}
+		params.Presensi = &presensi
+	}
+	if arg.TglKehadiran != nil {
+		tgl, err := time.Parse("2006-01-02", *arg.TglKehadiran)
+		if err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_kehadiran harus YYYY-MM-DD")
+		}
+		params.TglKehadiran = pgtype.Date{Valid: true, Time: tgl}
+	}
+	if params.Presensi == nil && params.RuanganID == nil && !params.TglKehadiran.Valid {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "minimal satu dari presensi, ruangan_id, atau tgl_kehadiran harus diisi")
+	}
+
+	k, err := mu.db.GetKehadiran(c, arg.KehadiranID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+	if arg.DiajukanOleh != k.UserID && arg.DiajukanOleh != k.PembimbingID && arg.DiajukanOleh != k.PembimbingKlinik {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda tidak berhak mengajukan koreksi untuk kehadiran ini")
+	}
+
+	res, err := mu.db.CreateKoreksiKehadiran(c, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "masih ada koreksi yang menunggu review untuk kehadiran ini")
+			case "23503":
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "ruangan tidak ditemukan")
+			}
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create koreksi kehadiran")
+	}
+	return res, nil
+}
+
+func (mu *KoreksiKehadiranUsecaseImpl) ListKoreksi(c context.Context, arg request.SearchKoreksiKehadiran) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	var cparams pg.CountKoreksiKehadiranParams
+	if arg.KehadiranID != "" {
+		id := uuid.FromStringOrNil(arg.KehadiranID)
+		cparams.KehadiranID = &id
+	}
+	if arg.DiajukanOleh != "" {
+		id := uuid.FromStringOrNil(arg.DiajukanOleh)
+		cparams.DiajukanOleh = &id
+	}
+	if arg.ReviewerID != "" {
+		id := uuid.FromStringOrNil(arg.ReviewerID)
+		cparams.ReviewerID = &id
+	}
+	if arg.Status != "" {
+		cparams.Status = &arg.Status
+	}
+	cparams.PemanggilID = arg.PemanggilID
+
+	res, err := mu.db.ListKoreksiKehadiran(c, pg.ListKoreksiKehadiranParams{
+		KehadiranID:  cparams.KehadiranID,
+		DiajukanOleh: cparams.DiajukanOleh,
+		ReviewerID:   cparams.ReviewerID,
+		Status:       cparams.Status,
+		PemanggilID:  cparams.PemanggilID,
+		Limit:        arg.Limit,
+		Offset:       arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get koreksi kehadiran list")
+	}
+
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountKoreksiKehadiran(c, cparams)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to count koreksi kehadiran")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+// ReviewKoreksi diputuskan oleh pembimbing atau pembimbing klinik kehadiran tersebut,
+// selain pengaju. Koreksi yang diterima langsung diterapkan dan dicatat ke riwayat
+// dalam satu transaksi.
+func (mu *KoreksiKehadiranUsecaseImpl) ReviewKoreksi(c context.Context, arg request.ReviewKoreksiKehadiran) (any, error) {
+	status := strings.ToLower(strings.TrimSpace(arg.Status))
+	if status != statusKoreksiDiterima && status != statusKoreksiDitolak {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "status harus diterima atau ditolak")
+	}
+	if status == statusKoreksiDitolak && (arg.CatatanReview == nil || strings.TrimSpace(*arg.CatatanReview) == "") {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "catatan_review wajib diisi saat menolak koreksi")
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		koreksi, err := qtx.GetKoreksiKehadiran(c, arg.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "koreksi kehadiran tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get koreksi kehadiran")
+		}
+		if koreksi.Status != statusKoreksiMenunggu {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "koreksi kehadiran sudah "+koreksi.Status)
+		}
+
+		sebelum, err := qtx.GetKehadiran(c, koreksi.KehadiranID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+		}
+		if arg.ReviewerID != sebelum.PembimbingID && arg.ReviewerID != sebelum.PembimbingKlinik {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pembimbing pada kehadiran ini")
+		}
+		if arg.ReviewerID == koreksi.DiajukanOleh {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "koreksi tidak dapat direview oleh pengajunya sendiri")
+		}
+
+		res, err := qtx.ReviewKoreksiKehadiran(c, pg.ReviewKoreksiKehadiranParams{
+			Status:        status,
+			CatatanReview: arg.CatatanReview,
+			ReviewerID:    &arg.ReviewerID,
+			ReviewedBy:    arg.ReviewedBy,
+			ID:            arg.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "koreksi kehadiran sudah direview")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed review koreksi kehadiran")
+		}
+
+		if status == statusKoreksiDiterima {
+			// Koreksi lama ke izin/sakit yang masih menunggu hanya dapat ditolak.
+			if res.Presensi != nil && !presensiKoreksi[*res.Presensi] {
+				return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "koreksi ke izin atau sakit tidak dapat diterima; ajukan lewat pengajuan izin")
+			}
+			sesudah, err := qtx.ApplyKoreksiKehadiran(c, pg.ApplyKoreksiKehadiranParams{
+				UpdatedBy: arg.ReviewedBy,
+				KoreksiID: res.ID,
+			})
+			if err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "mahasiswa sudah memiliki kehadiran pada tanggal tersebut")
+				}
+				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed apply koreksi kehadiran")
+			}
+			if err := catatRiwayatKehadiran(c, qtx, sebelum, sesudah, &res.ID, &res.Alasan, arg.ReviewedBy); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	})
+}
+
+// ListRiwayatKehadiran hanya dapat dilihat oleh mahasiswa pemilik dan pembimbingnya.
+func (mu *KoreksiKehadiranUsecaseImpl) ListRiwayatKehadiran(c context.Context, kehadiranID uuid.UUID, userID uuid.UUID) (any, error) {
+	k, err := mu.db.GetKehadiran(c, kehadiranID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+	if userID != k.UserID && userID != k.PembimbingID && userID != k.PembimbingKlinik {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda tidak berhak melihat riwayat kehadiran ini")
+	}
+
+	rows, err := mu.db.ListRiwayatKehadiran(c, kehadiranID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get riwayat kehadiran")
+	}
+
+	res := make([]RiwayatKehadiran, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, RiwayatKehadiran{
+			ID:          r.ID,
+			KehadiranID: r.KehadiranID,
+			KoreksiID:   r.KoreksiID,
+			Sebelum:     json.RawMessage(r.Sebelum),
+			Sesudah:     json.RawMessage(r.Sesudah),
+			Alasan:      r.Alasan,
+			CreatedBy:   r.CreatedBy,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// catatRiwayatKehadiran menyimpan snapshot kehadiran sebelum dan sesudah perubahan.
+// Harus dipanggil dengan qtx di dalam transaksi yang sama dengan perubahannya.
+func catatRiwayatKehadiran(c context.Context, qtx *pg.Queries, sebelum, sesudah pg.Kehadiran, koreksiID *uuid.UUID, alasan, oleh *string) error {
+	b, err := json.Marshal(sebelum)
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed encode riwayat kehadiran")
+	}
+	a, err := json.Marshal(sesudah)
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed encode riwayat kehadiran")
+	}
+
+	if _, err := qtx.CreateRiwayatKehadiran(c, pg.CreateRiwayatKehadiranParams{
+		KehadiranID: sesudah.ID,
+		KoreksiID:   koreksiID,
+		Sebelum:     b,
+		Sesudah:     a,
+		Alasan:      alasan,
+		CreatedBy:   oleh,
+	}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create riwayat kehadiran")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// errAkunTerkunci adalah error yang diteruskan ke client agar tahu kapan dapat mencoba lagi.
+func errAkunTerkunci(sampai time.Time) error {
+	return pkg.ExposeError(pkg.ErrorCodeLocked, "akun terkunci hingga "+sampai.Format(time.RFC3339))
+}
+
+// durasiKunciAkun menghitung lama penguncian untuk jumlah kegagalan beruntun: nol di bawah
+// ambang, lalu KunciAwalMenit yang berlipat dua setiap kegagalan berikutnya.
+func durasiKunciAkun(cfg config.AuthConfig, gagal int) time.Duration {
+	if cfg.MaksGagalLogin <= 0 || gagal < cfg.MaksGagalLogin {
+		return 0
+	}
+	maks := time.Duration(cfg.KunciMaksMenit) * time.Minute
+	durasi := time.Duration(cfg.KunciAwalMenit) * time.Minute
+	for i := cfg.MaksGagalLogin; i < gagal; i++ {
+		// tanpa KunciMaksMenit, penggandaan tetap dihentikan sebelum durasi meluap
+		if (maks > 0 && durasi >= maks) || durasi > math.MaxInt64/2 {
+			break
+		}
+		durasi *= 2
+	}
+	if maks > 0 && durasi > maks {
+		durasi = maks
+	}
+	return durasi
+}
+
+// catatGagalLogin menambah hitungan login gagal dalam jendela waktu dan mengunci akun bila
+// ambang terlampaui. Mengembalikan errAkunTerkunci bila akun baru saja dikunci.
+func (uu *UserUsecaseImpl) catatGagalLogin(c context.Context, id uuid.UUID) error {
+	gagal, err := uu.db.CatatGagalLogin(c, pg.CatatGagalLoginParams{
+		JendelaMenit: int32(uu.cfg.Auth.JendelaGagalMenit),
+		ID:           id,
+	})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed catat gagal login")
+	}
+	if gagal == nil {
+		return nil
+	}
+
+	durasi := durasiKunciAkun(uu.cfg.Auth, int(*gagal))
+	if durasi == 0 {
+		return nil
+	}
+	sampai := time.Now().Add(durasi)
+	if err := uu.db.KunciUser(c, pg.KunciUserParams{
+		LockedUntil: pgtype.Timestamptz{Time: sampai, Valid: true},
+		ID:          id,
+	}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed kunci user")
+	}
+	return errAkunTerkunci(sampai)
+}
+
+// BukaKunciUser dipakai admin untuk membuka akun yang terkunci dan mengosongkan hitungan gagal login.
+func (uu *UserUsecaseImpl) BukaKunciUser(c context.Context, id uuid.UUID, oleh *string) (any, error) {
+	n, err := uu.db.ResetGagalLogin(c, pg.ResetGagalLoginParams{UpdatedBy: oleh, ID: id})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed buka kunci user")
+	}
+	if n == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+	}
+	return map[string]any{"id": id, "terkunci": false}, nil
+}
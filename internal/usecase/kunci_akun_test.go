@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"e-klinik/config"
+	"testing"
+	"time"
+)
+
+func TestDurasiKunciAkun(t *testing.T) {
+	cfg := config.AuthConfig{MaksGagalLogin: 5, KunciAwalMenit: 5, KunciMaksMenit: 60}
+
+	tests := []struct {
+		name  string
+		cfg   config.AuthConfig
+		gagal int
+		want  time.Duration
+	}{
+		{"di bawah ambang", cfg, 4, 0},
+		{"tepat di ambang", cfg, 5, 5 * time.Minute},
+		{"berlipat dua", cfg, 6, 10 * time.Minute},
+		{"berlipat empat", cfg, 7, 20 * time.Minute},
+		{"dibatasi maksimum", cfg, 9, 60 * time.Minute},
+		{"jauh di atas maksimum", cfg, 100, 60 * time.Minute},
+		{"penguncian nonaktif", config.AuthConfig{KunciAwalMenit: 5, KunciMaksMenit: 60}, 100, 0},
+		{"tanpa batas maksimum", config.AuthConfig{MaksGagalLogin: 3, KunciAwalMenit: 1}, 5, 4 * time.Minute},
+		{"tanpa batas maksimum tidak meluap", config.AuthConfig{MaksGagalLogin: 1, KunciAwalMenit: 1}, 1000, time.Minute << 27},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := durasiKunciAkun(tt.cfg, tt.gagal); got != tt.want {
+				t.Errorf("durasiKunciAkun(%d) = %v, want %v", tt.gagal, got, tt.want)
+			}
+		})
+	}
+}
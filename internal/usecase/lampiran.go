@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/pkg"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// maksUkuranLampiran adalah batas ukuran berkas lampiran (5 MB).
+const maksUkuranLampiran = 5 << 20
+
+// tipeLampiranDiizinkan memetakan tipe konten yang diterima ke ekstensi berkas.
+var tipeLampiranDiizinkan = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type lampiran struct {
+	Key  string
+	Nama string
+	Tipe string
+}
+
+// simpanLampiran memvalidasi berkas lalu mengunggahnya ke MinIO dengan key <prefix>/<ulid><ext>.
+// Tipe berkas dideteksi dari isinya, bukan dari header yang dikirim klien.
+func simpanLampiran(ctx context.Context, s3 *minio.Client, bucket, prefix string, fh *multipart.FileHeader) (*lampiran, error) {
+	if s3 == nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInternal, "penyimpanan lampiran tidak tersedia")
+	}
+	if fh.Size > maksUkuranLampiran {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "ukuran lampiran maksimal 5 MB")
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed open lampiran")
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed read lampiran")
+	}
+	tipe := http.DetectContentType(head[:n])
+	ext, ok := tipeLampiranDiizinkan[tipe]
+	if !ok {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lampiran harus berupa PDF, JPG, atau PNG")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed read lampiran")
+	}
+
+	key := path.Join(prefix, pkg.NewUlid()+ext)
+	if _, err := s3.PutObject(ctx, bucket, key, f, fh.Size, minio.PutObjectOptions{ContentType: tipe}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed upload lampiran")
+	}
+
+	return &lampiran{Key: key, Nama: filepath.Base(fh.Filename), Tipe: tipe}, nil
+}
+
+// urlLampiran membuat presigned URL berumur pendek untuk mengunduh lampiran.
+func urlLampiran(ctx context.Context, s3 *minio.Client, bucket, key, nama string) (string, error) {
+	if s3 == nil {
+		return "", pkg.ExposeError(pkg.ErrorCodeInternal, "penyimpanan lampiran tidak tersedia")
+	}
+
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", nama))
+	u, err := s3.PresignedGetObject(ctx, bucket, key, 15*time.Minute, params)
+	if err != nil {
+		return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed presign lampiran")
+	}
+	return u.String(), nil
+}
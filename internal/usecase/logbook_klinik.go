@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/pkg"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var kolomLogbook = []string{"No", "Tanggal", "Shift", "Ruangan", "Presensi", "Masuk", "Pulang", "Jam Dinas"}
+
+var lebarLogbook = []float64{10, 24, 22, 52, 20, 18, 18, 22}
+
+// LogbookKlinik menulis logbook praktik klinik seorang mahasiswa untuk satu penempatan
+// dalam format PDF. Isi dokumen hanya bergantung pada data penempatan: urutan baris
+// ditentukan query, tanggal dokumen dan tanggal tanda tangan diambil dari tgl_selesai,
+// sehingga penempatan dengan data yang sama selalu menghasilkan berkas yang identik.
+// pemanggilID nil berarti tanpa batasan (admin/koordinator); selain itu hanya mahasiswa
+// pemilik penempatan serta pembimbing dan pembimbing kliniknya yang boleh mengunduh.
+func (mu *SummaryUsecaseImpl) LogbookKlinik(c context.Context, penempatanID uuid.UUID, pemanggilID *uuid.UUID, tulis func(nama, tipe string) io.Writer) error {
+	p, err := mu.db.GetLogbookPenempatan(c, penempatanID)
+	if err != nil {
+		return errEksporTidakDitemukan(err, "penempatan")
+	}
+	if pemanggilID != nil && *pemanggilID != p.UserID && *pemanggilID != p.PembimbingID && *pemanggilID != p.PembimbingKlinik {
+		return pkg.ExposeError(pkg.ErrorCodeForbidden, "akses ditolak")
+	}
+
+	kehadiran, err := mu.db.ListLogbookKehadiran(c, pg.ListLogbookKehadiranParams{
+		UserID:   p.UserID,
+		TglAwal:  p.TglMulai,
+		TglAkhir: p.TglSelesai,
+	})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran logbook")
+	}
+	skp, err := mu.db.ListLogbookSkpDisetujui(c, pg.ListLogbookSkpDisetujuiParams{
+		UserID:   p.UserID,
+		TglAwal:  p.TglMulai,
+		TglAkhir: p.TglSelesai,
+	})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp logbook")
+	}
+
+	skpPerKehadiran := make(map[uuid.UUID][]pg.ListLogbookSkpDisetujuiRow, len(kehadiran))
+	for _, s := range skp {
+		skpPerKehadiran[s.KehadiranID] = append(skpPerKehadiran[s.KehadiranID], s)
+	}
+
+	nama := fmt.Sprintf("logbook-%s-%s-%s.pdf", p.Username, p.TglMulai.Time.Format("20060102"), p.TglSelesai.Time.Format("20060102"))
+	return mu.tulisLogbookPdf(p, kehadiran, skpPerKehadiran, len(skp), func() io.Writer { return tulis(nama, tipePdf) })
+}
+
+func (mu *SummaryUsecaseImpl) tulisLogbookPdf(p pg.GetLogbookPenempatanRow, kehadiran []pg.ListLogbookKehadiranRow, skp map[uuid.UUID][]pg.ListLogbookSkpDisetujuiRow, totalSkp int, buka func() io.Writer) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// 🔒 Metadata tetap agar hasil dapat direproduksi byte demi byte
+	tglDokumen := p.TglSelesai.Time.UTC()
+	pdf.SetCreationDate(tglDokumen)
+	pdf.SetModificationDate(tglDokumen)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle("Logbook Praktik Klinik "+p.Username, true)
+	pdf.SetMargins(12, 12, 12)
+	pdf.SetAutoPageBreak(false, 12)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	lebarHalaman, tinggiHalaman := pdf.GetPageSize()
+	lebarIsi := lebarHalaman - 24
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(lebarIsi/2, 5, tr(p.NamaMahasiswa+" - "+p.Username), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	kepala := func() {
+		pdf.SetFont("Arial", "B", 8)
+		pdf.SetFillColor(230, 230, 230)
+		for i, k := range kolomLogbook {
+			pdf.CellFormat(lebarLogbook[i], 7, tr(k), "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Arial", "", 8)
+	}
+	cukup := func(tinggi float64) {
+		if pdf.GetY()+tinggi > tinggiHalaman-15 {
+			pdf.AddPage()
+			kepala()
+		}
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 7, tr(mu.cfg.Ekspor.Institusi), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 7, "LOGBOOK PRAKTIK KLINIK", "", 1, "C", false, 0, "")
+	pdf.Line(12, pdf.GetY()+1, lebarHalaman-12, pdf.GetY()+1)
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "", 10)
+	for _, b := range [][2]string{
+		{"Nama", p.NamaMahasiswa},
+		{"NIM / Username", p.Username},
+		{"Mata Kuliah", p.MataKuliah},
+		{"Fasilitas", p.NamaFasilitas},
+		{"Ruangan", p.NamaRuangan},
+		{"Pembimbing Akademik", p.NamaPembimbing},
+		{"Pembimbing Klinik", p.NamaPembimbingKlinik},
+		{"Periode", periodeEkspor(p.TglMulai.Time, p.TglSelesai.Time)},
+	} {
+		pdf.CellFormat(45, 6, tr(b[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, tr(": "+b[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// 📋 Satu baris per hari, diikuti daftar SKP yang telah disetujui
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 7, "Catatan Harian", "", 1, "L", false, 0, "")
+	kepala()
+	presensi := map[string]int{}
+	var totalMenit int64
+	for i, k := range kehadiran {
+		presensi[k.Presensi]++
+		durasi := 0.0
+		if k.DurasiMenit != nil {
+			totalMenit += int64(*k.DurasiMenit)
+			durasi = jamDinas(int64(*k.DurasiMenit))
+		}
+		shift := "-"
+		if k.JadwalDinas != nil {
+			shift = *k.JadwalDinas
+		}
+		masuk := "-"
+		if k.Presensi == "hadir" {
+			masuk = jamLogbook(k.JamMasuk, "15:04")
+		}
+
+		cukup(6)
+		for j, v := range []string{
+			fmt.Sprint(i + 1), k.TglKehadiran.Time.Format("2006-01-02"), shift, k.NamaRuangan,
+			k.Presensi, masuk, jamLogbook(k.JamPulang, "15:04"), fmt.Sprintf("%.2f", durasi),
+		} {
+			align := "C"
+			if j == 3 {
+				align = "L"
+			}
+			pdf.CellFormat(lebarLogbook[j], 6, tr(v), "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+
+		for _, s := range skp[k.ID] {
+			teks := s.NamaIntervensi
+			if s.NamaLevel != nil {
+				teks += " (" + *s.NamaLevel + ")"
+			}
+			teks += " - disetujui " + s.NamaPenyetuju + ", " + jamLogbook(s.ReviewedAt, "2006-01-02 15:04")
+			baris := pdf.SplitLines([]byte(tr(teks)), lebarIsi-lebarLogbook[0]-2)
+			cukup(float64(len(baris)) * 5)
+			pdf.SetX(12 + lebarLogbook[0])
+			pdf.SetFont("Arial", "I", 8)
+			pdf.MultiCell(lebarIsi-lebarLogbook[0], 5, tr(teks), "LRB", "L", false)
+			pdf.SetFont("Arial", "", 8)
+		}
+	}
+	if len(kehadiran) == 0 {
+		pdf.CellFormat(lebarIsi, 6, "Tidak ada kehadiran pada periode ini", "1", 1, "C", false, 0, "")
+	}
+
+	// 🧮 Ringkasan
+	if pdf.GetY()+50 > tinggiHalaman-15 {
+		pdf.AddPage()
+	}
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 7, "Ringkasan", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	for _, b := range [][2]string{
+		{"Hari tercatat", fmt.Sprint(len(kehadiran))},
+		{"Hadir", fmt.Sprint(presensi["hadir"])},
+		{"Izin", fmt.Sprint(presensi["izin"])},
+		{"Sakit", fmt.Sprint(presensi["sakit"])},
+		{"Alpa", fmt.Sprint(presensi["alpa"])},
+		{"Total jam dinas", fmt.Sprintf("%.2f", jamDinas(totalMenit))},
+		{"SKP disetujui", fmt.Sprint(totalSkp)},
+	} {
+		pdf.CellFormat(60, 6, tr(b[0]), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 6, tr(b[1]), "1", 1, "C", false, 0, "")
+	}
+
+	// ✍️ Tanda tangan mahasiswa, pembimbing klinik, dan pembimbing akademik
+	if pdf.GetY()+45 > tinggiHalaman-15 {
+		pdf.AddPage()
+	}
+	pdf.Ln(8)
+	tempat := tanggalIndonesia(p.TglSelesai.Time)
+	if mu.cfg.Ekspor.Kota != "" {
+		tempat = mu.cfg.Ekspor.Kota + ", " + tempat
+	}
+	lebarTtd := lebarIsi / 3
+	for _, ttd := range [][3]string{
+		{"", "", tempat},
+		{"Mahasiswa", "Pembimbing Klinik", "Pembimbing Akademik"},
+		{"", "", ""},
+		{"", "", ""},
+		{"", "", ""},
+		{"( " + p.NamaMahasiswa + " )", "( " + p.NamaPembimbingKlinik + " )", "( " + p.NamaPembimbing + " )"},
+	} {
+		for i, v := range ttd {
+			ln := 0
+			if i == len(ttd)-1 {
+				ln = 1
+			}
+			pdf.CellFormat(lebarTtd, 6, tr(v), "", ln, "C", false, 0, "")
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create pdf")
+	}
+	if err := pdf.Output(buka()); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write pdf")
+	}
+	return nil
+}
+
+// jamLogbook memformat waktu dalam zona Asia/Jakarta agar tidak bergantung pada zona server.
+func jamLogbook(t pgtype.Timestamptz, layout string) string {
+	if !t.Valid {
+		return "-"
+	}
+	if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+		return t.Time.In(loc).Format(layout)
+	}
+	return t.Time.UTC().Format(layout)
+}
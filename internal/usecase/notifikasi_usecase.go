@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Jenis notifikasi yang dikirim aplikasi.
+const (
+	notifikasiSkpDibuka       = "skp_dibuka"
+	notifikasiDelegasiDibuat  = "delegasi_dibuat"
+	notifikasiDelegasiDicabut = "delegasi_dicabut"
+)
+
+type NotifikasiUsecase interface {
+	ListNotifikasi(c context.Context, arg request.SearchNotifikasi) (any, error)
+	TandaiDibaca(c context.Context, userID uuid.UUID, id *uuid.UUID) (any, error)
+}
+
+type NotifikasiUsecaseImpl struct {
+	worker *worker.ProducerService
+	db     *pg.Queries
+	pg     *pkg.Postgres
+	cache  *pkg.RedisCache
+}
+
+func NewNotifikasiUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *NotifikasiUsecaseImpl {
+	return &NotifikasiUsecaseImpl{
+		db:     pg.New(postgre.Pool),
+		pg:     postgre,
+		worker: worker,
+		cache:  cache,
+	}
+}
+
+// ListNotifikasi hanya menampilkan notifikasi milik user yang sedang login.
+func (mu *NotifikasiUsecaseImpl) ListNotifikasi(c context.Context, arg request.SearchNotifikasi) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	res, err := mu.db.ListNotifikasi(c, pg.ListNotifikasiParams{
+		UserID:      arg.UserID,
+		BelumDibaca: arg.BelumDibaca,
+		Limit:       arg.Limit,
+		Offset:      arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get notifikasi")
+	}
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountNotifikasi(c, pg.CountNotifikasiParams{
+		UserID:      arg.UserID,
+		BelumDibaca: arg.BelumDibaca,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count notifikasi")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+// TandaiDibaca menandai satu notifikasi, atau semuanya bila id kosong, sebagai sudah dibaca.
+func (mu *NotifikasiUsecaseImpl) TandaiDibaca(c context.Context, userID uuid.UUID, id *uuid.UUID) (any, error) {
+	n, err := mu.db.TandaiNotifikasiDibaca(c, pg.TandaiNotifikasiDibacaParams{
+		UserID: userID,
+		ID:     id,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update notifikasi")
+	}
+	return map[string]int64{"dibaca": n}, nil
+}
+
+// kirimNotifikasi menyimpan notifikasi yang sama untuk beberapa penerima sekaligus.
+// Dipanggil dengan qtx agar notifikasi ikut batal bila transaksinya gagal.
+func kirimNotifikasi(c context.Context, qtx *pg.Queries, penerima []uuid.UUID, jenis, judul, pesan string, refID *uuid.UUID, oleh *string) error {
+	if len(penerima) == 0 {
+		return nil
+	}
+	if _, err := qtx.CreateNotifikasi(c, pg.CreateNotifikasiParams{
+		Jenis:     jenis,
+		Judul:     judul,
+		Pesan:     pesan,
+		RefID:     refID,
+		CreatedBy: oleh,
+		UserIds:   penerima,
+	}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create notifikasi")
+	}
+	return nil
+}
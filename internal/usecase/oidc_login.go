@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/oauth2"
+)
+
+// ttlSesiOidc adalah batas waktu pengguna menyelesaikan login di identity provider.
+const ttlSesiOidc = 10 * time.Minute
+
+// sesiOidc disimpan di Redis dengan state sebagai kunci selama alur authorization code
+// berlangsung, dan dihapus begitu callback diterima agar state hanya terpakai sekali.
+type sesiOidc struct {
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+type klaimOidc struct {
+	Email         string `json:"email"`
+	EmailVerified *bool  `json:"email_verified"`
+	Name          string `json:"name"`
+	// Tautan adalah nilai klaim OIDC.KlaimTautan; preferred_username tidak dipakai karena dapat
+	// diubah sendiri oleh pengguna di banyak identity provider.
+	Tautan string `json:"-"`
+}
+
+// identitasOidc mengembalikan username dan email yang boleh dipakai untuk menautkan akun.
+// Email tanpa email_verified dianggap belum terverifikasi.
+func identitasOidc(klaim klaimOidc) (username *string, email *string) {
+	if klaim.Tautan != "" {
+		username = &klaim.Tautan
+	}
+	if klaim.Email != "" && klaim.EmailVerified != nil && *klaim.EmailVerified {
+		email = &klaim.Email
+	}
+	return username, email
+}
+
+// cocokStateOidc membandingkan state dari callback dengan state pada cookie browser yang
+// memulai login, sehingga callback dari browser lain (login CSRF) ditolak.
+func cocokStateOidc(state, cookie string) bool {
+	return state != "" && subtle.ConstantTimeCompare([]byte(state), []byte(cookie)) == 1
+}
+
+func kunciSesiOidc(state string) string {
+	return "oidc:state:" + state
+}
+
+// klienOidc menyiapkan provider OIDC saat pertama kali dipakai, sehingga aplikasi tetap dapat
+// berjalan ketika SSO tidak dikonfigurasi atau issuer sedang tidak terjangkau.
+func (uu *UserUsecaseImpl) klienOidc(c context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	if uu.cfg.Oidc.IssuerUrl == "" || uu.cfg.Oidc.ClientId == "" {
+		return nil, nil, pkg.ExposeError(pkg.ErrorCodeBadRequest, "SSO belum dikonfigurasi")
+	}
+
+	uu.oidcMu.Lock()
+	defer uu.oidcMu.Unlock()
+	if uu.oidcProvider == nil {
+		ctx, cancel := context.WithTimeout(c, 10*time.Second)
+		defer cancel()
+		provider, err := oidc.NewProvider(ctx, uu.cfg.Oidc.IssuerUrl)
+		if err != nil {
+			return nil, nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed discover oidc provider")
+		}
+		uu.oidcProvider = provider
+	}
+	return uu.oidcProvider, pkg.NewOidcConfig(uu.cfg, uu.oidcProvider), nil
+}
+
+// OidcLoginURL memulai alur authorization code dengan PKCE. Nonce dan code verifier disimpan
+// sementara dengan state sebagai kunci; state juga dikembalikan agar handler mengikatnya ke
+// browser lewat cookie.
+func (uu *UserUsecaseImpl) OidcLoginURL(c context.Context) (string, string, error) {
+	_, conf, err := uu.klienOidc(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	state := oauth2.GenerateVerifier()
+	sesi := sesiOidc{
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+	}
+	if !uu.cache.SetWithTTL(c, kunciSesiOidc(state), sesi, ttlSesiOidc) {
+		return "", "", pkg.ExposeError(pkg.ErrorCodeInternal, "failed simpan sesi sso")
+	}
+
+	return urlLoginOidc(conf, state, sesi), state, nil
+}
+
+func urlLoginOidc(conf *oauth2.Config, state string, sesi sesiOidc) string {
+	return conf.AuthCodeURL(state, oidc.Nonce(sesi.Nonce), oauth2.S256ChallengeOption(sesi.Verifier))
+}
+
+// LoginOidc menyelesaikan callback SSO: mencocokkan state dengan cookie browser, menukar code,
+// memverifikasi ID token beserta nonce, menautkan identitas ke pengguna, lalu menerbitkan token
+// seperti LoginWithPassword.
+func (uu *UserUsecaseImpl) LoginOidc(c context.Context, state string, stateCookie string, code string, perangkat request.Perangkat) (resp.User, error) {
+	if state == "" || code == "" {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "state dan code wajib diisi")
+	}
+	if !cocokStateOidc(state, stateCookie) {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "state tidak cocok dengan sesi browser")
+	}
+	provider, conf, err := uu.klienOidc(c)
+	if err != nil {
+		return resp.User{}, err
+	}
+
+	var sesi sesiOidc
+	if !uu.cache.Get(c, kunciSesiOidc(state), &sesi) {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "state tidak valid atau kedaluwarsa")
+	}
+	uu.cache.Delete(c, kunciSesiOidc(state))
+
+	idToken, klaim, err := tukarKodeOidc(c, provider, conf, sesi, code, uu.cfg.Oidc.KlaimTautan)
+	if err != nil {
+		return resp.User{}, err
+	}
+
+	username, err := uu.tautkanUserOidc(c, idToken.Issuer, idToken.Subject, klaim)
+	if err != nil {
+		return resp.User{}, err
+	}
+
+	res, err := uu.db.UsersFindByUsername(c, username)
+	if err != nil {
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed login")
+	}
+	if res.LockedUntil.Valid && res.LockedUntil.Time.After(time.Now()) {
+		return resp.User{}, errAkunTerkunci(res.LockedUntil.Time)
+	}
+
+	return uu.terbitkanToken(c, res, perangkat)
+}
+
+// tukarKodeOidc menukar authorization code dengan code verifier PKCE, memverifikasi ID token
+// dan nonce-nya, lalu membaca klaim termasuk klaim tautan bila dikonfigurasi.
+func tukarKodeOidc(c context.Context, provider *oidc.Provider, conf *oauth2.Config, sesi sesiOidc, code string, klaimTautan string) (*oidc.IDToken, klaimOidc, error) {
+	token, err := conf.Exchange(c, code, oauth2.VerifierOption(sesi.Verifier))
+	if err != nil {
+		return nil, klaimOidc{}, pkg.WrapError(err, pkg.ErrorCodeUnauthorized, "failed exchange code")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, klaimOidc{}, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "id_token tidak ditemukan")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: conf.ClientID}).Verify(c, rawIDToken)
+	if err != nil {
+		return nil, klaimOidc{}, pkg.WrapError(err, pkg.ErrorCodeUnauthorized, "id_token tidak valid")
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(sesi.Nonce)) != 1 {
+		return nil, klaimOidc{}, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "nonce tidak valid")
+	}
+
+	var klaim klaimOidc
+	if err := idToken.Claims(&klaim); err != nil {
+		return nil, klaimOidc{}, pkg.WrapError(err, pkg.ErrorCodeUnauthorized, "failed baca klaim id_token")
+	}
+	if klaimTautan != "" {
+		semua := map[string]any{}
+		if err := idToken.Claims(&semua); err != nil {
+			return nil, klaimOidc{}, pkg.WrapError(err, pkg.ErrorCodeUnauthorized, "failed baca klaim id_token")
+		}
+		klaim.Tautan, _ = semua[klaimTautan].(string)
+	}
+	return idToken, klaim, nil
+}
+
+// tautkanUserOidc mencari pengguna untuk identitas SSO: tautan yang sudah ada, lalu klaim tautan
+// atau email terverifikasi, dan terakhir pembuatan akun bila AutoProvision aktif.
+func (uu *UserUsecaseImpl) tautkanUserOidc(c context.Context, issuer, subject string, klaim klaimOidc) (string, error) {
+	username, email := identitasOidc(klaim)
+
+	tertaut, err := uu.db.GetUserOidc(c, pg.GetUserOidcParams{Issuer: issuer, Subject: subject})
+	if err == nil {
+		if err := uu.db.TautkanUserOidc(c, pg.TautkanUserOidcParams{
+			UserID:  tertaut.ID,
+			Issuer:  issuer,
+			Subject: subject,
+			Email:   email,
+		}); err != nil {
+			return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed tautkan identitas sso")
+		}
+		return tertaut.Username, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get identitas sso")
+	}
+
+	if username == nil && email == nil {
+		return "", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "identitas SSO tidak memiliki email terverifikasi")
+	}
+
+	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (string, error) {
+		user, err := qtx.CariUserOidc(c, pg.CariUserOidcParams{Username: username, Email: email})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cari user sso")
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			if !uu.cfg.Oidc.AutoProvision {
+				return "", pkg.ExposeError(pkg.ErrorCodeUnauthorized, "akun SSO belum terdaftar")
+			}
+			baruUsername := utils.DerefString(username)
+			if baruUsername == "" {
+				baruUsername = *email
+			}
+			nama := klaim.Name
+			if nama == "" {
+				nama = baruUsername
+			}
+			baru, err := qtx.CreateUserSso(c, pg.CreateUserSsoParams{
+				Nama:      nama,
+				Username:  baruUsername,
+				Email:     email,
+				CreatedBy: utils.StringPtr("sso"),
+			})
+			if err != nil {
+				return "", pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed register sso")
+			}
+			user = pg.CariUserOidcRow{ID: baru.ID, Username: baru.Username}
+
+			if role := uu.cfg.Oidc.DefaultRole; role != "" {
+				if err := qtx.CreateUserRole(c, pg.CreateUserRoleParams{
+					UserID:    user.ID,
+					RoleID:    utils.StrToInt32(role),
+					CreatedBy: utils.StringPtr("sso"),
+				}); err != nil {
+					return "", pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed add role sso")
+				}
+				if _, err := uu.cbn.AddRoleForUser(user.ID.String(), role, "", "", "", ""); err != nil {
+					return "", pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed add casbin role")
+				}
+			}
+		}
+
+		if err := qtx.TautkanUserOidc(c, pg.TautkanUserOidcParams{
+			UserID:  user.ID,
+			Issuer:  issuer,
+			Subject: subject,
+			Email:   email,
+		}); err != nil {
+			return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed tautkan identitas sso")
+		}
+		return user.Username, nil
+	})
+}
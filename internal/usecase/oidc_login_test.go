@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"e-klinik/config"
+	"e-klinik/pkg"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// issuerOidcTiruan adalah identity provider minimal: discovery, JWKS, dan token endpoint yang
+// memeriksa code_verifier terhadap code_challenge dari URL login.
+type issuerOidcTiruan struct {
+	srv       *httptest.Server
+	kunci     *rsa.PrivateKey
+	challenge string
+	nonce     string
+	klaim     jwt.MapClaims
+}
+
+func buatIssuerOidcTiruan(t *testing.T) *issuerOidcTiruan {
+	t.Helper()
+	kunci, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := &issuerOidcTiruan{kunci: kunci}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                is.srv.URL,
+			"authorization_endpoint":                is.srv.URL + "/auth",
+			"token_endpoint":                        is.srv.URL + "/token",
+			"jwks_uri":                              is.srv.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		b64 := base64.RawURLEncoding.EncodeToString
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA", "alg": "RS256", "use": "sig", "kid": "k1",
+			"n": b64(kunci.N.Bytes()),
+			"e": b64(big.NewInt(int64(kunci.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "kode-valid" || base64.RawURLEncoding.EncodeToString(sum[:]) != is.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		klaim := jwt.MapClaims{
+			"iss":   is.srv.URL,
+			"sub":   "subjek-1",
+			"aud":   "e-klinik",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": is.nonce,
+		}
+		for k, v := range is.klaim {
+			klaim[k] = v
+		}
+		tok := jwt.NewWithClaims(jwt.SigningMethodRS256, klaim)
+		tok.Header["kid"] = "k1"
+		idToken, err := tok.SignedString(kunci)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "akses",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+	is.srv = httptest.NewServer(mux)
+	t.Cleanup(is.srv.Close)
+	return is
+}
+
+func TestTukarKodeOidc(t *testing.T) {
+	is := buatIssuerOidcTiruan(t)
+	ctx := context.Background()
+	provider, err := oidc.NewProvider(ctx, is.srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := pkg.NewOidcConfig(&config.Config{Oidc: config.OIDC{
+		ClientId:     "e-klinik",
+		ClientSecret: "rahasia",
+		RedirectUrl:  "http://localhost/api/v1/web/auth/oidc/callback",
+	}}, provider)
+
+	tests := []struct {
+		nama      string
+		kode      string
+		verifier  string
+		nonce     string
+		klaim     jwt.MapClaims
+		wantErr   bool
+		wantEmail bool
+	}{
+		{nama: "valid", kode: "kode-valid", klaim: jwt.MapClaims{"email": "a@kampus.ac.id", "email_verified": true, "nim": "2101"}, wantEmail: true},
+		{nama: "email tanpa email_verified", kode: "kode-valid", klaim: jwt.MapClaims{"email": "a@kampus.ac.id", "nim": "2101"}},
+		{nama: "email belum terverifikasi", kode: "kode-valid", klaim: jwt.MapClaims{"email": "a@kampus.ac.id", "email_verified": false}},
+		{nama: "code verifier salah", kode: "kode-valid", verifier: oauth2.GenerateVerifier(), wantErr: true},
+		{nama: "nonce tidak cocok", kode: "kode-valid", nonce: "nonce-lain", wantErr: true},
+		{nama: "code tidak valid", kode: "kode-palsu", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			state := oauth2.GenerateVerifier()
+			sesi := sesiOidc{Verifier: oauth2.GenerateVerifier(), Nonce: oauth2.GenerateVerifier()}
+
+			u, err := url.Parse(urlLoginOidc(conf, state, sesi))
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := u.Query()
+			if q.Get("state") != state || q.Get("nonce") != sesi.Nonce || q.Get("code_challenge_method") != "S256" {
+				t.Fatalf("url login tidak lengkap: %s", u)
+			}
+
+			is.challenge = q.Get("code_challenge")
+			is.nonce = sesi.Nonce
+			if tt.nonce != "" {
+				is.nonce = tt.nonce
+			}
+			is.klaim = tt.klaim
+			if tt.verifier != "" {
+				sesi.Verifier = tt.verifier
+			}
+
+			idToken, klaim, err := tukarKodeOidc(ctx, provider, conf, sesi, tt.kode, "nim")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tukarKodeOidc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if idToken.Subject != "subjek-1" {
+				t.Errorf("subject = %q", idToken.Subject)
+			}
+			if want, _ := tt.klaim["nim"].(string); klaim.Tautan != want {
+				t.Errorf("tautan = %q, want %q", klaim.Tautan, want)
+			}
+			if _, email := identitasOidc(klaim); (email != nil) != tt.wantEmail {
+				t.Errorf("email dipakai untuk tautan = %v, want %v", email != nil, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestCocokStateOidc(t *testing.T) {
+	tests := []struct {
+		nama   string
+		state  string
+		cookie string
+		want   bool
+	}{
+		{"sama", "abc", "abc", true},
+		{"berbeda", "abc", "abd", false},
+		{"tanpa cookie", "abc", "", false},
+		{"keduanya kosong", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			if got := cocokStateOidc(tt.state, tt.cookie); got != tt.want {
+				t.Errorf("cocokStateOidc(%q, %q) = %v, want %v", tt.state, tt.cookie, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matthewhartstonge/argon2"
+)
+
+// validasiPassword memeriksa password baru terhadap kebijakan PasswordConfig dan menyebutkan
+// semua aturan yang belum terpenuhi sekaligus.
+func validasiPassword(cfg config.PasswordConfig, password string) error {
+	var kurang []string
+	if n := len([]rune(password)); n < cfg.MinLength {
+		kurang = append(kurang, fmt.Sprintf("minimal %d karakter", cfg.MinLength))
+	} else if cfg.MaxLength > 0 && n > cfg.MaxLength {
+		kurang = append(kurang, fmt.Sprintf("maksimal %d karakter", cfg.MaxLength))
+	}
+
+	var besar, kecil, angka, simbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			besar = true
+		case unicode.IsLower(r):
+			kecil = true
+		case unicode.IsDigit(r):
+			angka = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			simbol = true
+		}
+	}
+	if cfg.IncludeUppercase && !besar {
+		kurang = append(kurang, "mengandung huruf besar")
+	}
+	if cfg.IncludeLowercase && !kecil {
+		kurang = append(kurang, "mengandung huruf kecil")
+	}
+	if cfg.IncludeDigits && !angka {
+		kurang = append(kurang, "mengandung angka")
+	}
+	if cfg.IncludeChars && !simbol {
+		kurang = append(kurang, "mengandung simbol")
+	}
+
+	if len(kurang) > 0 {
+		return pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "password harus "+strings.Join(kurang, ", "))
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	config := argon2.DefaultConfig()
+	encoded, err := config.HashEncoded([]byte(password))
+	if err != nil {
+		return "", pkg.WrapError(err, pkg.ErrorCodeInternal, "failed hash password")
+	}
+	return string(encoded), nil
+}
+
+// cekRiwayatPassword menolak password yang sama dengan password saat ini atau salah satu dari
+// PasswordConfig.Riwayat password terakhir.
+func (uu *UserUsecaseImpl) cekRiwayatPassword(c context.Context, qtx *pg.Queries, userID uuid.UUID, password string) error {
+	if uu.cfg.Password.Riwayat <= 0 {
+		return nil
+	}
+
+	lama, err := qtx.ListRiwayatPassword(c, pg.ListRiwayatPasswordParams{
+		UserID: userID,
+		Limit:  int32(uu.cfg.Password.Riwayat),
+	})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get riwayat password")
+	}
+	if sekarang, err := qtx.GetPasswordUser(c, userID); err == nil && sekarang != "" {
+		lama = append(lama, sekarang)
+	}
+
+	for _, hash := range lama {
+		if ok, _ := argon2.VerifyEncoded([]byte(password), []byte(hash)); ok {
+			return pkg.ExposeError(pkg.ErrorCodeInvalidArgument,
+				fmt.Sprintf("password tidak boleh sama dengan %d password terakhir", uu.cfg.Password.Riwayat))
+		}
+	}
+	return nil
+}
+
+// simpanPassword mengganti password user setelah lolos cek riwayat dan mencatatnya ke riwayat.
+// wajibGanti menandai password sementara yang harus diganti user saat login berikutnya.
+func (uu *UserUsecaseImpl) simpanPassword(c context.Context, qtx *pg.Queries, userID uuid.UUID, password string, wajibGanti bool, oleh *string) error {
+	if err := uu.cekRiwayatPassword(c, qtx, userID, password); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	n, err := qtx.GantiPasswordUser(c, pg.GantiPasswordUserParams{
+		Password:           hash,
+		WajibGantiPassword: wajibGanti,
+		UpdatedBy:          oleh,
+		ID:                 userID,
+	})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update password")
+	}
+	if n == 0 {
+		return pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+	}
+
+	if err := qtx.CreateRiwayatPassword(c, pg.CreateRiwayatPasswordParams{UserID: userID, Password: hash}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed simpan riwayat password")
+	}
+	return nil
+}
+
+// GantiPassword mengganti password user setelah password lama diverifikasi. Semua sesi lain
+// dicabut dan token baru diterbitkan untuk perangkat yang sedang dipakai.
+func (uu *UserUsecaseImpl) GantiPassword(c context.Context, userID uuid.UUID, arg request.GantiPassword, perangkat request.Perangkat) (resp.User, error) {
+	if err := validasiPassword(uu.cfg.Password, arg.PasswordBaru); err != nil {
+		return resp.User{}, err
+	}
+
+	hash, err := uu.db.GetPasswordUser(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp.User{}, pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+		}
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+	}
+	if hash == "" {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "password lama salah")
+	}
+	ok, err := argon2.VerifyEncoded([]byte(arg.PasswordLama), []byte(hash))
+	if err != nil || !ok {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "password lama salah")
+	}
+
+	if _, err := utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		if err := uu.simpanPassword(c, qtx, userID, arg.PasswordBaru, false, arg.UpdatedBy); err != nil {
+			return nil, err
+		}
+		return uu.cabutSemuaSesi(c, qtx, userID, "password diganti")
+	}); err != nil {
+		return resp.User{}, err
+	}
+
+	user, err := uu.db.GetUserByID(c, userID)
+	if err != nil {
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+	}
+	res, err := uu.db.UsersFindByUsername(c, user.Username)
+	if err != nil {
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+	}
+	return uu.terbitkanToken(c, res, perangkat)
+}
+
+// BuatResetPassword dipakai admin untuk menerbitkan token reset password sekali pakai. Token
+// hanya ditampilkan sekali di respons ini; token reset sebelumnya yang belum dipakai dibatalkan.
+func (uu *UserUsecaseImpl) BuatResetPassword(c context.Context, id uuid.UUID, oleh *string) (any, error) {
+	token, err := utils.GenerateSecureKey(32)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed generate token")
+	}
+
+	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		if _, err := qtx.GetUserByID(c, id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+		}
+
+		if err := qtx.BatalkanResetPassword(c, id); err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed batalkan reset password")
+		}
+		res, err := qtx.CreateResetPassword(c, pg.CreateResetPasswordParams{
+			UserID:    id,
+			TokenHash: hashToken(token),
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Duration(uu.cfg.Password.ResetTokenMenit) * time.Minute), Valid: true},
+			CreatedBy: oleh,
+		})
+		if err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create reset password")
+		}
+
+		return map[string]any{
+			"user_id":    id,
+			"token":      token,
+			"expires_at": res.ExpiresAt,
+		}, nil
+	})
+}
+
+// ResetPassword menukar token reset dengan password baru. Token hangus setelah dipakai, akun
+// yang terkunci dibuka, dan semua sesi user dicabut.
+func (uu *UserUsecaseImpl) ResetPassword(c context.Context, arg request.ResetPassword) (any, error) {
+	if arg.Token == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "token wajib diisi")
+	}
+	if err := validasiPassword(uu.cfg.Password, arg.PasswordBaru); err != nil {
+		return nil, err
+	}
+
+	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		userID, err := qtx.PakaiResetPassword(c, hashToken(arg.Token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "token reset tidak valid atau kedaluwarsa")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get reset password")
+		}
+
+		if err := uu.simpanPassword(c, qtx, userID, arg.PasswordBaru, false, nil); err != nil {
+			return nil, err
+		}
+		if _, err := uu.cabutSemuaSesi(c, qtx, userID, "password direset"); err != nil {
+			return nil, err
+		}
+		return map[string]any{"user_id": userID}, nil
+	})
+}
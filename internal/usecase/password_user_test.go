@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"e-klinik/config"
+	"e-klinik/pkg"
+	"strings"
+	"testing"
+)
+
+func TestValidasiPassword(t *testing.T) {
+	cfg := config.PasswordConfig{
+		MinLength:        8,
+		MaxLength:        16,
+		IncludeDigits:    true,
+		IncludeUppercase: true,
+		IncludeLowercase: true,
+	}
+	simbol := cfg
+	simbol.IncludeChars = true
+
+	tests := []struct {
+		name     string
+		cfg      config.PasswordConfig
+		password string
+		kurang   []string
+	}{
+		{"memenuhi kebijakan", cfg, "Rahasia123", nil},
+		{"terlalu pendek", cfg, "Ra1", []string{"minimal 8 karakter"}},
+		{"terlalu panjang", cfg, "Rahasia123456789012", []string{"maksimal 16 karakter"}},
+		{"tanpa huruf besar", cfg, "rahasia123", []string{"mengandung huruf besar"}},
+		{"tanpa huruf kecil", cfg, "RAHASIA123", []string{"mengandung huruf kecil"}},
+		{"tanpa angka", cfg, "RahasiaSekali", []string{"mengandung angka"}},
+		{"tanpa simbol", simbol, "Rahasia123", []string{"mengandung simbol"}},
+		{"dengan simbol", simbol, "Rahasia123!", nil},
+		{"semua aturan dilaporkan", cfg, "abc", []string{"minimal 8 karakter", "mengandung huruf besar", "mengandung angka"}},
+		{"panjang dihitung per karakter", cfg, "Rähäsiä1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validasiPassword(tt.cfg, tt.password)
+			if len(tt.kurang) == 0 {
+				if err != nil {
+					t.Fatalf("validasiPassword(%q) = %v, want nil", tt.password, err)
+				}
+				return
+			}
+			appErr, ok := pkg.AsAppError(err)
+			if !ok || appErr.Code != pkg.ErrorCodeInvalidArgument {
+				t.Fatalf("validasiPassword(%q) = %v, want InvalidArgument", tt.password, err)
+			}
+			if want := "password harus " + strings.Join(tt.kurang, ", "); appErr.Message != want {
+				t.Errorf("validasiPassword(%q) = %q, want %q", tt.password, appErr.Message, want)
+			}
+		})
+	}
+}
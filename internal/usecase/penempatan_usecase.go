@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PenempatanUsecase interface {
+	AddPenempatan(c context.Context, arg request.CreatePenempatan) (any, error)
+	ListPenempatan(c context.Context, arg request.SearchPenempatan) (any, error)
+	UpdatePenempatan(c context.Context, arg request.UpdatePenempatan) (any, error)
+	DeletePenempatan(c context.Context, arg pg.DeletePenempatanParams) error
+}
+
+type PenempatanUsecaseImpl struct {
+	worker *worker.ProducerService
+	db     *pg.Queries
+	pg     *pkg.Postgres
+	cache  *pkg.RedisCache
+}
+
+func NewPenempatanUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *PenempatanUsecaseImpl {
+	return &PenempatanUsecaseImpl{
+		db:     pg.New(postgre.Pool),
+		pg:     postgre,
+		worker: worker,
+		cache:  cache,
+	}
+}
+
+// penempatanValid adalah hasil validasi penempatan yang siap disimpan.
+type penempatanValid struct {
+	FasilitasID uuid.UUID
+	TglMulai    pgtype.Date
+	TglSelesai  pgtype.Date
+}
+
+func (mu *PenempatanUsecaseImpl) AddPenempatan(c context.Context, arg request.CreatePenempatan) (any, error) {
+	if arg.UserID == uuid.Nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "user_id wajib diisi")
+	}
+
+	v, err := mu.validasiPenempatan(c, arg.UserID, nil, arg.KontrakID, arg.RuanganID, arg.PembimbingKlinik, arg.TglMulai, arg.TglSelesai)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := mu.db.CreatePenempatan(c, pg.CreatePenempatanParams{
+		UserID:           arg.UserID,
+		FasilitasID:      v.FasilitasID,
+		KontrakID:        arg.KontrakID,
+		RuanganID:        arg.RuanganID,
+		MataKuliahID:     arg.MataKuliahID,
+		PembimbingID:     arg.PembimbingID,
+		PembimbingKlinik: arg.PembimbingKlinik,
+		TglMulai:         v.TglMulai,
+		TglSelesai:       v.TglSelesai,
+		CreatedBy:        arg.CreatedBy,
+	})
+	if err != nil {
+		return nil, errPenempatan(err, "failed create penempatan")
+	}
+	return res, nil
+}
+
+func (mu *PenempatanUsecaseImpl) ListPenempatan(c context.Context, arg request.SearchPenempatan) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	var cparams pg.CountPenempatanParams
+	if arg.UserID != "" {
+		id := uuid.FromStringOrNil(arg.UserID)
+		cparams.UserID = &id
+	}
+	if arg.KontrakID != "" {
+		id := uuid.FromStringOrNil(arg.KontrakID)
+		cparams.KontrakID = &id
+	}
+	if arg.RuanganID != "" {
+		id := uuid.FromStringOrNil(arg.RuanganID)
+		cparams.RuanganID = &id
+	}
+	if arg.PembimbingID != "" {
+		id := uuid.FromStringOrNil(arg.PembimbingID)
+		cparams.PembimbingID = &id
+	}
+	if arg.Tgl != "" {
+		tgl, err := time.Parse("2006-01-02", arg.Tgl)
+		if err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl harus YYYY-MM-DD")
+		}
+		cparams.Tgl = pgtype.Date{Valid: true, Time: tgl}
+	}
+	cparams.PemanggilID = arg.PemanggilID
+
+	res, err := mu.db.ListPenempatan(c, pg.ListPenempatanParams{
+		UserID:       cparams.UserID,
+		KontrakID:    cparams.KontrakID,
+		RuanganID:    cparams.RuanganID,
+		PembimbingID: cparams.PembimbingID,
+		Tgl:          cparams.Tgl,
+		PemanggilID:  cparams.PemanggilID,
+		Limit:        arg.Limit,
+		Offset:       arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get penempatan list")
+	}
+
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountPenempatan(c, cparams)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to count penempatan")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+func (mu *PenempatanUsecaseImpl) UpdatePenempatan(c context.Context, arg request.UpdatePenempatan) (any, error) {
+	lama, err := mu.db.GetPenempatan(c, arg.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "penempatan tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get penempatan")
+	}
+
+	isActive := lama.IsActive
+	if arg.IsActive != nil {
+		isActive = *arg.IsActive
+	}
+
+	v, err := mu.validasiPenempatan(c, lama.UserID, &lama.ID, arg.KontrakID, arg.RuanganID, arg.PembimbingKlinik, arg.TglMulai, arg.TglSelesai)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := mu.db.UpdatePenempatan(c, pg.UpdatePenempatanParams{
+		FasilitasID:      v.FasilitasID,
+		KontrakID:        arg.KontrakID,
+		RuanganID:        arg.RuanganID,
+		MataKuliahID:     arg.MataKuliahID,
+		PembimbingID:     arg.PembimbingID,
+		PembimbingKlinik: arg.PembimbingKlinik,
+		TglMulai:         v.TglMulai,
+		TglSelesai:       v.TglSelesai,
+		IsActive:         isActive,
+		UpdatedBy:        arg.UpdatedBy,
+		UpdatedNote:      arg.UpdatedNote,
+		ID:               arg.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "penempatan tidak ditemukan")
+		}
+		return nil, errPenempatan(err, "failed update penempatan")
+	}
+	return res, nil
+}
+
+func (mu *PenempatanUsecaseImpl) DeletePenempatan(c context.Context, arg pg.DeletePenempatanParams) error {
+	n, err := mu.db.DeletePenempatan(c, arg)
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed delete penempatan")
+	}
+	if n == 0 {
+		return pkg.ExposeError(pkg.ErrorCodeNotFound, "penempatan tidak ditemukan")
+	}
+	return nil
+}
+
+// validasiPenempatan memastikan rentang tanggal berada di dalam periode kontrak, ruangan
+// milik kontrak tersebut, pembimbing klinik terdaftar pada kontrak, dan tidak ada
+// penempatan lain milik mahasiswa yang sama pada rentang tanggal itu.
+func (mu *PenempatanUsecaseImpl) validasiPenempatan(c context.Context, userID uuid.UUID, kecualiID *uuid.UUID, kontrakID, ruanganID, pembimbingKlinik uuid.UUID, mulai, selesai string) (*penempatanValid, error) {
+	tglMulai, err := time.Parse("2006-01-02", mulai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_mulai harus YYYY-MM-DD")
+	}
+	tglSelesai, err := time.Parse("2006-01-02", selesai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_selesai harus YYYY-MM-DD")
+	}
+	if tglSelesai.Before(tglMulai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_selesai tidak boleh sebelum tgl_mulai")
+	}
+
+	kontrak, err := mu.db.GetKontrakByID(c, kontrakID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kontrak tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kontrak")
+	}
+	if !kontrak.PeriodeMulai.Valid || !kontrak.PeriodeSelesai.Valid {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "periode kontrak belum diatur")
+	}
+	periodeMulai := truncHari(kontrak.PeriodeMulai.Time)
+	periodeSelesai := truncHari(kontrak.PeriodeSelesai.Time)
+	if tglMulai.Before(periodeMulai) || tglSelesai.After(periodeSelesai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "penempatan harus berada dalam periode kontrak "+
+			periodeMulai.Format("2006-01-02")+" s.d. "+periodeSelesai.Format("2006-01-02"))
+	}
+
+	ruangan, err := mu.db.GetRuangan(c, ruanganID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "ruangan tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get ruangan")
+	}
+	if ruangan.KontrakID != kontrak.ID {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "ruangan bukan bagian dari kontrak")
+	}
+
+	ok, err := mu.db.CekPembimbingKlinikRuangan(c, pg.CekPembimbingKlinikRuanganParams{
+		RuanganID: ruanganID,
+		UserID:    pembimbingKlinik,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check pembimbing klinik")
+	}
+	if !ok {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "pembimbing klinik tidak terdaftar pada kontrak")
+	}
+
+	v := &penempatanValid{
+		FasilitasID: kontrak.FasilitasID,
+		TglMulai:    pgtype.Date{Valid: true, Time: tglMulai},
+		TglSelesai:  pgtype.Date{Valid: true, Time: tglSelesai},
+	}
+
+	bertumpuk, err := mu.db.CekPenempatanBertumpuk(c, pg.CekPenempatanBertumpukParams{
+		UserID:     userID,
+		KecualiID:  kecualiID,
+		TglSelesai: v.TglSelesai,
+		TglMulai:   v.TglMulai,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check penempatan")
+	}
+	if bertumpuk {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "mahasiswa sudah memiliki penempatan pada rentang tanggal tersebut")
+	}
+
+	return v, nil
+}
+
+func errPenempatan(err error, msg string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return pkg.ExposeError(pkg.ErrorCodeNotFound, "mahasiswa, mata kuliah atau pembimbing tidak ditemukan")
+	}
+	return pkg.WrapError(err, pkg.ErrorCodeInternal, msg)
+}
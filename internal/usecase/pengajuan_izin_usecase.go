@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/config"
+	"e-klinik/infra/pg"
+	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+)
+
+// Status pengajuan izin.
+const (
+	statusIzinMenunggu  = "menunggu"
+	statusIzinDisetujui = "disetujui"
+	statusIzinDitolak   = "ditolak"
+)
+
+// maksHariPengajuanIzin membatasi panjang satu pengajuan izin/sakit.
+const maksHariPengajuanIzin = 31
+
+type PengajuanIzinUsecase interface {
+	AjukanIzin(c context.Context, arg request.CreatePengajuanIzin, berkas *multipart.FileHeader) (any, error)
+	ListPengajuanIzin(c context.Context, arg request.SearchPengajuanIzin) (any, error)
+	ReviewPengajuanIzin(c context.Context, arg request.ReviewPengajuanIzin) (any, error)
+	GetLampiranIzin(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error)
+}
+
+type PengajuanIzinUsecaseImpl struct {
+	worker *worker.ProducerService
+	db     *pg.Queries
+	pg     *pkg.Postgres
+	cfg    *config.Config
+	cache  *pkg.RedisCache
+	s3     *minio.Client
+}
+
+func NewPengajuanIzinUsecase(postgre *pkg.Postgres, cfg *config.Config, worker *worker.ProducerService, cache *pkg.RedisCache, s3 *minio.Client) *PengajuanIzinUsecaseImpl {
+	return &PengajuanIzinUsecaseImpl{
+		db:     pg.New(postgre.Pool),
+		pg:     postgre,
+		cfg:    cfg,
+		worker: worker,
+		cache:  cache,
+		s3:     s3,
+	}
+}
+
+func (mu *PengajuanIzinUsecaseImpl) AjukanIzin(c context.Context, arg request.CreatePengajuanIzin, berkas *multipart.FileHeader) (any, error) {
+	jenis := strings.ToLower(strings.TrimSpace(arg.Jenis))
+	if jenis != "izin" && jenis != "sakit" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "jenis harus izin atau sakit")
+	}
+	if strings.TrimSpace(arg.Alasan) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "alasan wajib diisi")
+	}
+	if jenis == "sakit" && berkas == nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "pengajuan sakit wajib melampirkan surat keterangan")
+	}
+
+	params := pg.CreatePengajuanIzinParams{
+		UserID:    arg.UserID,
+		Jenis:     jenis,
+		Alasan:    strings.TrimSpace(arg.Alasan),
+		CreatedBy: arg.CreatedBy,
+	}
+
+	tglMulai, err := time.Parse("2006-01-02", arg.TglMulai)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_mulai harus YYYY-MM-DD")
+	}
+	tglSelesai := tglMulai
+	if arg.TglSelesai != "" {
+		if tglSelesai, err = time.Parse("2006-01-02", arg.TglSelesai); err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format tgl_selesai harus YYYY-MM-DD")
+		}
+	}
+	if tglSelesai.Before(tglMulai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "tgl_selesai tidak boleh sebelum tgl_mulai")
+	}
+	if tglSelesai.Sub(tglMulai) >= maksHariPengajuanIzin*24*time.Hour {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "pengajuan izin maksimal 31 hari")
+	}
+	params.TglMulai = pgtype.Date{Valid: true, Time: tglMulai}
+	params.TglSelesai = pgtype.Date{Valid: true, Time: tglSelesai}
+
+	// 🏥 Reviewer & konteks klinik diambil dari penempatan aktif yang mencakup seluruh rentang,
+	// bukan dari klien, agar mahasiswa tidak bisa memilih sendiri pembimbing yang menyetujui
+	penempatan, err := mu.db.GetPenempatanAktif(c, pg.GetPenempatanAktifParams{
+		UserID: arg.UserID,
+		Tgl:    params.TglMulai,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeBadRequest, "tidak ada penempatan aktif pada tgl_mulai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get penempatan aktif")
+	}
+	if penempatan.TglSelesai.Time.Before(tglSelesai) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeBadRequest, "rentang izin melewati akhir penempatan, ajukan terpisah per penempatan")
+	}
+	params.FasilitasID = penempatan.FasilitasID
+	params.KontrakID = penempatan.KontrakID
+	params.RuanganID = penempatan.RuanganID
+	params.MataKuliahID = penempatan.MataKuliahID
+	params.PembimbingID = penempatan.PembimbingID
+	params.PembimbingKlinik = penempatan.PembimbingKlinik
+
+	bertumpuk, err := mu.db.CekPengajuanIzinBertumpuk(c, pg.CekPengajuanIzinBertumpukParams{
+		UserID:     arg.UserID,
+		TglMulai:   params.TglMulai,
+		TglSelesai: params.TglSelesai,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check pengajuan izin")
+	}
+	if bertumpuk {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "sudah ada pengajuan izin pada rentang tanggal tersebut")
+	}
+
+	// 📎 Unggah lampiran ke object storage
+	if berkas != nil {
+		l, err := simpanLampiran(c, mu.s3, mu.cfg.Minio.Bucket1, "izin/"+arg.UserID.String(), berkas)
+		if err != nil {
+			return nil, err
+		}
+		params.LampiranKey = &l.Key
+		params.LampiranNama = &l.Nama
+		params.LampiranTipe = &l.Tipe
+	}
+
+	res, err := mu.db.CreatePengajuanIzin(c, params)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create pengajuan izin")
+	}
+	return res, nil
+}
+
+func (mu *PengajuanIzinUsecaseImpl) ListPengajuanIzin(c context.Context, arg request.SearchPengajuanIzin) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	var cparams pg.CountPengajuanIzinParams
+	if arg.UserID != "" {
+		id := uuid.FromStringOrNil(arg.UserID)
+		cparams.UserID = &id
+	}
+	if arg.ReviewerID != "" {
+		id := uuid.FromStringOrNil(arg.ReviewerID)
+		cparams.ReviewerID = &id
+	}
+	if arg.Status != "" {
+		cparams.Status = &arg.Status
+	}
+	cparams.PemanggilID = arg.PemanggilID
+
+	res, err := mu.db.ListPengajuanIzin(c, pg.ListPengajuanIzinParams{
+		UserID:      cparams.UserID,
+		ReviewerID:  cparams.ReviewerID,
+		Status:      cparams.Status,
+		PemanggilID: cparams.PemanggilID,
+		Limit:       arg.Limit,
+		Offset:      arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get pengajuan izin list")
+	}
+
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountPengajuanIzin(c, cparams)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to count pengajuan izin")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+// ReviewPengajuanIzin menyetujui atau menolak pengajuan oleh pembimbing yang ditunjuk.
+// Persetujuan langsung membuat record kehadiran izin/sakit untuk setiap tanggal dalam
+// rentang, menggantikan record alpa yang mungkin sudah dibuat job.
+func (mu *PengajuanIzinUsecaseImpl) ReviewPengajuanIzin(c context.Context, arg request.ReviewPengajuanIzin) (any, error) {
+	status := strings.ToLower(strings.TrimSpace(arg.Status))
+	if status != statusIzinDisetujui && status != statusIzinDitolak {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "status harus disetujui atau ditolak")
+	}
+	if status == statusIzinDitolak && (arg.CatatanReview == nil || strings.TrimSpace(*arg.CatatanReview) == "") {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "catatan_review wajib diisi saat menolak pengajuan")
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		izin, err := qtx.GetPengajuanIzin(c, arg.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "pengajuan izin tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get pengajuan izin")
+		}
+		if izin.PembimbingID != arg.ReviewerID && izin.PembimbingKlinik != arg.ReviewerID {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pembimbing pada pengajuan ini")
+		}
+		if izin.Status != statusIzinMenunggu {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "pengajuan izin sudah "+izin.Status)
+		}
+
+		res, err := qtx.ReviewPengajuanIzin(c, pg.ReviewPengajuanIzinParams{
+			Status:        status,
+			CatatanReview: arg.CatatanReview,
+			ReviewedBy:    arg.ReviewedBy,
+			ID:            arg.ID,
+			ReviewerID:    arg.ReviewerID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "pengajuan izin sudah direview")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed review pengajuan izin")
+		}
+
+		if status == statusIzinDisetujui {
+			if _, err := qtx.CreateKehadiranDariIzin(c, res.ID); err != nil {
+				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create kehadiran izin")
+			}
+		}
+
+		return res, nil
+	})
+}
+
+// GetLampiranIzin mengembalikan URL unduh sementara untuk pemilik pengajuan atau pembimbingnya.
+func (mu *PengajuanIzinUsecaseImpl) GetLampiranIzin(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error) {
+	izin, err := mu.db.GetPengajuanIzin(c, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "pengajuan izin tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get pengajuan izin")
+	}
+	if userID != izin.UserID && userID != izin.PembimbingID && userID != izin.PembimbingKlinik {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda tidak berhak melihat lampiran ini")
+	}
+	if izin.LampiranKey == nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "pengajuan izin tidak memiliki lampiran")
+	}
+
+	url, err := urlLampiran(c, mu.s3, mu.cfg.Minio.Bucket1, *izin.LampiranKey, utils.DerefString(izin.LampiranNama))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"url": url}, nil
+}
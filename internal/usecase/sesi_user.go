@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/entity"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// tokenSesi adalah pasangan token yang diterbitkan untuk satu sesi perangkat.
+type tokenSesi struct {
+	access     string
+	accessExp  int64
+	refresh    string
+	refreshExp int64
+}
+
+// hashToken dipakai agar database hanya menyimpan hash, bukan token (refresh atau reset) yang bisa dipakai.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// perangkatSesi mengubah identitas perangkat menjadi kolom sesi; nilai kosong atau IP yang
+// tidak valid disimpan sebagai NULL.
+func perangkatSesi(p request.Perangkat) (*string, *netip.Addr) {
+	var userAgent *string
+	if p.UserAgent != "" {
+		userAgent = &p.UserAgent
+	}
+	var ip *netip.Addr
+	if addr, err := netip.ParseAddr(p.IpAddress); err == nil {
+		ip = &addr
+	}
+	return userAgent, ip
+}
+
+// buatToken menerbitkan access dan refresh token untuk sesi user.Session, sekaligus menyiapkan
+// cache menu dan sesi yang dibaca middleware.
+func (uu *UserUsecaseImpl) buatToken(c context.Context, user entity.User) (tokenSesi, error) {
+	var t tokenSesi
+	var err error
+
+	t.access, t.accessExp, err = pkg.CreateAccessToken(
+		user,
+		uu.cfg.JWT.AccessTokenSecret,
+		uu.cfg.JWT.AccessTokenExpireHour)
+	if err != nil {
+		return tokenSesi{}, err
+	}
+	t.refresh, t.refreshExp, err = pkg.CreateRefreshToken(
+		user,
+		uu.cfg.JWT.RefreshTokenSecret,
+		uu.cfg.JWT.RefreshTokenExpireHour)
+	if err != nil {
+		return tokenSesi{}, err
+	}
+
+	id := uuid.FromStringOrNil(user.ID)
+	view, err := uu.db.UserViewPermission(c, id)
+	if err != nil {
+		return tokenSesi{}, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed get menu")
+	}
+
+	redisKey := fmt.Sprintf("view:%d", id)
+
+	uu.cache.SetWithTTL(c, redisKey, view, time.Duration(uu.cfg.JWT.AccessTokenExpireHour)*time.Minute)
+
+	// sesi di Redis berumur sedikit lebih lama dari access token agar token yang masih sah tidak ditolak
+	if !uu.sesi.Simpan(c, user.Session, time.Until(time.Unix(t.accessExp, 0))+time.Minute) {
+		return tokenSesi{}, pkg.ExposeError(pkg.ErrorCodeInternal, "failed simpan sesi")
+	}
+
+	return t, nil
+}
+
+// cabutSesiBocor mencabut sesi ketika refresh token lama dipakai ulang. Pemakaian ulang berarti
+// token telah disalin pihak lain, sehingga seluruh rantai token pada sesi itu tidak lagi dipercaya.
+func (uu *UserUsecaseImpl) cabutSesiBocor(c context.Context, id uuid.UUID) error {
+	if _, err := uu.db.CabutUserSesi(c, pg.CabutUserSesiParams{
+		RevokedReason: utils.StringPtr("refresh token dipakai ulang"),
+		ID:            id,
+	}); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cabut sesi")
+	}
+	uu.sesi.Cabut(c, id.String())
+	return pkg.ExposeError(pkg.ErrorCodeUnauthorized, "refresh token sudah pernah dipakai, sesi dicabut")
+}
+
+// ListSesi menampilkan sesi aktif milik pengguna; sesi yang sedang dipakai ditandai saat_ini.
+func (uu *UserUsecaseImpl) ListSesi(c context.Context, userID uuid.UUID, sesiSaatIni string) (any, error) {
+	res, err := uu.db.ListUserSesiAktif(c, userID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get sesi")
+	}
+
+	sesi := make([]resp.SesiUser, 0, len(res))
+	for _, s := range res {
+		sesi = append(sesi, resp.SesiUser{
+			ListUserSesiAktifRow: s,
+			SaatIni:              s.ID.String() == sesiSaatIni,
+		})
+	}
+	return sesi, nil
+}
+
+// CabutSesi mencabut satu sesi milik pengguna, misalnya perangkat yang hilang.
+func (uu *UserUsecaseImpl) CabutSesi(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error) {
+	n, err := uu.db.CabutUserSesi(c, pg.CabutUserSesiParams{
+		RevokedReason: utils.StringPtr("dicabut pengguna"),
+		ID:            id,
+		UserID:        &userID,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cabut sesi")
+	}
+	if n == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "sesi tidak ditemukan")
+	}
+	uu.sesi.Cabut(c, id.String())
+	return map[string]any{"id": id}, nil
+}
+
+// cabutSemuaSesi mencabut seluruh sesi aktif user, lalu menghapusnya dari cache sesi sehingga
+// access token yang masih berlaku ikut ditolak middleware.
+func (uu *UserUsecaseImpl) cabutSemuaSesi(c context.Context, qtx *pg.Queries, userID uuid.UUID, alasan string) ([]uuid.UUID, error) {
+	ids, err := qtx.CabutSemuaUserSesi(c, pg.CabutSemuaUserSesiParams{
+		RevokedReason: &alasan,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cabut sesi")
+	}
+	for _, id := range ids {
+		uu.sesi.Cabut(c, id.String())
+	}
+	return ids, nil
+}
+
+// PaksaLogoutUser dipakai admin untuk mengeluarkan user dari semua perangkat sekaligus.
+func (uu *UserUsecaseImpl) PaksaLogoutUser(c context.Context, id uuid.UUID, oleh *string) (any, error) {
+	if _, err := uu.db.GetUserByID(c, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+	}
+
+	alasan := "dipaksa logout"
+	if oleh != nil {
+		alasan += " oleh " + *oleh
+	}
+	ids, err := uu.cabutSemuaSesi(c, uu.db, id, alasan)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"id": id, "sesi_dicabut": len(ids)}, nil
+}
+
+// cekAdminTerakhir menolak penonaktifan admin aktif terakhir, karena tanpa admin tidak ada
+// yang dapat mengaktifkan user kembali.
+func (uu *UserUsecaseImpl) cekAdminTerakhir(c context.Context, qtx *pg.Queries, id uuid.UUID) error {
+	terakhir, err := qtx.AdminAktifTerakhir(c, pg.AdminAktifTerakhirParams{ID: id, Tag: uu.cfg.Auth.RoleAdmin})
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed cek admin")
+	}
+	if terakhir {
+		return pkg.ExposeError(pkg.ErrorCodeConflict, "admin aktif terakhir tidak dapat dinonaktifkan")
+	}
+	return nil
+}
+
+// NonaktifkanUser menonaktifkan user dan mencabut semua sesinya; user tidak dapat login lagi
+// sampai diaktifkan kembali lewat update user.
+func (uu *UserUsecaseImpl) NonaktifkanUser(c context.Context, id uuid.UUID, oleh *string) (any, error) {
+	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		if _, err := qtx.GetUserByID(c, id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "user not found")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get user")
+		}
+		if err := uu.cekAdminTerakhir(c, qtx, id); err != nil {
+			return nil, err
+		}
+
+		if err := qtx.UpdateUserPartial(c, pg.UpdateUserPartialParams{
+			ID:          id,
+			IsActive:    utils.ToPtr(false),
+			UpdatedNote: utils.StringPtr("dinonaktifkan"),
+			UpdatedBy:   oleh,
+		}); err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed update user")
+		}
+
+		ids, err := uu.cabutSemuaSesi(c, qtx, id, "user dinonaktifkan")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id, "is_active": false, "sesi_dicabut": len(ids)}, nil
+	})
+}
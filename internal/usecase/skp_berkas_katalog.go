@@ -0,0 +1,616 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	formatKatalogJson = "json"
+	formatKatalogCsv  = "csv"
+	tipeJson          = "application/json"
+	tipeCsv           = "text/csv"
+)
+
+// kolomKatalogSkp adalah header CSV katalog SKP: satu baris per intervensi.
+var kolomKatalogSkp = []string{"kategori_kode", "kategori", "subkategori_kode", "subkategori", "intervensi_kode", "intervensi"}
+
+// BerkasKatalogSkp adalah bentuk JSON katalog SKP untuk ekspor maupun impor.
+type BerkasKatalogSkp struct {
+	Kategori []BerkasKategoriSkp `json:"kategori"`
+}
+
+type BerkasKategoriSkp struct {
+	Kode        string                 `json:"kode,omitempty"`
+	Nama        string                 `json:"nama"`
+	Subkategori []BerkasSubkategoriSkp `json:"subkategori"`
+}
+
+type BerkasSubkategoriSkp struct {
+	Kode       string                `json:"kode,omitempty"`
+	Nama       string                `json:"nama"`
+	Intervensi []BerkasIntervensiSkp `json:"intervensi"`
+}
+
+type BerkasIntervensiSkp struct {
+	Kode string `json:"kode,omitempty"`
+	Nama string `json:"nama"`
+}
+
+// PerubahanKatalogSkp adalah satu baris diff hasil impor katalog.
+type PerubahanKatalogSkp struct {
+	Level     string `json:"level"`
+	Kode      string `json:"kode,omitempty"`
+	Nama      string `json:"nama"`
+	NamaLama  string `json:"nama_lama,omitempty"`
+	Induk     string `json:"induk,omitempty"`
+	IndukLama string `json:"induk_lama,omitempty"`
+}
+
+type HasilImportKatalogSkp struct {
+	DryRun        bool                  `json:"dry_run"`
+	Ditambah      []PerubahanKatalogSkp `json:"ditambah"`
+	DiubahNama    []PerubahanKatalogSkp `json:"diubah_nama"`
+	Dipindah      []PerubahanKatalogSkp `json:"dipindah"`
+	Diaktifkan    []PerubahanKatalogSkp `json:"diaktifkan"`
+	Dinonaktifkan []PerubahanKatalogSkp `json:"dinonaktifkan"`
+	Galat         []string              `json:"galat"`
+}
+
+// EksporKatalogSkp menulis seluruh katalog SKP yang aktif sebagai JSON bertingkat atau CSV datar.
+// Hasilnya dapat diimpor kembali lewat ImportKatalogSkp.
+func (mu *SkpUsecaseImpl) EksporKatalogSkp(c context.Context, format string, tulis func(nama, tipe string) io.Writer) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = formatKatalogJson
+	}
+	if format != formatKatalogJson && format != formatKatalogCsv {
+		return pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format harus json atau csv")
+	}
+
+	// Ambil semua baris lalu saring di sini, agar kategori yang hanya memiliki
+	// subkategori nonaktif tetap ikut terekspor.
+	rows, err := mu.db.ListKatalogSkp(c, nil)
+	if err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get katalog skp")
+	}
+	data := susunBerkasKatalogSkp(rows)
+
+	if format == formatKatalogCsv {
+		cw := csv.NewWriter(tulis("katalog-skp.csv", tipeCsv))
+		if err := cw.Write(kolomKatalogSkp); err != nil {
+			return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write csv")
+		}
+		for _, k := range data.Kategori {
+			if len(k.Subkategori) == 0 {
+				_ = cw.Write([]string{k.Kode, k.Nama, "", "", "", ""})
+			}
+			for _, s := range k.Subkategori {
+				if len(s.Intervensi) == 0 {
+					_ = cw.Write([]string{k.Kode, k.Nama, s.Kode, s.Nama, "", ""})
+				}
+				for _, i := range s.Intervensi {
+					_ = cw.Write([]string{k.Kode, k.Nama, s.Kode, s.Nama, i.Kode, i.Nama})
+				}
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write csv")
+		}
+		return nil
+	}
+
+	enc := json.NewEncoder(tulis("katalog-skp.json", tipeJson))
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed write json")
+	}
+	return nil
+}
+
+func susunBerkasKatalogSkp(rows []pg.ListKatalogSkpRow) BerkasKatalogSkp {
+	data := BerkasKatalogSkp{Kategori: []BerkasKategoriSkp{}}
+	posKat := map[uuid.UUID]int{}
+	posSub := map[uuid.UUID]int{}
+	for _, r := range rows {
+		if !r.KategoriAktif {
+			continue
+		}
+		ki, ok := posKat[r.KategoriID]
+		if !ok {
+			ki = len(data.Kategori)
+			posKat[r.KategoriID] = ki
+			data.Kategori = append(data.Kategori, BerkasKategoriSkp{
+				Kode:        utils.DerefString(r.KategoriKode),
+				Nama:        r.KategoriNama,
+				Subkategori: []BerkasSubkategoriSkp{},
+			})
+		}
+		if r.SubkategoriID == nil || !utils.DerefBool(r.SubkategoriAktif) {
+			continue
+		}
+		k := &data.Kategori[ki]
+		si, ok := posSub[*r.SubkategoriID]
+		if !ok {
+			si = len(k.Subkategori)
+			posSub[*r.SubkategoriID] = si
+			k.Subkategori = append(k.Subkategori, BerkasSubkategoriSkp{
+				Kode:       utils.DerefString(r.SubkategoriKode),
+				Nama:       utils.DerefString(r.SubkategoriNama),
+				Intervensi: []BerkasIntervensiSkp{},
+			})
+		}
+		if r.IntervensiID == nil || !utils.DerefBool(r.IntervensiAktif) {
+			continue
+		}
+		s := &k.Subkategori[si]
+		s.Intervensi = append(s.Intervensi, BerkasIntervensiSkp{
+			Kode: utils.DerefString(r.IntervensiKode),
+			Nama: utils.DerefString(r.IntervensiNama),
+		})
+	}
+	return data
+}
+
+// ImportKatalogSkp mencocokkan berkas katalog (JSON, CSV atau XLSX) dengan katalog yang ada:
+// item dicocokkan lewat kode, atau lewat nama di bawah induk yang sama bila kode kosong.
+// Item baru ditambahkan, nama yang berbeda diganti, item aktif yang tidak ada di berkas
+// dinonaktifkan. Intervensi yang sudah dipakai kehadiran_skp diganti lewat versi baru
+// sehingga referensi lama tetap utuh. Dengan dry_run=true hanya diff yang dikembalikan.
+func (mu *SkpUsecaseImpl) ImportKatalogSkp(c context.Context, arg request.ImportKatalogSkp, berkas *multipart.FileHeader) (any, error) {
+	data, err := bacaBerkasKatalogSkp(berkas)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Kategori) == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "berkas tidak berisi kategori")
+	}
+
+	if arg.DryRun {
+		return imporKatalogSkp(c, mu.db, data, true, arg.UpdatedBy)
+	}
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		hasil, err := imporKatalogSkp(c, qtx, data, false, arg.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+		if len(hasil.Galat) > 0 {
+			galat := hasil.Galat
+			if len(galat) > 5 {
+				galat = append(galat[:5:5], fmt.Sprintf("dan %d galat lainnya", len(hasil.Galat)-5))
+			}
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "katalog tidak valid: "+strings.Join(galat, "; "))
+		}
+		return hasil, nil
+	})
+}
+
+// bacaBerkasKatalogSkp membaca berkas .json sebagai BerkasKatalogSkp, selain itu sebagai
+// tabel CSV/XLSX dengan kolom kolomKatalogSkp. Urutan item mengikuti kemunculan pertama.
+func bacaBerkasKatalogSkp(fh *multipart.FileHeader) (BerkasKatalogSkp, error) {
+	var data BerkasKatalogSkp
+	if strings.ToLower(filepath.Ext(fh.Filename)) == ".json" {
+		if fh.Size > maksUkuranTabelImport {
+			return data, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "ukuran berkas maksimal 5 MB")
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return data, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed open berkas")
+		}
+		defer f.Close()
+		if err := json.NewDecoder(f).Decode(&data); err != nil {
+			return data, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format JSON tidak valid: "+err.Error())
+		}
+		return data, nil
+	}
+
+	rows, err := bacaTabelImport(fh)
+	if err != nil {
+		return data, err
+	}
+	idx, err := indeksKolom(rows[0], "kategori", "subkategori", "intervensi")
+	if err != nil {
+		return data, err
+	}
+
+	posKat := map[string]int{}
+	posSub := map[string]int{}
+	for n, row := range rows[1:] {
+		baris := n + 2
+		kat := BerkasKategoriSkp{Kode: nilaiKolom(row, idx, "kategori_kode"), Nama: nilaiKolom(row, idx, "kategori")}
+		sub := BerkasSubkategoriSkp{Kode: nilaiKolom(row, idx, "subkategori_kode"), Nama: nilaiKolom(row, idx, "subkategori")}
+		inv := BerkasIntervensiSkp{Kode: nilaiKolom(row, idx, "intervensi_kode"), Nama: nilaiKolom(row, idx, "intervensi")}
+		if kat.Nama == "" && sub.Nama == "" && inv.Nama == "" {
+			continue
+		}
+		if kat.Nama == "" {
+			return data, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, fmt.Sprintf("baris %d: kategori wajib diisi", baris))
+		}
+		if inv.Nama != "" && sub.Nama == "" {
+			return data, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, fmt.Sprintf("baris %d: subkategori wajib diisi untuk intervensi", baris))
+		}
+
+		kunciKat := kunciBerkasSkp(kat.Kode, kat.Nama)
+		ki, ok := posKat[kunciKat]
+		if !ok {
+			ki = len(data.Kategori)
+			posKat[kunciKat] = ki
+			data.Kategori = append(data.Kategori, kat)
+		}
+		if sub.Nama == "" {
+			continue
+		}
+		k := &data.Kategori[ki]
+		kunciSub := kunciKat + "/" + kunciBerkasSkp(sub.Kode, sub.Nama)
+		si, ok := posSub[kunciSub]
+		if !ok {
+			si = len(k.Subkategori)
+			posSub[kunciSub] = si
+			k.Subkategori = append(k.Subkategori, sub)
+		}
+		if inv.Nama != "" {
+			k.Subkategori[si].Intervensi = append(k.Subkategori[si].Intervensi, inv)
+		}
+	}
+	return data, nil
+}
+
+func kunciBerkasSkp(kode, nama string) string {
+	if kode != "" {
+		return "kode:" + kode
+	}
+	return "nama:" + strings.ToLower(nama)
+}
+
+// itemKatalogSkp menyeragamkan kategori, subkategori dan intervensi untuk pencocokan.
+type itemKatalogSkp struct {
+	id    uuid.UUID
+	induk uuid.UUID
+	kode  *string
+	nama  string
+	aktif bool
+}
+
+type pencocokKatalogSkp struct {
+	item    []itemKatalogSkp
+	kode    map[string]int
+	dipilih map[uuid.UUID]bool
+}
+
+func newPencocokKatalogSkp(item []itemKatalogSkp) *pencocokKatalogSkp {
+	p := &pencocokKatalogSkp{item: item, kode: map[string]int{}, dipilih: map[uuid.UUID]bool{}}
+	for i, it := range item {
+		if it.kode == nil {
+			continue
+		}
+		// kode nonaktif boleh dipakai ulang; utamakan yang aktif
+		if j, ok := p.kode[*it.kode]; !ok || (!item[j].aktif && it.aktif) {
+			p.kode[*it.kode] = i
+		}
+	}
+	return p
+}
+
+// cari mencocokkan lewat kode lebih dulu, lalu lewat nama di bawah induk yang sama
+// untuk item yang belum berkode atau berkode sama. Item aktif diutamakan.
+func (p *pencocokKatalogSkp) cari(kode, nama string, induk uuid.UUID) *itemKatalogSkp {
+	if kode != "" {
+		if i, ok := p.kode[kode]; ok && !p.dipilih[p.item[i].id] {
+			return &p.item[i]
+		}
+	}
+	var hasil *itemKatalogSkp
+	for i := range p.item {
+		it := &p.item[i]
+		if p.dipilih[it.id] || it.induk != induk || !strings.EqualFold(it.nama, nama) {
+			continue
+		}
+		if kode != "" && it.kode != nil && *it.kode != kode {
+			continue
+		}
+		if hasil == nil || (!hasil.aktif && it.aktif) {
+			hasil = it
+		}
+	}
+	return hasil
+}
+
+// urutanImporSkp mengumpulkan urutan item lama agar disimpan sekali per level.
+type urutanImporSkp struct {
+	ids    []uuid.UUID
+	urutan []int32
+}
+
+func (u *urutanImporSkp) tambah(id uuid.UUID, urutan int32) {
+	u.ids = append(u.ids, id)
+	u.urutan = append(u.urutan, urutan)
+}
+
+func (p *pencocokKatalogSkp) sisaAktif() []itemKatalogSkp {
+	var sisa []itemKatalogSkp
+	for _, it := range p.item {
+		if it.aktif && !p.dipilih[it.id] {
+			sisa = append(sisa, it)
+		}
+	}
+	return sisa
+}
+
+// imporKatalogSkp menghitung diff dan, bila dryRun=false, langsung menerapkannya lewat q.
+// Item baru pada mode dry-run tidak memiliki ID sehingga anaknya dicocokkan dengan uuid.Nil.
+func imporKatalogSkp(c context.Context, q *pg.Queries, data BerkasKatalogSkp, dryRun bool, oleh *string) (*HasilImportKatalogSkp, error) {
+	hasil := &HasilImportKatalogSkp{
+		DryRun:        dryRun,
+		Ditambah:      []PerubahanKatalogSkp{},
+		DiubahNama:    []PerubahanKatalogSkp{},
+		Dipindah:      []PerubahanKatalogSkp{},
+		Diaktifkan:    []PerubahanKatalogSkp{},
+		Dinonaktifkan: []PerubahanKatalogSkp{},
+		Galat:         []string{},
+	}
+
+	kategori, err := q.ListSemuaSkpKategori(c)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp kategori")
+	}
+	subkategori, err := q.ListSemuaSkpSubkategori(c)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp subkategori")
+	}
+	intervensi, err := q.ListSkpIntervensiTerbaru(c)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp intervensi")
+	}
+
+	itemKat := make([]itemKatalogSkp, 0, len(kategori))
+	for _, k := range kategori {
+		itemKat = append(itemKat, itemKatalogSkp{id: k.ID, kode: k.Kode, nama: k.Nama, aktif: k.IsActive})
+	}
+	namaSub := make(map[uuid.UUID]string, len(subkategori))
+	itemSub := make([]itemKatalogSkp, 0, len(subkategori))
+	for _, s := range subkategori {
+		namaSub[s.ID] = s.Nama
+		itemSub = append(itemSub, itemKatalogSkp{id: s.ID, induk: s.KategoriID, kode: s.Kode, nama: s.Nama, aktif: s.IsActive})
+	}
+	dataInv := make(map[uuid.UUID]pg.SkpIntervensi, len(intervensi))
+	itemInv := make([]itemKatalogSkp, 0, len(intervensi))
+	for _, i := range intervensi {
+		dataInv[i.ID] = i
+		itemInv = append(itemInv, itemKatalogSkp{id: i.ID, induk: i.SubkategoriID, kode: i.Kode, nama: i.Nama, aktif: i.IsActive})
+	}
+	pk, ps, pi := newPencocokKatalogSkp(itemKat), newPencocokKatalogSkp(itemSub), newPencocokKatalogSkp(itemInv)
+
+	// 🔍 Kode wajib unik per level dan nama wajib unik di bawah induk yang sama
+	kodeDipakai := map[string]bool{}
+	cekGanda := func(level, kode, nama, induk string, namaDipakai map[string]bool) bool {
+		ganda := false
+		if kode != "" {
+			if kodeDipakai[level+":"+kode] {
+				hasil.Galat = append(hasil.Galat, fmt.Sprintf("kode %s %q muncul lebih dari sekali", level, kode))
+				ganda = true
+			}
+			kodeDipakai[level+":"+kode] = true
+		}
+		if namaDipakai[strings.ToLower(nama)] {
+			hasil.Galat = append(hasil.Galat, fmt.Sprintf("%s %q muncul lebih dari sekali di %s", level, nama, induk))
+			ganda = true
+		}
+		namaDipakai[strings.ToLower(nama)] = true
+		return ganda
+	}
+
+	var urutKat, urutSub, urutInv urutanImporSkp
+	namaKat := map[string]bool{}
+	for ki, fk := range data.Kategori {
+		fk.Kode, fk.Nama = strings.TrimSpace(fk.Kode), strings.TrimSpace(fk.Nama)
+		if fk.Nama == "" {
+			hasil.Galat = append(hasil.Galat, fmt.Sprintf("kategori ke-%d: nama wajib diisi", ki+1))
+			continue
+		}
+		if cekGanda(levelSkpKategori, fk.Kode, fk.Nama, "katalog", namaKat) {
+			continue
+		}
+
+		var katID uuid.UUID
+		if m := pk.cari(fk.Kode, fk.Nama, uuid.Nil); m == nil {
+			hasil.Ditambah = append(hasil.Ditambah, PerubahanKatalogSkp{Level: levelSkpKategori, Kode: fk.Kode, Nama: fk.Nama})
+			if !dryRun {
+				res, err := q.CreateSkpKategori(c, pg.CreateSkpKategoriParams{Kode: kodeSkp(&fk.Kode), Nama: fk.Nama, Urutan: int32(ki + 1), CreatedBy: oleh})
+				if err != nil {
+					return nil, errImporKatalogSkp(err, levelSkpKategori, fk.Nama)
+				}
+				katID = res.ID
+			}
+		} else {
+			pk.dipilih[m.id] = true
+			katID = m.id
+			params := pg.UpdateSkpKategoriParams{ID: m.id, UpdatedBy: oleh}
+			if ubahItemKatalogSkp(hasil, levelSkpKategori, m, fk.Kode, fk.Nama, "", &params.Kode, &params.Nama, &params.IsActive) && !dryRun {
+				if _, err := q.UpdateSkpKategori(c, params); err != nil {
+					return nil, errImporKatalogSkp(err, levelSkpKategori, fk.Nama)
+				}
+			}
+			urutKat.tambah(m.id, int32(ki+1))
+		}
+
+		namaSubDipakai := map[string]bool{}
+		for si, fs := range fk.Subkategori {
+			fs.Kode, fs.Nama = strings.TrimSpace(fs.Kode), strings.TrimSpace(fs.Nama)
+			if fs.Nama == "" {
+				hasil.Galat = append(hasil.Galat, fmt.Sprintf("subkategori ke-%d di %q: nama wajib diisi", si+1, fk.Nama))
+				continue
+			}
+			if cekGanda(levelSkpSubkategori, fs.Kode, fs.Nama, fk.Nama, namaSubDipakai) {
+				continue
+			}
+
+			var subID uuid.UUID
+			m := ps.cari(fs.Kode, fs.Nama, katID)
+			if m != nil && m.induk != katID {
+				hasil.Galat = append(hasil.Galat, fmt.Sprintf("subkategori %q sudah terdaftar di kategori lain", fs.Kode))
+				continue
+			}
+			if m == nil {
+				hasil.Ditambah = append(hasil.Ditambah, PerubahanKatalogSkp{Level: levelSkpSubkategori, Kode: fs.Kode, Nama: fs.Nama, Induk: fk.Nama})
+				if !dryRun {
+					res, err := q.CreateSkpSubkategori(c, pg.CreateSkpSubkategoriParams{KategoriID: katID, Kode: kodeSkp(&fs.Kode), Nama: fs.Nama, Urutan: int32(si + 1), CreatedBy: oleh})
+					if err != nil {
+						return nil, errImporKatalogSkp(err, levelSkpSubkategori, fs.Nama)
+					}
+					subID = res.ID
+				}
+			} else {
+				ps.dipilih[m.id] = true
+				subID = m.id
+				params := pg.UpdateSkpSubkategoriParams{ID: m.id, UpdatedBy: oleh}
+				if ubahItemKatalogSkp(hasil, levelSkpSubkategori, m, fs.Kode, fs.Nama, fk.Nama, &params.Kode, &params.Nama, &params.IsActive) && !dryRun {
+					if _, err := q.UpdateSkpSubkategori(c, params); err != nil {
+						return nil, errImporKatalogSkp(err, levelSkpSubkategori, fs.Nama)
+					}
+				}
+				urutSub.tambah(m.id, int32(si+1))
+			}
+
+			namaInvDipakai := map[string]bool{}
+			for ii, fi := range fs.Intervensi {
+				fi.Kode, fi.Nama = strings.TrimSpace(fi.Kode), strings.TrimSpace(fi.Nama)
+				if fi.Nama == "" {
+					hasil.Galat = append(hasil.Galat, fmt.Sprintf("intervensi ke-%d di %q: nama wajib diisi", ii+1, fs.Nama))
+					continue
+				}
+				if cekGanda(levelSkpIntervensi, fi.Kode, fi.Nama, fs.Nama, namaInvDipakai) {
+					continue
+				}
+
+				m := pi.cari(fi.Kode, fi.Nama, subID)
+				if m == nil {
+					hasil.Ditambah = append(hasil.Ditambah, PerubahanKatalogSkp{Level: levelSkpIntervensi, Kode: fi.Kode, Nama: fi.Nama, Induk: fs.Nama})
+					if !dryRun {
+						if _, err := q.CreateSkpIntervensi(c, pg.CreateSkpIntervensiParams{
+							KategoriID:    katID,
+							SubkategoriID: subID,
+							Kode:          kodeSkp(&fi.Kode),
+							Nama:          fi.Nama,
+							Urutan:        int32(ii + 1),
+							Versi:         1,
+							CreatedBy:     oleh,
+						}); err != nil {
+							return nil, errImporKatalogSkp(err, levelSkpIntervensi, fi.Nama)
+						}
+					}
+					continue
+				}
+
+				pi.dipilih[m.id] = true
+				lama := dataInv[m.id]
+				ubah := request.UpdateSkpIntervensi{ID: m.id, UpdatedBy: oleh}
+				berubah := ubahItemKatalogSkp(hasil, levelSkpIntervensi, m, fi.Kode, fi.Nama, fs.Nama, &ubah.Kode, &ubah.Nama, &ubah.IsActive)
+				if m.induk != subID {
+					hasil.Dipindah = append(hasil.Dipindah, PerubahanKatalogSkp{Level: levelSkpIntervensi, Kode: fi.Kode, Nama: fi.Nama, Induk: fs.Nama, IndukLama: namaSub[m.induk]})
+					ubah.SubkategoriID = &subID
+					berubah = true
+				}
+				id := m.id
+				if berubah && !dryRun {
+					// versi nonaktif diaktifkan dulu agar penggantian nama dapat membuat versi baru
+					if ubah.IsActive != nil {
+						if lama, err = ubahIntervensi(c, q, lama, request.UpdateSkpIntervensi{ID: lama.ID, IsActive: ubah.IsActive, UpdatedBy: oleh}); err != nil {
+							return nil, err
+						}
+						ubah.IsActive = nil
+					}
+					res, err := ubahIntervensi(c, q, lama, ubah)
+					if err != nil {
+						return nil, err
+					}
+					id = res.ID
+				}
+				urutInv.tambah(id, int32(ii+1))
+			}
+		}
+	}
+
+	// 🗂️ Item aktif yang tidak ada di berkas dinonaktifkan, tidak dihapus
+	for _, it := range pi.sisaAktif() {
+		hasil.Dinonaktifkan = append(hasil.Dinonaktifkan, PerubahanKatalogSkp{Level: levelSkpIntervensi, Kode: utils.DerefString(it.kode), Nama: it.nama, Induk: namaSub[it.induk]})
+		if !dryRun {
+			if _, err := q.UpdateSkpIntervensi(c, pg.UpdateSkpIntervensiParams{IsActive: utils.BoolPtr(false), UpdatedBy: oleh, ID: it.id}); err != nil {
+				return nil, errImporKatalogSkp(err, levelSkpIntervensi, it.nama)
+			}
+		}
+	}
+	for _, it := range ps.sisaAktif() {
+		hasil.Dinonaktifkan = append(hasil.Dinonaktifkan, PerubahanKatalogSkp{Level: levelSkpSubkategori, Kode: utils.DerefString(it.kode), Nama: it.nama})
+		if !dryRun {
+			if _, err := q.UpdateSkpSubkategori(c, pg.UpdateSkpSubkategoriParams{IsActive: utils.BoolPtr(false), UpdatedBy: oleh, ID: it.id}); err != nil {
+				return nil, errImporKatalogSkp(err, levelSkpSubkategori, it.nama)
+			}
+		}
+	}
+	for _, it := range pk.sisaAktif() {
+		hasil.Dinonaktifkan = append(hasil.Dinonaktifkan, PerubahanKatalogSkp{Level: levelSkpKategori, Kode: utils.DerefString(it.kode), Nama: it.nama})
+		if !dryRun {
+			if _, err := q.UpdateSkpKategori(c, pg.UpdateSkpKategoriParams{IsActive: utils.BoolPtr(false), UpdatedBy: oleh, ID: it.id}); err != nil {
+				return nil, errImporKatalogSkp(err, levelSkpKategori, it.nama)
+			}
+		}
+	}
+
+	if dryRun || len(hasil.Galat) > 0 {
+		return hasil, nil
+	}
+	if _, err := q.UrutkanSkpKategori(c, pg.UrutkanSkpKategoriParams{UpdatedBy: oleh, Ids: urutKat.ids, Urutan: urutKat.urutan}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed urutkan skp kategori")
+	}
+	if _, err := q.UrutkanSkpSubkategori(c, pg.UrutkanSkpSubkategoriParams{UpdatedBy: oleh, Ids: urutSub.ids, Urutan: urutSub.urutan}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed urutkan skp subkategori")
+	}
+	if _, err := q.UrutkanSkpIntervensi(c, pg.UrutkanSkpIntervensiParams{UpdatedBy: oleh, Ids: urutInv.ids, Urutan: urutInv.urutan}); err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed urutkan skp intervensi")
+	}
+	return hasil, nil
+}
+
+// ubahItemKatalogSkp mencatat penggantian nama dan pengaktifan kembali item yang cocok,
+// mengisi parameter update yang diperlukan, dan melaporkan apakah ada yang perlu disimpan.
+// Kode hanya diisi bila item belum berkode.
+func ubahItemKatalogSkp(hasil *HasilImportKatalogSkp, level string, m *itemKatalogSkp, kode, nama, induk string, pKode, pNama **string, pAktif **bool) bool {
+	berubah := false
+	if m.nama != nama {
+		hasil.DiubahNama = append(hasil.DiubahNama, PerubahanKatalogSkp{Level: level, Kode: kode, Nama: nama, NamaLama: m.nama, Induk: induk})
+		*pNama = &nama
+		berubah = true
+	}
+	if !m.aktif {
+		hasil.Diaktifkan = append(hasil.Diaktifkan, PerubahanKatalogSkp{Level: level, Kode: kode, Nama: nama, Induk: induk})
+		*pAktif = utils.BoolPtr(true)
+		berubah = true
+	}
+	if kode != "" && m.kode == nil {
+		*pKode = &kode
+		berubah = true
+	}
+	return berubah
+}
+
+func errImporKatalogSkp(err error, level, nama string) error {
+	if kodeSkpDipakai(err) {
+		return pkg.ExposeError(pkg.ErrorCodeConflict, fmt.Sprintf("kode skp %s %q sudah dipakai", level, nama))
+	}
+	return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed impor skp "+level)
+}
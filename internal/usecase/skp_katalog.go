@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Level katalog SKP yang dapat diurutkan.
+const (
+	levelSkpKategori    = "kategori"
+	levelSkpSubkategori = "subkategori"
+	levelSkpIntervensi  = "intervensi"
+)
+
+func (mu *SkpUsecaseImpl) ListKatalogSkp(c context.Context, arg request.SearchKatalogSkp) (any, error) {
+	res, err := mu.db.ListKatalogSkp(c, arg.IsActive)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get katalog skp")
+	}
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+	return resp.WithPaginate(res, nil), nil
+}
+
+func (mu *SkpUsecaseImpl) AddSkpKategori(c context.Context, arg request.CreateSkpKategori) (any, error) {
+	nama := strings.TrimSpace(arg.Nama)
+	if nama == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama wajib diisi")
+	}
+
+	res, err := mu.db.CreateSkpKategori(c, pg.CreateSkpKategoriParams{
+		Kode:      kodeSkp(arg.Kode),
+		Nama:      nama,
+		Urutan:    arg.Urutan,
+		CreatedBy: arg.CreatedBy,
+	})
+	if err != nil {
+		if kodeSkpDipakai(err) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp kategori sudah dipakai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create skp kategori")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) UpdateSkpKategori(c context.Context, arg pg.UpdateSkpKategoriParams) (any, error) {
+	if arg.Nama != nil && strings.TrimSpace(*arg.Nama) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama tidak boleh kosong")
+	}
+
+	arg.Kode = kodeSkp(arg.Kode)
+
+	res, err := mu.db.UpdateSkpKategori(c, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp kategori tidak ditemukan")
+		}
+		if kodeSkpDipakai(err) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp kategori sudah dipakai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp kategori")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) AddSkpSubkategori(c context.Context, arg request.CreateSkpSubkategori) (any, error) {
+	nama := strings.TrimSpace(arg.Nama)
+	if nama == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama wajib diisi")
+	}
+
+	res, err := mu.db.CreateSkpSubkategori(c, pg.CreateSkpSubkategoriParams{
+		KategoriID: arg.KategoriID,
+		Kode:       kodeSkp(arg.Kode),
+		Nama:       nama,
+		Urutan:     arg.Urutan,
+		CreatedBy:  arg.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp kategori tidak ditemukan")
+		}
+		if kodeSkpDipakai(err) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp subkategori sudah dipakai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create skp subkategori")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) UpdateSkpSubkategori(c context.Context, arg pg.UpdateSkpSubkategoriParams) (any, error) {
+	if arg.Nama != nil && strings.TrimSpace(*arg.Nama) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama tidak boleh kosong")
+	}
+
+	arg.Kode = kodeSkp(arg.Kode)
+
+	res, err := mu.db.UpdateSkpSubkategori(c, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp subkategori tidak ditemukan")
+		}
+		if kodeSkpDipakai(err) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp subkategori sudah dipakai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp subkategori")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) AddSkpIntervensi(c context.Context, arg request.CreateSkpIntervensi) (any, error) {
+	nama := strings.TrimSpace(arg.Nama)
+	if nama == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama wajib diisi")
+	}
+
+	sub, err := mu.db.GetSkpSubkategori(c, arg.SubkategoriID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp subkategori tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp subkategori")
+	}
+
+	res, err := mu.db.CreateSkpIntervensi(c, pg.CreateSkpIntervensiParams{
+		KategoriID:     sub.KategoriID,
+		SubkategoriID:  sub.ID,
+		Kode:           kodeSkp(arg.Kode),
+		Nama:           nama,
+		Urutan:         arg.Urutan,
+		Versi:          1,
+		LevelMinimalID: arg.LevelMinimalID,
+		CreatedBy:      arg.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp level tidak ditemukan")
+		}
+		if kodeSkpDipakai(err) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp intervensi sudah dipakai")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create skp intervensi")
+	}
+	return res, nil
+}
+
+// UpdateSkpIntervensi mengubah intervensi di tempat selama belum pernah dicatat pada
+// kehadiran_skp. Bila sudah dipakai, perubahan nama/subkategori membuat versi baru dan
+// versi lama dinonaktifkan agar riwayat capaian tetap menunjuk item aslinya; target SKP
+// dipindahkan ke versi baru.
+func (mu *SkpUsecaseImpl) UpdateSkpIntervensi(c context.Context, arg request.UpdateSkpIntervensi) (any, error) {
+	if arg.Nama != nil {
+		nama := strings.TrimSpace(*arg.Nama)
+		if nama == "" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama tidak boleh kosong")
+		}
+		arg.Nama = &nama
+	}
+	arg.Kode = kodeSkp(arg.Kode)
+
+	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
+		lama, err := qtx.GetSkpIntervensi(c, arg.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp intervensi tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp intervensi")
+		}
+		return ubahIntervensi(c, qtx, lama, arg)
+	})
+}
+
+// ubahIntervensi menerapkan perubahan intervensi di dalam transaksi qtx, membuat versi baru
+// bila intervensi yang sudah dipakai diganti nama atau dipindah subkategori.
+func ubahIntervensi(c context.Context, qtx *pg.Queries, lama pg.SkpIntervensi, arg request.UpdateSkpIntervensi) (pg.SkpIntervensi, error) {
+	params := pg.UpdateSkpIntervensiParams{
+		Kode:      arg.Kode,
+		Nama:      arg.Nama,
+		IsActive:  arg.IsActive,
+		UpdatedBy: arg.UpdatedBy,
+		ID:        lama.ID,
+	}
+	if arg.SubkategoriID != nil && *arg.SubkategoriID != lama.SubkategoriID {
+		sub, err := qtx.GetSkpSubkategori(c, *arg.SubkategoriID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pg.SkpIntervensi{}, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp subkategori tidak ditemukan")
+			}
+			return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp subkategori")
+		}
+		params.SubkategoriID = &sub.ID
+		params.KategoriID = &sub.KategoriID
+	}
+
+	berubah := (params.Nama != nil && *params.Nama != lama.Nama) || params.SubkategoriID != nil
+	dipakai := false
+	if berubah {
+		var err error
+		if dipakai, err = qtx.CekIntervensiDipakai(c, lama.ID); err != nil {
+			return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check skp intervensi")
+		}
+	}
+
+	if !dipakai {
+		res, err := qtx.UpdateSkpIntervensi(c, params)
+		if err != nil {
+			if kodeSkpDipakai(err) {
+				return pg.SkpIntervensi{}, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp intervensi sudah dipakai")
+			}
+			return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp intervensi")
+		}
+		return res, nil
+	}
+
+	if !lama.IsActive {
+		return pg.SkpIntervensi{}, pkg.ExposeError(pkg.ErrorCodeConflict, "versi intervensi yang sudah nonaktif tidak dapat diubah")
+	}
+
+	// 🗂️ Nonaktifkan versi lama lebih dulu (kode aktif harus unik), lalu buat versi baru
+	if _, err := qtx.UpdateSkpIntervensi(c, pg.UpdateSkpIntervensiParams{
+		IsActive:  utils.BoolPtr(false),
+		UpdatedBy: arg.UpdatedBy,
+		ID:        lama.ID,
+	}); err != nil {
+		return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed nonaktifkan skp intervensi")
+	}
+
+	akarID := lama.AkarID
+	if akarID == nil {
+		akarID = &lama.ID
+	}
+	baru := pg.CreateSkpIntervensiParams{
+		KategoriID:     lama.KategoriID,
+		SubkategoriID:  lama.SubkategoriID,
+		Kode:           lama.Kode,
+		Nama:           lama.Nama,
+		Urutan:         lama.Urutan,
+		Versi:          lama.Versi + 1,
+		AkarID:         akarID,
+		LevelMinimalID: lama.LevelMinimalID,
+		CreatedBy:      arg.UpdatedBy,
+	}
+	if params.Kode != nil {
+		baru.Kode = params.Kode
+	}
+	if params.Nama != nil {
+		baru.Nama = *params.Nama
+	}
+	if params.SubkategoriID != nil {
+		baru.SubkategoriID = *params.SubkategoriID
+		baru.KategoriID = *params.KategoriID
+	}
+
+	res, err := qtx.CreateSkpIntervensi(c, baru)
+	if err != nil {
+		if kodeSkpDipakai(err) {
+			return pg.SkpIntervensi{}, pkg.ExposeError(pkg.ErrorCodeConflict, "kode skp intervensi sudah dipakai")
+		}
+		return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create versi skp intervensi")
+	}
+	if err := qtx.PindahkanTargetIntervensi(c, pg.PindahkanTargetIntervensiParams{
+		IntervensiBaru: res.ID,
+		UpdatedBy:      arg.UpdatedBy,
+		IntervensiLama: lama.ID,
+	}); err != nil {
+		return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed pindahkan skp target")
+	}
+
+	if arg.IsActive != nil && !*arg.IsActive {
+		if res, err = qtx.UpdateSkpIntervensi(c, pg.UpdateSkpIntervensiParams{
+			IsActive:  arg.IsActive,
+			UpdatedBy: arg.UpdatedBy,
+			ID:        res.ID,
+		}); err != nil {
+			return pg.SkpIntervensi{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp intervensi")
+		}
+	}
+	return res, nil
+}
+
+// UrutkanSkp menyimpan urutan tampil beberapa item katalog pada satu level sekaligus.
+func (mu *SkpUsecaseImpl) UrutkanSkp(c context.Context, level string, arg request.UrutkanSkp) (any, error) {
+	if len(arg.Item) == 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "item urutan wajib diisi")
+	}
+
+	ids := make([]uuid.UUID, 0, len(arg.Item))
+	urutan := make([]int32, 0, len(arg.Item))
+	for _, it := range arg.Item {
+		ids = append(ids, it.ID)
+		urutan = append(urutan, it.Urutan)
+	}
+
+	var (
+		n   int64
+		err error
+	)
+	switch level {
+	case levelSkpKategori:
+		n, err = mu.db.UrutkanSkpKategori(c, pg.UrutkanSkpKategoriParams{UpdatedBy: arg.UpdatedBy, Ids: ids, Urutan: urutan})
+	case levelSkpSubkategori:
+		n, err = mu.db.UrutkanSkpSubkategori(c, pg.UrutkanSkpSubkategoriParams{UpdatedBy: arg.UpdatedBy, Ids: ids, Urutan: urutan})
+	case levelSkpIntervensi:
+		n, err = mu.db.UrutkanSkpIntervensi(c, pg.UrutkanSkpIntervensiParams{UpdatedBy: arg.UpdatedBy, Ids: ids, Urutan: urutan})
+	default:
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "level harus kategori, subkategori atau intervensi")
+	}
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed urutkan skp "+level)
+	}
+
+	return map[string]any{"level": level, "diperbarui": n}, nil
+}
+
+// kodeSkp menormalkan kode katalog; kode kosong dianggap tidak diisi.
+func kodeSkp(kode *string) *string {
+	if kode == nil {
+		return nil
+	}
+	k := strings.TrimSpace(*kode)
+	if k == "" {
+		return nil
+	}
+	return &k
+}
+
+func kodeSkpDipakai(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"e-klinik/config"
 	"e-klinik/infra/pg"
 	"e-klinik/infra/worker"
 	"e-klinik/internal/domain/request"
@@ -9,10 +10,21 @@ import (
 	"e-klinik/pkg"
 	"e-klinik/utils"
 	"errors"
+	"mime/multipart"
+	"strings"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jinzhu/copier"
+	"github.com/minio/minio-go/v7"
+)
+
+// Status keputusan pembimbing atas satu tindakan SKP.
+const (
+	statusSkpDisetujui = "disetujui"
+	statusSkpDitolak   = "ditolak"
+	statusSkpRevisi    = "revisi"
 )
 
 type SkpKehadiranUsecase interface {
@@ -23,21 +35,37 @@ type SkpKehadiranUsecase interface {
 	SkpByKehadiranId(c context.Context, arg uuid.UUID) (any, error)
 	IntervensiByKehadiranId(c context.Context, arg uuid.UUID) (any, error)
 	ApproveSkpKehadiran(c context.Context, arg request.ApproveKehadiranSkp) (any, error)
+	InboxApproval(c context.Context, arg request.SearchInboxApproval) (any, error)
+	BatchKeputusanSkp(c context.Context, arg request.BatchKeputusanSkp) (any, error)
+	BuatDelegasi(c context.Context, arg request.CreateDelegasiPembimbing) (any, error)
+	ListDelegasi(c context.Context, arg request.SearchDelegasiPembimbing) (any, error)
+	CabutDelegasi(c context.Context, id uuid.UUID, userID *uuid.UUID, oleh *string) (any, error)
+	AjukanUlangSkp(c context.Context, id uuid.UUID, userID uuid.UUID, updatedBy *string) (any, error)
+	BukaKunciSkp(c context.Context, arg request.BukaKunciSkp) (any, error)
+	ListPembukaanSkp(c context.Context, kehadiranID uuid.UUID) (any, error)
+	SimpanBuktiSkp(c context.Context, arg request.BuktiKehadiranSkp) (any, error)
+	UnggahLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID, createdBy *string, berkas *multipart.FileHeader) (any, error)
+	HapusLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID, deletedBy *string) error
+	GetLampiranSkp(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error)
 }
 
 type SkpKehadiranUsecaseImpl struct {
 	worker *worker.ProducerService
 	db     *pg.Queries
 	pg     *pkg.Postgres
+	cfg    *config.Config
 	cache  *pkg.RedisCache
+	s3     *minio.Client
 }
 
-func NewSkpKehadiranUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *SkpKehadiranUsecaseImpl {
+func NewSkpKehadiranUsecase(postgre *pkg.Postgres, cfg *config.Config, worker *worker.ProducerService, cache *pkg.RedisCache, s3 *minio.Client) *SkpKehadiranUsecaseImpl {
 	return &SkpKehadiranUsecaseImpl{
 		db:     pg.New(postgre.Pool),
 		pg:     postgre,
+		cfg:    cfg,
 		worker: worker,
 		cache:  cache,
+		s3:     s3,
 	}
 }
 
@@ -53,6 +81,11 @@ func (mu *SkpKehadiranUsecaseImpl) SyncSkpKehadiran(c context.Context, arg pg.Sy
 
 		if result.Status == nil {
 			if _, err := qtx.SyncKehadiranSkp(c, arg); err != nil {
+				// Tindakan yang sudah memiliki riwayat keputusan tidak dapat dihapus
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+					return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan yang sudah pernah direview tidak dapat dihapus")
+				}
 				return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed sync kehadiran skp")
 			}
 		}
@@ -137,37 +170,250 @@ func (mu *SkpKehadiranUsecaseImpl) SkpByKehadiranId(c context.Context, arg uuid.
 	return resp.WithPaginate(res, nil), nil
 }
 
+// IntervensiByKehadiranId menampilkan tindakan SKP beserta bukti dan lampirannya
+// sebagai bahan review pembimbing.
 func (mu *SkpKehadiranUsecaseImpl) IntervensiByKehadiranId(c context.Context, arg uuid.UUID) (any, error) {
-	res, err := mu.db.IntervensiKehadiranID(c, arg)
+	rows, err := mu.db.IntervensiKehadiranID(c, arg)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return resp.WithPaginate([]any{}, nil), nil
 		}
 		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get intervensi")
 	}
+	if len(rows) == 0 {
+		return resp.WithPaginate([]any{}, nil), nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.ID)
+	}
+	lampiran, err := mu.lampiranPerSkp(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	keputusan, err := mu.db.ListKehadiranSkpKeputusan(c, ids)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get riwayat keputusan skp")
+	}
+	riwayat := make(map[uuid.UUID][]pg.KehadiranSkpKeputusan, len(ids))
+	for _, k := range keputusan {
+		riwayat[k.KehadiranSkpID] = append(riwayat[k.KehadiranSkpID], k)
+	}
+
+	res := make([]IntervensiKehadiranSkp, 0, len(rows))
+	for _, r := range rows {
+		l := lampiran[r.ID]
+		if l == nil {
+			l = []LampiranSkp{}
+		}
+		rw := riwayat[r.ID]
+		if rw == nil {
+			rw = []pg.KehadiranSkpKeputusan{}
+		}
+		res = append(res, IntervensiKehadiranSkp{IntervensiKehadiranIDRow: r, Lampiran: l, Riwayat: rw})
+	}
 	return resp.WithPaginate(res, nil), nil
 }
 
+// ApproveSkpKehadiran menerapkan keputusan pembimbing untuk setiap tindakan yang menunggu
+// review. Tindakan yang disetujui diberi tingkat kompetensi; tindakan yang ditolak atau
+// dikembalikan wajib disertai alasan. Tindakan yang dikembalikan (revisi) tidak dikunci
+// sehingga mahasiswa dapat memperbaiki lalu mengajukan ulang.
 func (mu *SkpKehadiranUsecaseImpl) ApproveSkpKehadiran(c context.Context, arg request.ApproveKehadiranSkp) (any, error) {
+	keputusan := make(map[uuid.UUID]request.KeputusanSkp, len(arg.SkpKehadiranID)+len(arg.Keputusan))
+	for _, id := range arg.SkpKehadiranID {
+		keputusan[id] = request.KeputusanSkp{ID: id, Status: statusSkpDisetujui, LevelID: arg.LevelID}
+	}
+	for _, k := range arg.Keputusan {
+		if _, ok := keputusan[k.ID]; ok {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "setiap tindakan hanya boleh memiliki satu keputusan")
+		}
+		keputusan[k.ID] = k
+	}
+
+	levelAktif, err := mu.levelSkpAktif(c)
+	if err != nil {
+		return nil, err
+	}
+	for id, k := range keputusan {
+		if keputusan[id], err = validasiKeputusanSkp(k, levelAktif); err != nil {
+			return nil, err
+		}
+	}
+
 	return utils.WithTransactionResult(c, mu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
-		updateParams := pg.UpdateKehadiranPartialParams{
-			ID:     arg.KehadiranID,
-			Status: utils.StringPtr("disetujui"),
+		return terapkanKeputusanSkp(c, qtx, arg.KehadiranID, arg.ReviewerID, arg.UpdatedBy, keputusan)
+	})
+}
+
+// levelSkpAktif mengembalikan skala tingkat kompetensi yang masih aktif.
+func (mu *SkpKehadiranUsecaseImpl) levelSkpAktif(c context.Context) (map[uuid.UUID]bool, error) {
+	levels, err := mu.db.ListSkpLevel(c, utils.BoolPtr(true))
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp level")
+	}
+	res := make(map[uuid.UUID]bool, len(levels))
+	for _, lv := range levels {
+		res[lv.ID] = true
+	}
+	return res, nil
+}
+
+// validasiKeputusanSkp menormalkan status dan memastikan alasan serta tingkat kompetensi
+// terisi sesuai keputusan. Tingkat wajib untuk tindakan yang disetujui selama skala tersedia.
+func validasiKeputusanSkp(k request.KeputusanSkp, levelAktif map[uuid.UUID]bool) (request.KeputusanSkp, error) {
+	k.Status = strings.ToLower(strings.TrimSpace(k.Status))
+	switch k.Status {
+	case statusSkpDisetujui:
+		if k.LevelID == nil {
+			if len(levelAktif) > 0 {
+				return k, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "level kompetensi wajib diisi untuk tindakan yang disetujui")
+			}
+		} else if !levelAktif[*k.LevelID] {
+			return k, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "level kompetensi tidak valid")
 		}
-		res, err := qtx.UpdateKehadiranPartial(c, updateParams)
-		if err != nil {
-			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update kehadiran")
+	case statusSkpDitolak, statusSkpRevisi:
+		if k.Alasan == nil || strings.TrimSpace(*k.Alasan) == "" {
+			return k, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "alasan wajib diisi untuk tindakan yang ditolak atau dikembalikan")
 		}
+		alasan := strings.TrimSpace(*k.Alasan)
+		k.Alasan = &alasan
+		k.LevelID = nil
+	default:
+		return k, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "status harus disetujui, ditolak atau revisi")
+	}
+	return k, nil
+}
 
-		approveParams := pg.ApproveKehadiranSkpByIdsParams{
-			UpdatedBy:      arg.UpdatedBy,
-			SkpKehadiranID: arg.SkpKehadiranID,
-			KehadiranID:    arg.KehadiranID,
+// terapkanKeputusanSkp menyimpan keputusan untuk seluruh tindakan yang menunggu review pada
+// satu kehadiran, mencatat riwayatnya, lalu memperbarui status kehadiran. Kehadiran tanpa
+// tindakan SKP cukup disetujui. Penerima delegasi aktif boleh memutuskan atas nama
+// pembimbing klinik. Keputusan atas kehadiran itu sendiri selalu dicatat, sehingga
+// persetujuan kehadiran tanpa tindakan pun meninggalkan jejak peninjau dan delegasinya.
+func terapkanKeputusanSkp(c context.Context, qtx *pg.Queries, kehadiranID uuid.UUID, reviewerID uuid.UUID, reviewedBy *string, keputusan map[uuid.UUID]request.KeputusanSkp) (pg.Kehadiran, error) {
+	kehadiran, err := qtx.GetKehadiran(c, kehadiranID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pg.Kehadiran{}, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran not found")
 		}
-		if err := qtx.ApproveKehadiranSkpByIds(c, approveParams); err != nil {
-			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed approve kehadiran skp")
+		return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran")
+	}
+	delegasi, err := penyetujuKehadiran(c, qtx, kehadiran, reviewerID)
+	if err != nil {
+		return pg.Kehadiran{}, err
+	}
+	// Keputusan oleh penerima delegasi dicatat atas nama pembimbing klinik asal
+	var atasNamaID, delegasiID *uuid.UUID
+	if delegasi != nil {
+		atasNamaID, delegasiID = &delegasi.PembimbingID, &delegasi.ID
+	}
+
+	// Semua tindakan yang menunggu review harus diputuskan sekaligus
+	menunggu, err := qtx.ListSkpMenungguReview(c, kehadiranID)
+	if err != nil {
+		return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp menunggu review")
+	}
+	if len(menunggu) == 0 && (kehadiran.Status != nil || len(keputusan) > 0) {
+		return pg.Kehadiran{}, pkg.ExposeError(pkg.ErrorCodeConflict, "tidak ada tindakan yang menunggu review")
+	}
+	if len(menunggu) != len(keputusan) {
+		return pg.Kehadiran{}, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "semua tindakan yang menunggu review harus diputuskan")
+	}
+
+	for _, id := range menunggu {
+		k, ok := keputusan[id]
+		if !ok {
+			return pg.Kehadiran{}, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "semua tindakan yang menunggu review harus diputuskan")
+		}
+		if _, err := qtx.ReviewKehadiranSkp(c, pg.ReviewKehadiranSkpParams{
+			Status:        k.Status,
+			CatatanReview: k.Alasan,
+			LevelID:       k.LevelID,
+			ReviewerID:    &reviewerID,
+			ReviewedBy:    reviewedBy,
+			ID:            id,
+			KehadiranID:   kehadiranID,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pg.Kehadiran{}, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah direview")
+			}
+			return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed review kehadiran skp")
+		}
+		if err := qtx.CreateKehadiranSkpKeputusan(c, pg.CreateKehadiranSkpKeputusanParams{
+			KehadiranSkpID:       id,
+			Status:               k.Status,
+			CatatanReview:        k.Alasan,
+			ReviewerID:           reviewerID,
+			ReviewedBy:           reviewedBy,
+			LevelID:              k.LevelID,
+			AtasNamaID:           atasNamaID,
+			DelegasiPembimbingID: delegasiID,
+		}); err != nil {
+			return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create keputusan skp")
 		}
+	}
+
+	// Kehadiran tetap berstatus revisi selama masih ada tindakan yang dikembalikan
+	revisi, err := qtx.CountSkpRevisi(c, kehadiranID)
+	if err != nil {
+		return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed count skp revisi")
+	}
+	status := statusSkpDisetujui
+	if revisi > 0 {
+		status = statusSkpRevisi
+	}
 
-		return res, nil
+	res, err := qtx.UpdateKehadiranPartial(c, pg.UpdateKehadiranPartialParams{
+		ID:        kehadiranID,
+		Status:    utils.StringPtr(status),
+		UpdatedBy: reviewedBy,
 	})
+	if err != nil {
+		return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update kehadiran")
+	}
+	if err := qtx.CreateKehadiranKeputusan(c, pg.CreateKehadiranKeputusanParams{
+		KehadiranID:          kehadiranID,
+		Status:               status,
+		ReviewerID:           reviewerID,
+		ReviewedBy:           reviewedBy,
+		AtasNamaID:           atasNamaID,
+		DelegasiPembimbingID: delegasiID,
+	}); err != nil {
+		return pg.Kehadiran{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create keputusan kehadiran")
+	}
+	return res, nil
+}
+
+// AjukanUlangSkp mengajukan kembali tindakan yang dikembalikan pembimbing untuk direview.
+func (mu *SkpKehadiranUsecaseImpl) AjukanUlangSkp(c context.Context, id uuid.UUID, userID uuid.UUID, updatedBy *string) (any, error) {
+	ks, err := mu.db.GetKehadiranSkp(c, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "kehadiran skp tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get kehadiran skp")
+	}
+	if ks.UserID != userID {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "Anda bukan pemilik tindakan ini")
+	}
+	if utils.DerefString(ks.Status) != statusSkpRevisi {
+		return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "hanya tindakan yang dikembalikan untuk revisi yang dapat diajukan ulang")
+	}
+	if utils.DerefString(ks.Narasi) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "lengkapi bukti tindakan sebelum mengajukan ulang")
+	}
+
+	res, err := mu.db.AjukanUlangKehadiranSkp(c, pg.AjukanUlangKehadiranSkpParams{
+		UpdatedBy: updatedBy,
+		ID:        id,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "tindakan sudah diajukan ulang")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed ajukan ulang kehadiran skp")
+	}
+	return res, nil
 }
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (mu *SkpUsecaseImpl) ListSkpLevel(c context.Context, arg request.SearchKatalogSkp) (any, error) {
+	res, err := mu.db.ListSkpLevel(c, arg.IsActive)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp level")
+	}
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+	return resp.WithPaginate(res, nil), nil
+}
+
+func (mu *SkpUsecaseImpl) AddSkpLevel(c context.Context, arg request.CreateSkpLevel) (any, error) {
+	kode := strings.ToLower(strings.TrimSpace(arg.Kode))
+	nama := strings.TrimSpace(arg.Nama)
+	if kode == "" || nama == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kode dan nama wajib diisi")
+	}
+	if arg.Urutan <= 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "urutan harus lebih dari 0")
+	}
+
+	res, err := mu.db.CreateSkpLevel(c, pg.CreateSkpLevelParams{
+		Kode:      kode,
+		Nama:      nama,
+		Urutan:    arg.Urutan,
+		Deskripsi: arg.Deskripsi,
+		CreatedBy: arg.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "kode level sudah digunakan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create skp level")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) UpdateSkpLevel(c context.Context, arg pg.UpdateSkpLevelParams) (any, error) {
+	if arg.Nama != nil && strings.TrimSpace(*arg.Nama) == "" {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "nama tidak boleh kosong")
+	}
+	if arg.Urutan != nil && *arg.Urutan <= 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "urutan harus lebih dari 0")
+	}
+
+	res, err := mu.db.UpdateSkpLevel(c, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp level tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp level")
+	}
+	return res, nil
+}
+
+// SetLevelMinimalIntervensi mengatur tingkat minimal agar tindakan dihitung tercapai;
+// level_minimal_id kosong berarti cukup disetujui pembimbing.
+func (mu *SkpUsecaseImpl) SetLevelMinimalIntervensi(c context.Context, arg request.SetLevelMinimalSkp) (any, error) {
+	if arg.LevelMinimalID != nil {
+		lv, err := mu.db.GetSkpLevel(c, *arg.LevelMinimalID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp level tidak ditemukan")
+			}
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get skp level")
+		}
+		if !lv.IsActive {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "skp level sudah nonaktif")
+		}
+	}
+
+	res, err := mu.db.SetLevelMinimalIntervensi(c, pg.SetLevelMinimalIntervensiParams{
+		LevelMinimalID: arg.LevelMinimalID,
+		UpdatedBy:      arg.UpdatedBy,
+		ID:             arg.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp intervensi tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update level minimal skp intervensi")
+	}
+	return res, nil
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"e-klinik/infra/pg"
+	"e-klinik/internal/domain/request"
+	"e-klinik/internal/domain/resp"
+	"e-klinik/pkg"
+	"e-klinik/utils"
+	"errors"
+	"math"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ProgresSkpItem membandingkan satu target (intervensi atau subkategori) dengan capaian
+// kehadiran_skp yang sudah disetujui.
+type ProgresSkpItem struct {
+	TargetID        uuid.UUID  `json:"target_id"`
+	SkpIntervensiID *uuid.UUID `json:"skp_intervensi_id"`
+	NamaIntervensi  *string    `json:"nama_intervensi"`
+	JumlahTarget    int32      `json:"jumlah_target"`
+	JumlahTercapai  int64      `json:"jumlah_tercapai"`
+	Persentase      float64    `json:"persentase"`
+	Terpenuhi       bool       `json:"terpenuhi"`
+}
+
+type ProgresSkpSubkategori struct {
+	SkpSubkategoriID uuid.UUID        `json:"skp_subkategori_id"`
+	NamaSubkategori  string           `json:"nama_subkategori"`
+	JumlahTarget     int64            `json:"jumlah_target"`
+	JumlahTercapai   int64            `json:"jumlah_tercapai"`
+	Persentase       float64          `json:"persentase"`
+	Item             []ProgresSkpItem `json:"item"`
+}
+
+type ProgresSkp struct {
+	UserID         uuid.UUID               `json:"user_id"`
+	MataKuliahID   uuid.UUID               `json:"mata_kuliah_id"`
+	JumlahTarget   int64                   `json:"jumlah_target"`
+	JumlahTercapai int64                   `json:"jumlah_tercapai"`
+	Persentase     float64                 `json:"persentase"`
+	Subkategori    []ProgresSkpSubkategori `json:"subkategori"`
+}
+
+func (mu *SkpUsecaseImpl) AddSkpTarget(c context.Context, arg request.CreateSkpTarget) (any, error) {
+	if (arg.SkpIntervensiID == nil) == (arg.SkpSubkategoriID == nil) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "isi salah satu dari skp_intervensi_id atau skp_subkategori_id")
+	}
+	if arg.JumlahTarget <= 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "jumlah_target harus lebih dari 0")
+	}
+
+	res, err := mu.db.CreateSkpTarget(c, pg.CreateSkpTargetParams{
+		MataKuliahID:     arg.MataKuliahID,
+		SkpIntervensiID:  arg.SkpIntervensiID,
+		SkpSubkategoriID: arg.SkpSubkategoriID,
+		JumlahTarget:     arg.JumlahTarget,
+		CreatedBy:        arg.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "target untuk item tersebut sudah ada pada mata kuliah ini")
+			case "23503":
+				return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "mata kuliah, intervensi atau subkategori tidak ditemukan")
+			}
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create skp target")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) ListSkpTarget(c context.Context, arg request.SearchSkpTarget) (any, error) {
+	if arg.Limit <= 0 {
+		arg.Limit = 10
+	}
+	if arg.Page <= 0 {
+		arg.Page = 1
+	}
+	arg.Offset = utils.GetOffset(arg.Page, arg.Limit)
+
+	var mataKuliahID *uuid.UUID
+	if arg.MataKuliahID != "" {
+		id := uuid.FromStringOrNil(arg.MataKuliahID)
+		mataKuliahID = &id
+	}
+
+	res, err := mu.db.ListSkpTarget(c, pg.ListSkpTargetParams{
+		MataKuliahID: mataKuliahID,
+		Limit:        arg.Limit,
+		Offset:       arg.Offset,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to get skp target list")
+	}
+
+	if len(res) == 0 {
+		return resp.WithPaginate([]string{}, nil), nil
+	}
+
+	count, err := mu.db.CountSkpTarget(c, mataKuliahID)
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed to count skp target")
+	}
+
+	return resp.WithPaginate(res, resp.CalculatePagination(arg.Page, arg.Limit, count)), nil
+}
+
+func (mu *SkpUsecaseImpl) UpdateSkpTarget(c context.Context, arg pg.UpdateSkpTargetParams) (any, error) {
+	if arg.JumlahTarget != nil && *arg.JumlahTarget <= 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "jumlah_target harus lebih dari 0")
+	}
+
+	res, err := mu.db.UpdateSkpTarget(c, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeNotFound, "skp target tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed update skp target")
+	}
+	return res, nil
+}
+
+func (mu *SkpUsecaseImpl) DeleteSkpTarget(c context.Context, arg pg.DeleteSkpTargetParams) error {
+	if err := mu.db.DeleteSkpTarget(c, arg); err != nil {
+		return pkg.WrapError(err, pkg.ErrorCodeInternal, "failed delete skp target")
+	}
+	return nil
+}
+
+// ProgresSkp menghitung capaian mahasiswa terhadap target SKP mata kuliah. Capaian yang
+// melebihi target tidak menutupi kekurangan item lain, sehingga persentase subkategori dan
+// keseluruhan hanya mencapai 100% bila setiap target terpenuhi.
+// pemanggilID nil berarti tanpa batasan (admin/koordinator); selain itu progres hanya dapat
+// dilihat oleh mahasiswa itu sendiri atau pembimbingnya pada mata kuliah tersebut.
+func (mu *SkpUsecaseImpl) ProgresSkp(c context.Context, userID uuid.UUID, mataKuliahID uuid.UUID, pemanggilID *uuid.UUID) (any, error) {
+	if pemanggilID != nil && *pemanggilID != userID {
+		pembimbing, err := mu.db.CekPembimbingMahasiswa(c, pg.CekPembimbingMahasiswaParams{
+			UserID:       userID,
+			MataKuliahID: mataKuliahID,
+			PembimbingID: *pemanggilID,
+		})
+		if err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed check pembimbing mahasiswa")
+		}
+		if !pembimbing {
+			return nil, pkg.ExposeError(pkg.ErrorCodeForbidden, "akses ditolak")
+		}
+	}
+
+	rows, err := mu.db.GetProgresSkp(c, pg.GetProgresSkpParams{
+		UserID:       userID,
+		MataKuliahID: mataKuliahID,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get progres skp")
+	}
+
+	res := ProgresSkp{
+		UserID:       userID,
+		MataKuliahID: mataKuliahID,
+		Subkategori:  []ProgresSkpSubkategori{},
+	}
+	posisi := make(map[uuid.UUID]int)
+	for _, r := range rows {
+		i, ok := posisi[r.SkpSubkategoriID]
+		if !ok {
+			i = len(res.Subkategori)
+			posisi[r.SkpSubkategoriID] = i
+			res.Subkategori = append(res.Subkategori, ProgresSkpSubkategori{
+				SkpSubkategoriID: r.SkpSubkategoriID,
+				NamaSubkategori:  r.NamaSubkategori,
+				Item:             []ProgresSkpItem{},
+			})
+		}
+
+		terhitung := min(r.JumlahTercapai, int64(r.JumlahTarget))
+		sub := &res.Subkategori[i]
+		sub.Item = append(sub.Item, ProgresSkpItem{
+			TargetID:        r.TargetID,
+			SkpIntervensiID: r.SkpIntervensiID,
+			NamaIntervensi:  r.NamaIntervensi,
+			JumlahTarget:    r.JumlahTarget,
+			JumlahTercapai:  r.JumlahTercapai,
+			Persentase:      persentase(terhitung, int64(r.JumlahTarget)),
+			Terpenuhi:       r.JumlahTercapai >= int64(r.JumlahTarget),
+		})
+		sub.JumlahTarget += int64(r.JumlahTarget)
+		sub.JumlahTercapai += terhitung
+		res.JumlahTarget += int64(r.JumlahTarget)
+		res.JumlahTercapai += terhitung
+	}
+
+	for i := range res.Subkategori {
+		res.Subkategori[i].Persentase = persentase(res.Subkategori[i].JumlahTercapai, res.Subkategori[i].JumlahTarget)
+	}
+	res.Persentase = persentase(res.JumlahTercapai, res.JumlahTarget)
+
+	return res, nil
+}
+
+// persentase dibulatkan dua angka di belakang koma; 0 bila tidak ada target.
+func persentase(tercapai, target int64) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return math.Round(float64(tercapai)/float64(target)*10000) / 100
+}
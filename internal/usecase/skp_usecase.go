@@ -4,12 +4,36 @@ import (
 	"context"
 	"e-klinik/infra/pg"
 	"e-klinik/infra/worker"
+	"e-klinik/internal/domain/request"
 	"e-klinik/internal/domain/resp"
 	"e-klinik/pkg"
+	"io"
+	"mime/multipart"
+
+	"github.com/gofrs/uuid/v5"
 )
 
 type SkpUsecase interface {
 	ListIntervensi(c context.Context) (any, error)
+	AddSkpTarget(c context.Context, arg request.CreateSkpTarget) (any, error)
+	ListSkpTarget(c context.Context, arg request.SearchSkpTarget) (any, error)
+	UpdateSkpTarget(c context.Context, arg pg.UpdateSkpTargetParams) (any, error)
+	DeleteSkpTarget(c context.Context, arg pg.DeleteSkpTargetParams) error
+	ProgresSkp(c context.Context, userID uuid.UUID, mataKuliahID uuid.UUID, pemanggilID *uuid.UUID) (any, error)
+	ListKatalogSkp(c context.Context, arg request.SearchKatalogSkp) (any, error)
+	AddSkpKategori(c context.Context, arg request.CreateSkpKategori) (any, error)
+	UpdateSkpKategori(c context.Context, arg pg.UpdateSkpKategoriParams) (any, error)
+	AddSkpSubkategori(c context.Context, arg request.CreateSkpSubkategori) (any, error)
+	UpdateSkpSubkategori(c context.Context, arg pg.UpdateSkpSubkategoriParams) (any, error)
+	AddSkpIntervensi(c context.Context, arg request.CreateSkpIntervensi) (any, error)
+	UpdateSkpIntervensi(c context.Context, arg request.UpdateSkpIntervensi) (any, error)
+	UrutkanSkp(c context.Context, level string, arg request.UrutkanSkp) (any, error)
+	EksporKatalogSkp(c context.Context, format string, tulis func(nama, tipe string) io.Writer) error
+	ImportKatalogSkp(c context.Context, arg request.ImportKatalogSkp, berkas *multipart.FileHeader) (any, error)
+	ListSkpLevel(c context.Context, arg request.SearchKatalogSkp) (any, error)
+	AddSkpLevel(c context.Context, arg request.CreateSkpLevel) (any, error)
+	UpdateSkpLevel(c context.Context, arg pg.UpdateSkpLevelParams) (any, error)
+	SetLevelMinimalIntervensi(c context.Context, arg request.SetLevelMinimalSkp) (any, error)
 }
 
 type SkpUsecaseImpl struct {
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"e-klinik/config"
 	"e-klinik/infra/pg"
 	"e-klinik/infra/worker"
 	"e-klinik/internal/domain/request"
@@ -11,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -30,19 +32,23 @@ type SummaryUsecase interface {
 	ChartGetHariIniSKPPersentase(c context.Context) (any, error)
 	RekapSkpTercapaiMahasiswaByDate(c context.Context, arg request.SearchSkpTercapai) (any, error)
 	GetGlobalSKPPersentaseTahunanOtomatis(c context.Context) (any, error)
+	EksporRekapKehadiran(c context.Context, arg request.EksporRekapKehadiran, tulis func(nama, tipe string) io.Writer) error
+	LogbookKlinik(c context.Context, penempatanID uuid.UUID, pemanggilID *uuid.UUID, tulis func(nama, tipe string) io.Writer) error
 }
 
 type SummaryUsecaseImpl struct {
 	worker *worker.ProducerService
 	db     *pg.Queries
 	pg     *pkg.Postgres
+	cfg    *config.Config
 	cache  *pkg.RedisCache
 }
 
-func NewSummaryUsecase(postgre *pkg.Postgres, worker *worker.ProducerService, cache *pkg.RedisCache) *SummaryUsecaseImpl {
+func NewSummaryUsecase(postgre *pkg.Postgres, cfg *config.Config, worker *worker.ProducerService, cache *pkg.RedisCache) *SummaryUsecaseImpl {
 	return &SummaryUsecaseImpl{
 		db:     pg.New(postgre.Pool),
 		pg:     postgre,
+		cfg:    cfg,
 		worker: worker,
 		cache:  cache,
 	}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"e-klinik/pkg"
+	"encoding/csv"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maksUkuranTabelImport membatasi ukuran berkas CSV/XLSX yang diimpor (5 MB).
+const maksUkuranTabelImport = 5 << 20
+
+// maksBarisImport membatasi jumlah baris data dalam sekali impor.
+const maksBarisImport = 5000
+
+// bacaTabelImport membaca sheet pertama XLSX atau isi CSV menjadi baris-baris string.
+// Baris pertama selalu dianggap header.
+func bacaTabelImport(fh *multipart.FileHeader) ([][]string, error) {
+	if fh.Size > maksUkuranTabelImport {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "ukuran berkas maksimal 5 MB")
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed open berkas")
+	}
+	defer f.Close()
+
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(fh.Filename)) {
+	case ".csv":
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		for {
+			rec, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format CSV tidak valid: "+err.Error())
+			}
+			rows = append(rows, rec)
+		}
+	case ".xlsx":
+		x, err := excelize.OpenReader(f)
+		if err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "format XLSX tidak valid")
+		}
+		defer x.Close()
+		// Nilai mentah: sel tanggal tetap berupa nomor seri alih-alih teks hasil format
+		// lokal Excel yang urutan hari/bulannya tidak pasti.
+		if rows, err = x.GetRows(x.GetSheetName(0), excelize.Options{RawCellValue: true}); err != nil {
+			return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "gagal membaca sheet XLSX")
+		}
+	default:
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "berkas harus berformat CSV atau XLSX")
+	}
+
+	if len(rows) < 2 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "berkas tidak berisi data")
+	}
+	if len(rows)-1 > maksBarisImport {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "jumlah baris maksimal 5000")
+	}
+	return rows, nil
+}
+
+// indeksKolom memetakan nama kolom header (tanpa membedakan huruf besar/kecil) ke posisinya
+// dan mengembalikan error bila ada kolom wajib yang tidak ditemukan.
+func indeksKolom(header []string, wajib ...string) (map[string]int, error) {
+	idx := make(map[string]int, len(header))
+	for i, h := range header {
+		idx[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	var hilang []string
+	for _, k := range wajib {
+		if _, ok := idx[k]; !ok {
+			hilang = append(hilang, k)
+		}
+	}
+	if len(hilang) > 0 {
+		return nil, pkg.ExposeError(pkg.ErrorCodeInvalidArgument, "kolom wajib tidak ditemukan: "+strings.Join(hilang, ", "))
+	}
+	return idx, nil
+}
+
+// nilaiKolom mengambil nilai sel yang sudah di-trim; sel kosong di ujung baris dianggap "".
+func nilaiKolom(row []string, idx map[string]int, kolom string) string {
+	i, ok := idx[kolom]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
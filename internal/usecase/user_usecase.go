@@ -13,21 +13,25 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jinzhu/copier"
 	"github.com/matthewhartstonge/argon2"
 	"github.com/redis/go-redis/v9"
 )
 
 type UserUsecase interface {
-	LoginWithPassword(c context.Context, username string, password string) (resp.User, error)
-	Logout(c context.Context, id string) (any, error)
+	LoginWithPassword(c context.Context, username string, password string, perangkat request.Perangkat) (resp.User, error)
+	Logout(c context.Context, id string, sesi string) (any, error)
 	RegisterWithPassword(c context.Context, arg request.Register) (any, error)
-	Refresh(c context.Context, refresh string) (any, error)
+	Refresh(c context.Context, refresh string, perangkat request.Perangkat) (any, error)
 	AddRoleForUser(c context.Context, u pg.CreateUserRoleParams) (any, error)
 	AddMenu(c context.Context, arg pg.CreateR1ViewParams) (any, error)
 	ListMenu(c context.Context, arg request.SearchMenu) (any, error)
@@ -53,6 +57,14 @@ type UserUsecase interface {
 	GetViewByRoleId(c context.Context, arg int32) (any, error)
 	UpdateRolePolicy(c context.Context, arg request.UpdateRolePolicy) (any, error)
 	UserViewPermission(c context.Context, arg uuid.UUID) (any, error)
+	BukaKunciUser(c context.Context, id uuid.UUID, oleh *string) (any, error)
+	PaksaLogoutUser(c context.Context, id uuid.UUID, oleh *string) (any, error)
+	NonaktifkanUser(c context.Context, id uuid.UUID, oleh *string) (any, error)
+	ListSesi(c context.Context, userID uuid.UUID, sesiSaatIni string) (any, error)
+	CabutSesi(c context.Context, id uuid.UUID, userID uuid.UUID) (any, error)
+	GantiPassword(c context.Context, userID uuid.UUID, arg request.GantiPassword, perangkat request.Perangkat) (resp.User, error)
+	BuatResetPassword(c context.Context, id uuid.UUID, oleh *string) (any, error)
+	ResetPassword(c context.Context, arg request.ResetPassword) (any, error)
 }
 
 type UserUsecaseImpl struct {
@@ -61,19 +73,24 @@ type UserUsecaseImpl struct {
 	cfg   *config.Config
 	cache *pkg.RedisCache
 	cbn   *casbin.Enforcer
+	sesi  *pkg.SesiStore
+
+	oidcMu       sync.Mutex
+	oidcProvider *oidc.Provider
 }
 
-func NewUserUsecase(postgre *pkg.Postgres, cfg *config.Config, cache *pkg.RedisCache, cbn *casbin.Enforcer) *UserUsecaseImpl {
+func NewUserUsecase(postgre *pkg.Postgres, cfg *config.Config, cache *pkg.RedisCache, cbn *casbin.Enforcer, sesi *pkg.SesiStore) *UserUsecaseImpl {
 	return &UserUsecaseImpl{
 		db:    pg.New(postgre.Pool),
 		pg:    postgre,
 		cfg:   cfg,
 		cache: cache,
 		cbn:   cbn,
+		sesi:  sesi,
 	}
 }
 
-func (uu *UserUsecaseImpl) LoginWithPassword(c context.Context, username string, password string) (resp.User, error) {
+func (uu *UserUsecaseImpl) LoginWithPassword(c context.Context, username string, password string, perangkat request.Perangkat) (resp.User, error) {
 
 	var err error
 
@@ -87,6 +104,11 @@ func (uu *UserUsecaseImpl) LoginWithPassword(c context.Context, username string,
 		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed login")
 	}
 
+	// 🔒 Akun yang sedang terkunci ditolak sebelum password diperiksa
+	if res.LockedUntil.Valid && res.LockedUntil.Time.After(time.Now()) {
+		return resp.User{}, errAkunTerkunci(res.LockedUntil.Time)
+	}
+
 	if res.Password == "" {
 		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeNotFound, "password empty")
 	}
@@ -97,94 +119,104 @@ func (uu *UserUsecaseImpl) LoginWithPassword(c context.Context, username string,
 		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeNotFound, "verify password error")
 	}
 	if !ok {
+		if err := uu.catatGagalLogin(c, res.ID); err != nil {
+			return resp.User{}, err
+		}
 		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeNotFound, "password invalid")
 	}
+	if (res.FailedAttempts != nil && *res.FailedAttempts > 0) || res.LockedUntil.Valid {
+		if _, err := uu.db.ResetGagalLogin(c, pg.ResetGagalLoginParams{ID: res.ID}); err != nil {
+			return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed reset gagal login")
+		}
+	}
 
-	sessionId := pkg.NewUlid()
+	return uu.terbitkanToken(c, res, perangkat)
+}
 
-	user := entity.User{
-		ID:       res.ID.String(),
-		Username: res.Username,
-		Nama:     res.Nama,
-		Role:     res.Role,
-		Session:  sessionId,
-	}
-	//Generated Access Token
-	accessToken, accessExp, err := pkg.CreateAccessToken(
-		user,
-		uu.cfg.JWT.AccessTokenSecret,
-		uu.cfg.JWT.AccessTokenExpireHour)
-	if err != nil {
-		// uc.Log.Error(logging.JWT, logging.GenerateToken, err.Error(), nil)
-		return resp.User{}, err
+// terbitkanToken membuka sesi baru untuk perangkat yang login, baik lewat password maupun SSO,
+// dan menerbitkan access serta refresh token untuk sesi tersebut.
+func (uu *UserUsecaseImpl) terbitkanToken(c context.Context, res pg.UsersFindByUsernameRow, perangkat request.Perangkat) (resp.User, error) {
+	if !res.IsActive {
+		return resp.User{}, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "akun tidak aktif")
 	}
-	//remove session from refresh token
-	user.Session = ""
-	//Generated Refresh Token
-	refreshToken, _, err := pkg.CreateRefreshToken(
-		user,
-		uu.cfg.JWT.RefreshTokenSecret,
-		uu.cfg.JWT.RefreshTokenExpireHour)
+
+	sesiID, err := uuid.NewV7()
 	if err != nil {
-		// uc.Log.Error(logging.JWT, logging.GenerateToken, err.Error(), nil)
-		return resp.User{}, err
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create sesi")
 	}
 
-	view, err := uu.db.UserViewPermission(c, res.ID)
+	user := entity.User{
+		ID:            res.ID.String(),
+		Username:      res.Username,
+		Nama:          res.Nama,
+		Role:          res.Role,
+		Session:       sesiID.String(),
+		GantiPassword: res.WajibGantiPassword,
+	}
+	token, err := uu.buatToken(c, user)
 	if err != nil {
-		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed get menu")
+		return resp.User{}, err
 	}
 
-	expire := time.Duration(uu.cfg.JWT.AccessTokenExpireHour)*time.Minute - 1*time.Minute
-
-	redisKey := fmt.Sprintf("view:%d", res.ID)
-
-	uu.cache.SetWithTTL(c, redisKey, view, time.Duration(uu.cfg.JWT.AccessTokenExpireHour)*time.Minute)
-
-	uu.cache.SetWithTTL(c, sessionId, sessionId, expire)
-
-	arg := pg.UpdateUserPartialParams{
-		ID:      res.ID,
-		Refresh: &refreshToken,
+	userAgent, ip := perangkatSesi(perangkat)
+	if _, err := uu.db.CreateUserSesi(c, pg.CreateUserSesiParams{
+		ID:          sesiID,
+		UserID:      res.ID,
+		RefreshHash: hashToken(token.refresh),
+		UserAgent:   userAgent,
+		IpAddress:   ip,
+		ExpiresAt:   pgtype.Timestamptz{Time: time.Unix(token.refreshExp, 0), Valid: true},
+	}); err != nil {
+		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed create sesi")
 	}
-	err = uu.db.UpdateUserPartial(c, arg)
 
 	return resp.User{
 			ID:                 res.ID.String(),
 			Username:           res.Username,
 			Nama:               res.Nama,
 			Role:               res.Role,
-			AccessToken:        accessToken,
-			RefreshToken:       refreshToken,
-			AccessTokenExpires: accessExp,
+			AccessToken:        token.access,
+			RefreshToken:       token.refresh,
+			AccessTokenExpires: token.accessExp,
+			WajibGantiPassword: res.WajibGantiPassword,
 		},
 		nil
 
 }
 
 func (uu *UserUsecaseImpl) RegisterWithPassword(c context.Context, arg request.Register) (any, error) {
+	if err := validasiPassword(uu.cfg.Password, arg.Password); err != nil {
+		return nil, err
+	}
+
 	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
 		var err error
 		///TODO -  (maps recipe user ID to primary user ID)
 		// userid := pkg.NewUlid()
-		user := pg.CreateOrUpdateUserParams{
-			Username: arg.Username,
-			Nama:     arg.Nama,
-			Password: arg.Password,
+		user := pg.CreateUserParams{
+			Username:  arg.Username,
+			Nama:      arg.Nama,
+			CreatedBy: arg.CreatedBy,
 			// 	Role:     uuid.Must(uuid.FromString(u.Role)
 			// ),
 		}
-		config := argon2.DefaultConfig()
-
-		encoded, err := config.HashEncoded([]byte("12345678"))
+		// password dari admin bersifat sementara, user wajib menggantinya saat login pertama
+		user.Password, err = hashPassword(arg.Password)
 		if err != nil {
-			panic(err) // 💥
+			return nil, err
 		}
-		user.Password = string(encoded)
-		res, err := qtx.CreateOrUpdateUser(c, user)
+		// Username yang sudah dipakai ditolak; password user lain hanya berubah lewat simpanPassword
+		res, err := qtx.CreateUser(c, user)
 		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, pkg.ExposeError(pkg.ErrorCodeConflict, "username sudah terdaftar")
+			}
 			return nil, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed register")
 		}
+		if err := qtx.CreateRiwayatPassword(c, pg.CreateRiwayatPasswordParams{UserID: res.ID, Password: user.Password}); err != nil {
+			return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed simpan riwayat password")
+		}
 
 		// 5️⃣ Tambahkan role baru di SQL + Casbin
 		for _, roleID := range arg.Role {
@@ -203,13 +235,16 @@ func (uu *UserUsecaseImpl) RegisterWithPassword(c context.Context, arg request.R
 			}
 		}
 
+		res.Password = ""
 		return res, nil
 	})
 }
 
-func (uu *UserUsecaseImpl) Refresh(c context.Context, refresh string) (any, error) {
+// Refresh merotasi refresh token: setiap token hanya berlaku sekali dan diganti token baru pada
+// sesi yang sama. Token lama yang dipakai lagi membuat sesi tersebut dicabut.
+func (uu *UserUsecaseImpl) Refresh(c context.Context, refresh string, perangkat request.Perangkat) (any, error) {
 
-	isAuthorize, _, err := pkg.IsAuthorized(refresh, uu.cfg.JWT.RefreshTokenSecret)
+	isAuthorize, claims, err := pkg.IsAuthorized(refresh, uu.cfg.JWT.RefreshTokenSecret)
 	if err != nil {
 		return nil, pkg.WrapError(err, pkg.ErrorCodeUnknown, "checking auth failed")
 	}
@@ -217,68 +252,90 @@ func (uu *UserUsecaseImpl) Refresh(c context.Context, refresh string) (any, erro
 	if !isAuthorize {
 		return nil, pkg.WrapError(err, pkg.ErrorCodeInvalidArgument, "UnAuthorize")
 	}
-	id, err := pkg.ExtractIDFromToken(refresh, uu.cfg.JWT.RefreshTokenSecret)
-	// 3. Convert string -> UUID safely
-	// userUUID, err := uuid.FromString(id)
-	// if err != nil {
-	// 	return nil, pkg.WrapError(err, pkg.ErrorCodeInvalidArgument, "invalid UUID in token")
-	// }
-	res, err := uu.db.UsersFindById(c, uuid.Must(uuid.FromString(id)))
+
+	sesiID, err := uuid.FromString(claims.Session)
 	if err != nil {
-		return nil, err
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "refresh token tidak memiliki sesi")
 	}
-	if res.Refresh == nil {
-		return nil, pkg.WrapError(nil, pkg.ErrorCodeInvalidArgument, "user has no refresh token stored")
+	sesi, err := uu.db.GetUserSesi(c, sesiID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "sesi tidak ditemukan")
+		}
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed get sesi")
 	}
-	//Return false when refresh token doesn't match
-	if *res.Refresh != refresh {
-		return nil, errors.New("token doesn't match")
+	if sesi.UserID.String() != claims.Subject || sesi.RevokedAt.Valid || !sesi.ExpiresAt.Time.After(time.Now()) {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "sesi sudah berakhir")
 	}
 
-	sessionId := pkg.NewUlid()
-
-	user := entity.User{
-		ID:       res.ID.String(),
-		Username: res.Username,
-		Nama:     res.Nama,
-		Role:     res.Role,
-		Session:  sessionId,
+	hash := hashToken(refresh)
+	if sesi.RefreshHash != hash {
+		return nil, uu.cabutSesiBocor(c, sesi.ID)
 	}
 
-	access, exp, err := pkg.CreateAccessToken(user,
-		uu.cfg.JWT.AccessTokenSecret,
-		uu.cfg.JWT.AccessTokenExpireHour)
+	res, err := uu.db.UsersFindById(c, sesi.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	view, err := uu.db.UserViewPermission(c, res.ID)
+	user := entity.User{
+		ID:            res.ID.String(),
+		Username:      res.Username,
+		Nama:          res.Nama,
+		Role:          res.Role,
+		Session:       sesi.ID.String(),
+		GantiPassword: res.WajibGantiPassword,
+	}
+	token, err := uu.buatToken(c, user)
 	if err != nil {
-		return resp.User{}, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed get menu")
+		return nil, err
 	}
 
-	redisKey := fmt.Sprintf("view:%d", res.ID)
-
-	uu.cache.SetWithTTL(c, redisKey, view, time.Duration(uu.cfg.JWT.AccessTokenExpireHour)*time.Minute)
+	userAgent, ip := perangkatSesi(perangkat)
+	n, err := uu.db.RotasiUserSesi(c, pg.RotasiUserSesiParams{
+		RefreshHashBaru: hashToken(token.refresh),
+		ExpiresAt:       pgtype.Timestamptz{Time: time.Unix(token.refreshExp, 0), Valid: true},
+		UserAgent:       userAgent,
+		IpAddress:       ip,
+		ID:              sesi.ID,
+		RefreshHash:     hash,
+	})
+	if err != nil {
+		return nil, pkg.WrapError(err, pkg.ErrorCodeInternal, "failed rotasi sesi")
+	}
+	// token yang sama dipakai bersamaan: hanya satu yang boleh menang
+	if n == 0 {
+		return nil, uu.cabutSesiBocor(c, sesi.ID)
+	}
 
 	return resp.User{
 		ID:                 res.ID.String(),
 		Username:           res.Username,
 		Nama:               res.Nama,
 		Role:               res.Role,
-		AccessToken:        access,
-		RefreshToken:       utils.DerefString(res.Refresh),
-		AccessTokenExpires: exp,
+		AccessToken:        token.access,
+		RefreshToken:       token.refresh,
+		AccessTokenExpires: token.accessExp,
+		WajibGantiPassword: res.WajibGantiPassword,
 	}, nil
 }
 
-func (uu *UserUsecaseImpl) Logout(c context.Context, id string) (any, error) {
+func (uu *UserUsecaseImpl) Logout(c context.Context, id string, sesi string) (any, error) {
 
-	var err error
-	err = uu.db.UpdateUserPartial(c, pg.UpdateUserPartialParams{ID: uuid.Must(uuid.FromString(id)), Refresh: utils.StringPtr(""), UpdatedNote: utils.StringPtr("logout")})
+	sesiID, err := uuid.FromString(sesi)
+	if err != nil {
+		return nil, pkg.ExposeError(pkg.ErrorCodeUnauthorized, "token tidak memiliki sesi")
+	}
+	userID := uuid.Must(uuid.FromString(id))
+	_, err = uu.db.CabutUserSesi(c, pg.CabutUserSesiParams{
+		RevokedReason: utils.StringPtr("logout"),
+		ID:            sesiID,
+		UserID:        &userID,
+	})
 	if err != nil {
 		return nil, err
 	}
+	uu.sesi.Cabut(c, sesi)
 
 	return nil, nil
 }
@@ -549,17 +606,45 @@ func (uu *UserUsecaseImpl) ListUser(c context.Context, arg request.SearchUser) (
 }
 
 func (uu *UserUsecaseImpl) UpdateUserPartial(c context.Context, arg request.UpdateUser) (any, error) {
+	if arg.Password != nil {
+		if err := validasiPassword(uu.cfg.Password, *arg.Password); err != nil {
+			return nil, err
+		}
+	}
+
 	return utils.WithTransactionResult(c, uu.pg.Pool, func(qtx *pg.Queries, tx pgx.Tx) (any, error) {
 		// 1️⃣ Update user partial fields
 		var params pg.UpdateUserPartialParams
 		if err := copier.Copy(&params, &arg); err != nil {
 			return nil, err
 		}
+		if arg.IsActive != nil && !*arg.IsActive {
+			if err := uu.cekAdminTerakhir(c, qtx, arg.ID); err != nil {
+				return nil, err
+			}
+		}
 
 		if err := qtx.UpdateUserPartial(c, params); err != nil {
 			return nil, pkg.WrapError(err, pkg.ErrorCodeUnknown, "failed update user")
 		}
 
+		// Password yang diatur admin bersifat sementara dan mengeluarkan user dari semua perangkat
+		if arg.Password != nil {
+			if err := uu.simpanPassword(c, qtx, arg.ID, *arg.Password, true, arg.UpdatedBy); err != nil {
+				return nil, err
+			}
+			if _, err := uu.cabutSemuaSesi(c, qtx, arg.ID, "password diubah admin"); err != nil {
+				return nil, err
+			}
+		}
+
+		// User yang dinonaktifkan langsung dikeluarkan dari semua perangkat
+		if arg.IsActive != nil && !*arg.IsActive {
+			if _, err := uu.cabutSemuaSesi(c, qtx, arg.ID, "user dinonaktifkan"); err != nil {
+				return nil, err
+			}
+		}
+
 		// 2️⃣ Persiapkan list role dari input
 		valuesStr := make([]string, len(arg.Role))
 		valuesInt := make([]int32, len(arg.Role))
@@ -0,0 +1,6 @@
+ALTER TABLE public.kehadiran
+    DROP COLUMN IF EXISTS status_lokasi,
+    DROP COLUMN IF EXISTS jarak_meter,
+    DROP COLUMN IF EXISTS akurasi,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
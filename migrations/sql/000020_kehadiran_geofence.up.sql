@@ -0,0 +1,6 @@
+ALTER TABLE public.kehadiran
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS akurasi DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS jarak_meter DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS status_lokasi VARCHAR;
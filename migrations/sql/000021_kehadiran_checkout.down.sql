@@ -0,0 +1,4 @@
+ALTER TABLE public.kehadiran
+    DROP COLUMN IF EXISTS pulang_awal,
+    DROP COLUMN IF EXISTS durasi_menit,
+    DROP COLUMN IF EXISTS jam_pulang;
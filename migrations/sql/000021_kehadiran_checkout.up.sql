@@ -0,0 +1,4 @@
+ALTER TABLE public.kehadiran
+    ADD COLUMN IF NOT EXISTS jam_pulang TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS durasi_menit INTEGER,
+    ADD COLUMN IF NOT EXISTS pulang_awal BOOLEAN;
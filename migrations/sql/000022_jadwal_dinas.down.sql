@@ -0,0 +1,5 @@
+ALTER TABLE public.kehadiran
+    DROP COLUMN IF EXISTS jadwal_id;
+
+DROP TABLE IF EXISTS jadwal_dinas_mahasiswa;
+DROP TABLE IF EXISTS shift;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS shift (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kode VARCHAR NOT NULL,
+    nama TEXT NOT NULL,
+    jam_mulai TIME NOT NULL,
+    jam_selesai TIME NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_shift_kode
+    ON shift (kode) WHERE deleted_at IS NULL;
+
+INSERT INTO shift (kode, nama, jam_mulai, jam_selesai, created_by) VALUES
+    ('pagi', 'Dinas Pagi', '07:00', '14:00', 'system'),
+    ('siang', 'Dinas Siang', '14:00', '21:00', 'system'),
+    ('malam', 'Dinas Malam', '21:00', '07:00', 'system')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS jadwal_dinas_mahasiswa (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL,
+    ruangan_id UUID NOT NULL REFERENCES ruangan (id),
+    shift_id UUID NOT NULL REFERENCES shift (id),
+    tgl_dinas DATE NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_jadwal_dinas_user_tgl
+    ON jadwal_dinas_mahasiswa (user_id, tgl_dinas) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jadwal_dinas_ruangan_tgl
+    ON jadwal_dinas_mahasiswa (ruangan_id, tgl_dinas);
+
+ALTER TABLE public.kehadiran
+    ADD COLUMN IF NOT EXISTS jadwal_id UUID REFERENCES jadwal_dinas_mahasiswa (id);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS pengajuan_izin (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL,
+    fasilitas_id UUID NOT NULL,
+    kontrak_id UUID NOT NULL,
+    ruangan_id UUID NOT NULL,
+    mata_kuliah_id UUID NOT NULL,
+    pembimbing_id UUID NOT NULL,
+    pembimbing_klinik UUID NOT NULL,
+    jenis VARCHAR NOT NULL CHECK (jenis IN ('izin', 'sakit')),
+    tgl_mulai DATE NOT NULL,
+    tgl_selesai DATE NOT NULL,
+    alasan TEXT NOT NULL,
+    lampiran_key TEXT,
+    lampiran_nama TEXT,
+    lampiran_tipe VARCHAR,
+    status VARCHAR NOT NULL DEFAULT 'menunggu',
+    catatan_review TEXT,
+    reviewed_by VARCHAR,
+    reviewed_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT pengajuan_izin_tgl_check CHECK (tgl_selesai >= tgl_mulai)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pengajuan_izin_user ON pengajuan_izin (user_id, tgl_mulai);
+CREATE INDEX IF NOT EXISTS idx_pengajuan_izin_pembimbing ON pengajuan_izin (pembimbing_id, status);
+CREATE INDEX IF NOT EXISTS idx_pengajuan_izin_pembimbing_klinik ON pengajuan_izin (pembimbing_klinik, status);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_riwayat_kehadiran_immutable ON riwayat_kehadiran;
+DROP FUNCTION IF EXISTS tolak_ubah_riwayat_kehadiran();
+DROP TABLE IF EXISTS riwayat_kehadiran;
+DROP TABLE IF EXISTS koreksi_kehadiran;
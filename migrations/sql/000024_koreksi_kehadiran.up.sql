@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS koreksi_kehadiran (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_id UUID NOT NULL REFERENCES kehadiran (id),
+    diajukan_oleh UUID NOT NULL,
+    presensi VARCHAR,
+    ruangan_id UUID REFERENCES ruangan (id),
+    tgl_kehadiran DATE,
+    alasan TEXT NOT NULL,
+    status VARCHAR NOT NULL DEFAULT 'menunggu',
+    catatan_review TEXT,
+    reviewer_id UUID,
+    reviewed_by VARCHAR,
+    reviewed_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT koreksi_kehadiran_perubahan_check CHECK (
+        presensi IS NOT NULL OR ruangan_id IS NOT NULL OR tgl_kehadiran IS NOT NULL
+    )
+);
+
+-- Satu kehadiran hanya boleh memiliki satu koreksi yang menunggu review
+CREATE UNIQUE INDEX IF NOT EXISTS uq_koreksi_kehadiran_menunggu
+    ON koreksi_kehadiran (kehadiran_id) WHERE status = 'menunggu' AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS riwayat_kehadiran (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_id UUID NOT NULL REFERENCES kehadiran (id),
+    koreksi_id UUID REFERENCES koreksi_kehadiran (id),
+    sebelum JSONB NOT NULL,
+    sesudah JSONB NOT NULL,
+    alasan TEXT,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_riwayat_kehadiran_kehadiran
+    ON riwayat_kehadiran (kehadiran_id, created_at);
+
+-- Riwayat perubahan kehadiran tidak boleh diubah maupun dihapus
+CREATE OR REPLACE FUNCTION tolak_ubah_riwayat_kehadiran() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'riwayat_kehadiran bersifat immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_riwayat_kehadiran_immutable
+    BEFORE UPDATE OR DELETE ON riwayat_kehadiran
+    FOR EACH ROW EXECUTE FUNCTION tolak_ubah_riwayat_kehadiran();
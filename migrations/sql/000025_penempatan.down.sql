@@ -0,0 +1,4 @@
+ALTER TABLE public.kehadiran
+    DROP COLUMN IF EXISTS penempatan_id;
+
+DROP TABLE IF EXISTS penempatan;
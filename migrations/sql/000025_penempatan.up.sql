@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS penempatan (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id),
+    fasilitas_id UUID NOT NULL REFERENCES fasilitas_kesehatan (id),
+    kontrak_id UUID NOT NULL REFERENCES kontrak (id),
+    ruangan_id UUID NOT NULL REFERENCES ruangan (id),
+    mata_kuliah_id UUID NOT NULL REFERENCES mata_kuliah (id),
+    pembimbing_id UUID NOT NULL REFERENCES users (id),
+    pembimbing_klinik UUID NOT NULL REFERENCES users (id),
+    tgl_mulai DATE NOT NULL,
+    tgl_selesai DATE NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT penempatan_tgl_check CHECK (tgl_selesai >= tgl_mulai)
+);
+
+CREATE INDEX IF NOT EXISTS idx_penempatan_user_tgl
+    ON penempatan (user_id, tgl_mulai, tgl_selesai) WHERE deleted_at IS NULL;
+
+ALTER TABLE public.kehadiran
+    ADD COLUMN IF NOT EXISTS penempatan_id UUID REFERENCES penempatan (id);
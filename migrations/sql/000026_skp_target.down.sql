@@ -0,0 +1 @@
+DROP TABLE IF EXISTS skp_target;
@@ -0,0 +1,28 @@
+-- Target jumlah capaian SKP per mata kuliah, untuk satu intervensi atau satu subkategori
+CREATE TABLE IF NOT EXISTS skp_target (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    mata_kuliah_id UUID NOT NULL REFERENCES mata_kuliah (id),
+    skp_intervensi_id UUID REFERENCES skp_intervensi (id),
+    skp_subkategori_id UUID REFERENCES skp_subkategori (id),
+    jumlah_target INTEGER NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    updated_note TEXT,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT skp_target_jumlah_check CHECK (jumlah_target > 0),
+    CONSTRAINT skp_target_lingkup_check CHECK (
+        (skp_intervensi_id IS NULL) <> (skp_subkategori_id IS NULL)
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skp_target_intervensi
+    ON skp_target (mata_kuliah_id, skp_intervensi_id)
+    WHERE deleted_at IS NULL AND skp_intervensi_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skp_target_subkategori
+    ON skp_target (mata_kuliah_id, skp_subkategori_id)
+    WHERE deleted_at IS NULL AND skp_subkategori_id IS NOT NULL;
@@ -0,0 +1,43 @@
+ALTER TABLE public.skp_subkategori
+    DROP CONSTRAINT IF EXISTS skp_subkategori_kategori_id_fkey,
+    ADD CONSTRAINT skp_subkategori_kategori_id_fkey
+        FOREIGN KEY (kategori_id) REFERENCES skp_kategori (id) ON DELETE CASCADE;
+
+ALTER TABLE public.skp_intervensi
+    DROP CONSTRAINT IF EXISTS skp_intervensi_kategori_id_fkey,
+    DROP CONSTRAINT IF EXISTS skp_intervensi_subkategori_id_fkey,
+    ADD CONSTRAINT skp_intervensi_kategori_id_fkey
+        FOREIGN KEY (kategori_id) REFERENCES skp_kategori (id) ON DELETE CASCADE,
+    ADD CONSTRAINT skp_intervensi_subkategori_id_fkey
+        FOREIGN KEY (subkategori_id) REFERENCES skp_subkategori (id) ON DELETE CASCADE;
+
+ALTER TABLE public.kehadiran_skp
+    DROP CONSTRAINT IF EXISTS kehadiran_skp_skp_intervensi_id_fkey,
+    ADD CONSTRAINT kehadiran_skp_skp_intervensi_id_fkey
+        FOREIGN KEY (skp_intervensi_id) REFERENCES skp_intervensi (id) ON DELETE CASCADE;
+
+ALTER TABLE public.skp_intervensi
+    DROP COLUMN IF EXISTS akar_id,
+    DROP COLUMN IF EXISTS versi,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS urutan;
+
+ALTER TABLE public.skp_subkategori
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS urutan;
+
+ALTER TABLE public.skp_kategori
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS urutan;
@@ -0,0 +1,47 @@
+-- Kolom pengelolaan katalog SKP: urutan tampil, status aktif & audit
+ALTER TABLE public.skp_kategori
+    ADD COLUMN IF NOT EXISTS urutan INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS updated_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT now();
+
+ALTER TABLE public.skp_subkategori
+    ADD COLUMN IF NOT EXISTS urutan INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS updated_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT now();
+
+-- Intervensi yang sudah dipakai tidak diubah di tempat: perubahan membuat versi baru
+-- dengan akar_id menunjuk versi pertama, versi lama dinonaktifkan
+ALTER TABLE public.skp_intervensi
+    ADD COLUMN IF NOT EXISTS urutan INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS updated_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS versi INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS akar_id UUID REFERENCES skp_intervensi (id);
+
+-- Riwayat kehadiran_skp tidak boleh ikut terhapus bersama katalog
+ALTER TABLE public.kehadiran_skp
+    DROP CONSTRAINT IF EXISTS kehadiran_skp_skp_intervensi_id_fkey,
+    ADD CONSTRAINT kehadiran_skp_skp_intervensi_id_fkey
+        FOREIGN KEY (skp_intervensi_id) REFERENCES skp_intervensi (id) ON DELETE RESTRICT;
+
+ALTER TABLE public.skp_intervensi
+    DROP CONSTRAINT IF EXISTS skp_intervensi_kategori_id_fkey,
+    DROP CONSTRAINT IF EXISTS skp_intervensi_subkategori_id_fkey,
+    ADD CONSTRAINT skp_intervensi_kategori_id_fkey
+        FOREIGN KEY (kategori_id) REFERENCES skp_kategori (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT skp_intervensi_subkategori_id_fkey
+        FOREIGN KEY (subkategori_id) REFERENCES skp_subkategori (id) ON DELETE RESTRICT;
+
+ALTER TABLE public.skp_subkategori
+    DROP CONSTRAINT IF EXISTS skp_subkategori_kategori_id_fkey,
+    ADD CONSTRAINT skp_subkategori_kategori_id_fkey
+        FOREIGN KEY (kategori_id) REFERENCES skp_kategori (id) ON DELETE RESTRICT;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS kehadiran_skp_lampiran;
+
+ALTER TABLE public.kehadiran_skp
+    DROP COLUMN IF EXISTS waktu_tindakan,
+    DROP COLUMN IF EXISTS kamar_bed,
+    DROP COLUMN IF EXISTS kelompok_usia,
+    DROP COLUMN IF EXISTS inisial_pasien,
+    DROP COLUMN IF EXISTS narasi;
@@ -0,0 +1,23 @@
+-- Bukti & konteks klinis setiap tindakan SKP. Identitas pasien hanya disimpan dalam
+-- bentuk anonim (inisial, kelompok usia, ruang/bed).
+ALTER TABLE public.kehadiran_skp
+    ADD COLUMN IF NOT EXISTS narasi TEXT,
+    ADD COLUMN IF NOT EXISTS inisial_pasien VARCHAR(5),
+    ADD COLUMN IF NOT EXISTS kelompok_usia VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS kamar_bed VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS waktu_tindakan TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS kehadiran_skp_lampiran (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_skp_id UUID NOT NULL REFERENCES kehadiran_skp (id) ON DELETE CASCADE,
+    lampiran_key TEXT NOT NULL,
+    lampiran_nama TEXT NOT NULL,
+    lampiran_tipe VARCHAR NOT NULL,
+    deleted_by VARCHAR,
+    deleted_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kehadiran_skp_lampiran_skp
+    ON kehadiran_skp_lampiran (kehadiran_skp_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trg_kehadiran_skp_keputusan_immutable ON kehadiran_skp_keputusan;
+DROP FUNCTION IF EXISTS tolak_ubah_kehadiran_skp_keputusan();
+DROP TABLE IF EXISTS kehadiran_skp_keputusan;
+
+ALTER TABLE public.kehadiran_skp
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS reviewer_id,
+    DROP COLUMN IF EXISTS catatan_review;
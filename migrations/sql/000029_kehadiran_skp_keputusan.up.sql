@@ -0,0 +1,32 @@
+-- Keputusan pembimbing per tindakan SKP: alasan, peninjau & waktu keputusan terakhir.
+-- Status 'revisi' mengembalikan tindakan ke mahasiswa tanpa dikunci.
+ALTER TABLE public.kehadiran_skp
+    ADD COLUMN IF NOT EXISTS catatan_review TEXT,
+    ADD COLUMN IF NOT EXISTS reviewer_id UUID,
+    ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR,
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ;
+
+-- Riwayat setiap keputusan (setujui, tolak, kembalikan untuk revisi).
+CREATE TABLE IF NOT EXISTS kehadiran_skp_keputusan (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_skp_id UUID NOT NULL REFERENCES kehadiran_skp (id) ON DELETE RESTRICT,
+    status VARCHAR NOT NULL CHECK (status IN ('disetujui', 'ditolak', 'revisi')),
+    catatan_review TEXT,
+    reviewer_id UUID NOT NULL,
+    reviewed_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kehadiran_skp_keputusan_skp
+    ON kehadiran_skp_keputusan (kehadiran_skp_id, created_at);
+
+-- Riwayat keputusan tidak boleh diubah maupun dihapus
+CREATE OR REPLACE FUNCTION tolak_ubah_kehadiran_skp_keputusan() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'kehadiran_skp_keputusan bersifat immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_kehadiran_skp_keputusan_immutable
+    BEFORE UPDATE OR DELETE ON kehadiran_skp_keputusan
+    FOR EACH ROW EXECUTE FUNCTION tolak_ubah_kehadiran_skp_keputusan();
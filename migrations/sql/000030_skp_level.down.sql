@@ -0,0 +1,10 @@
+ALTER TABLE public.kehadiran_skp_keputusan
+    DROP COLUMN IF EXISTS level_id;
+
+ALTER TABLE public.kehadiran_skp
+    DROP COLUMN IF EXISTS level_id;
+
+ALTER TABLE public.skp_intervensi
+    DROP COLUMN IF EXISTS level_minimal_id;
+
+DROP TABLE IF EXISTS skp_level;
@@ -0,0 +1,31 @@
+-- Skala tingkat kompetensi yang diberikan pembimbing saat menyetujui tindakan SKP.
+-- Semakin besar urutan, semakin tinggi tingkat kemandirian.
+CREATE TABLE IF NOT EXISTS skp_level (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kode VARCHAR NOT NULL,
+    nama VARCHAR NOT NULL,
+    urutan INTEGER NOT NULL,
+    deskripsi TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    updated_by VARCHAR,
+    updated_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT skp_level_kode_unique UNIQUE (kode)
+);
+
+INSERT INTO skp_level (kode, nama, urutan, deskripsi, created_by) VALUES
+    ('observasi', 'Observasi', 1, 'Mahasiswa mengamati tindakan yang dilakukan pembimbing', 'system'),
+    ('bantuan', 'Dengan Bantuan', 2, 'Mahasiswa melakukan tindakan dengan bantuan pembimbing', 'system'),
+    ('mandiri', 'Mandiri', 3, 'Mahasiswa melakukan tindakan secara mandiri di bawah supervisi', 'system')
+ON CONFLICT (kode) DO NOTHING;
+
+-- Tingkat minimal agar tindakan dihitung tercapai; NULL berarti cukup disetujui
+ALTER TABLE public.skp_intervensi
+    ADD COLUMN IF NOT EXISTS level_minimal_id UUID REFERENCES skp_level (id) ON DELETE RESTRICT;
+
+ALTER TABLE public.kehadiran_skp
+    ADD COLUMN IF NOT EXISTS level_id UUID REFERENCES skp_level (id) ON DELETE RESTRICT;
+
+ALTER TABLE public.kehadiran_skp_keputusan
+    ADD COLUMN IF NOT EXISTS level_id UUID;
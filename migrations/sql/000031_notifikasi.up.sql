@@ -0,0 +1,15 @@
+-- Notifikasi dalam aplikasi untuk mahasiswa dan pembimbing.
+CREATE TABLE IF NOT EXISTS notifikasi (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id),
+    jenis VARCHAR NOT NULL,
+    judul VARCHAR NOT NULL,
+    pesan TEXT NOT NULL,
+    ref_id UUID,
+    dibaca_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifikasi_user
+    ON notifikasi (user_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_pembukaan_kehadiran_skp_immutable ON pembukaan_kehadiran_skp;
+DROP FUNCTION IF EXISTS tolak_ubah_pembukaan_kehadiran_skp();
+DROP TABLE IF EXISTS pembukaan_kehadiran_skp;
@@ -0,0 +1,25 @@
+-- Pembukaan kembali SKP yang sudah dikunci oleh admin/koordinator.
+-- Snapshot kehadiran dan seluruh tindakan SKP sebelum dibuka disimpan apa adanya.
+CREATE TABLE IF NOT EXISTS pembukaan_kehadiran_skp (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_id UUID NOT NULL REFERENCES kehadiran (id),
+    alasan TEXT NOT NULL CHECK (btrim(alasan) <> ''),
+    sebelum JSONB NOT NULL,
+    dibuka_oleh UUID NOT NULL,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pembukaan_kehadiran_skp_kehadiran
+    ON pembukaan_kehadiran_skp (kehadiran_id, created_at);
+
+-- Riwayat pembukaan tidak boleh diubah maupun dihapus
+CREATE OR REPLACE FUNCTION tolak_ubah_pembukaan_kehadiran_skp() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pembukaan_kehadiran_skp bersifat immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pembukaan_kehadiran_skp_immutable
+    BEFORE UPDATE OR DELETE ON pembukaan_kehadiran_skp
+    FOR EACH ROW EXECUTE FUNCTION tolak_ubah_pembukaan_kehadiran_skp();
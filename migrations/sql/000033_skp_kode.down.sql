@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS uq_skp_intervensi_kode_aktif;
+DROP INDEX IF EXISTS uq_skp_subkategori_kode;
+DROP INDEX IF EXISTS uq_skp_kategori_kode;
+
+ALTER TABLE public.skp_intervensi
+    DROP COLUMN IF EXISTS kode;
+
+ALTER TABLE public.skp_subkategori
+    DROP COLUMN IF EXISTS kode;
+
+ALTER TABLE public.skp_kategori
+    DROP COLUMN IF EXISTS kode;
@@ -0,0 +1,19 @@
+-- Kode katalog SKP untuk mencocokkan item saat impor katalog kurikulum baru.
+ALTER TABLE public.skp_kategori
+    ADD COLUMN IF NOT EXISTS kode VARCHAR;
+
+ALTER TABLE public.skp_subkategori
+    ADD COLUMN IF NOT EXISTS kode VARCHAR;
+
+ALTER TABLE public.skp_intervensi
+    ADD COLUMN IF NOT EXISTS kode VARCHAR;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skp_kategori_kode
+    ON skp_kategori (kode) WHERE kode IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skp_subkategori_kode
+    ON skp_subkategori (kode) WHERE kode IS NOT NULL;
+
+-- Versi intervensi berbagi kode yang sama; hanya satu versi yang boleh aktif
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skp_intervensi_kode_aktif
+    ON skp_intervensi (kode) WHERE kode IS NOT NULL AND is_active = true;
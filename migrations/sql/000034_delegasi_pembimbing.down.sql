@@ -0,0 +1,5 @@
+ALTER TABLE public.kehadiran_skp_keputusan
+    DROP COLUMN IF EXISTS delegasi_pembimbing_id,
+    DROP COLUMN IF EXISTS atas_nama_id;
+
+DROP TABLE IF EXISTS delegasi_pembimbing;
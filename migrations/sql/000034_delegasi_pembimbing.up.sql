@@ -0,0 +1,30 @@
+-- Delegasi persetujuan pembimbing klinik ke pembimbing lain pada kontrak yang sama
+-- selama rentang tanggal tertentu (mis. cuti).
+CREATE TABLE IF NOT EXISTS delegasi_pembimbing (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kontrak_id UUID NOT NULL,
+    pembimbing_id UUID NOT NULL REFERENCES users (id),
+    delegasi_id UUID NOT NULL REFERENCES users (id),
+    tgl_mulai DATE NOT NULL,
+    tgl_selesai DATE NOT NULL,
+    alasan TEXT,
+    dicabut_by VARCHAR,
+    dicabut_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ck_delegasi_pembimbing_rentang CHECK (tgl_selesai >= tgl_mulai),
+    CONSTRAINT ck_delegasi_pembimbing_beda CHECK (delegasi_id <> pembimbing_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegasi_pembimbing_delegasi
+    ON delegasi_pembimbing (delegasi_id, kontrak_id, tgl_mulai, tgl_selesai)
+    WHERE dicabut_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_delegasi_pembimbing_pembimbing
+    ON delegasi_pembimbing (pembimbing_id, kontrak_id, tgl_mulai, tgl_selesai)
+    WHERE dicabut_at IS NULL;
+
+-- Keputusan yang diambil penerima delegasi mencatat pembimbing aslinya.
+ALTER TABLE public.kehadiran_skp_keputusan
+    ADD COLUMN IF NOT EXISTS atas_nama_id UUID,
+    ADD COLUMN IF NOT EXISTS delegasi_pembimbing_id UUID REFERENCES delegasi_pembimbing (id);
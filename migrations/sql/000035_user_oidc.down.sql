@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_oidc;
+
+DROP INDEX IF EXISTS uq_users_email;
+
+ALTER TABLE public.users
+    DROP COLUMN IF EXISTS email;
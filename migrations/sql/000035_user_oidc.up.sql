@@ -0,0 +1,22 @@
+-- Email pengguna dipakai untuk menautkan akun dengan identitas SSO kampus.
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS email TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_users_email
+    ON public.users (lower(email))
+    WHERE email IS NOT NULL AND deleted_at IS NULL;
+
+-- Identitas OIDC (issuer + subject) yang sudah ditautkan ke pengguna.
+CREATE TABLE IF NOT EXISTS user_oidc (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT,
+    last_login_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_user_oidc_subject UNIQUE (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_oidc_user
+    ON user_oidc (user_id);
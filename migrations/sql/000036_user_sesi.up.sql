@@ -0,0 +1,18 @@
+-- Satu baris per perangkat yang login. refresh_hash menyimpan hash refresh token terakhir;
+-- token lama yang dipakai ulang menandakan kebocoran sehingga seluruh sesi dicabut.
+CREATE TABLE IF NOT EXISTS user_sesi (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    refresh_hash TEXT NOT NULL,
+    user_agent TEXT,
+    ip_address INET,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ,
+    revoked_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sesi_user
+    ON user_sesi (user_id, last_used_at DESC)
+    WHERE revoked_at IS NULL;
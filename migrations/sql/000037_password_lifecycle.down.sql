@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS reset_password;
+
+DROP TABLE IF EXISTS riwayat_password;
+
+ALTER TABLE public.users
+    DROP COLUMN IF EXISTS password_changed_at,
+    DROP COLUMN IF EXISTS wajib_ganti_password;
@@ -0,0 +1,31 @@
+-- Pengguna yang password-nya diatur admin wajib menggantinya saat login pertama.
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS wajib_ganti_password BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ;
+
+-- Hash password yang pernah dipakai, untuk menolak pemakaian ulang.
+CREATE TABLE IF NOT EXISTS riwayat_password (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    password TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_riwayat_password_user
+    ON riwayat_password (user_id, created_at DESC);
+
+-- Token reset password sekali pakai yang diterbitkan admin; hanya hash token yang disimpan.
+CREATE TABLE IF NOT EXISTS reset_password (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_by VARCHAR,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_reset_password_token UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reset_password_user
+    ON reset_password (user_id)
+    WHERE used_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_kehadiran_keputusan_immutable ON kehadiran_keputusan;
+DROP FUNCTION IF EXISTS tolak_ubah_kehadiran_keputusan();
+DROP TABLE IF EXISTS kehadiran_keputusan;
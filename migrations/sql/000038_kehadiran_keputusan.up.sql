@@ -0,0 +1,26 @@
+-- Keputusan pembimbing atas kehadiran secara keseluruhan, termasuk kehadiran tanpa
+-- tindakan SKP. Keputusan oleh penerima delegasi mencatat pembimbing aslinya.
+CREATE TABLE IF NOT EXISTS kehadiran_keputusan (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    kehadiran_id UUID NOT NULL REFERENCES kehadiran (id) ON DELETE RESTRICT,
+    status VARCHAR NOT NULL CHECK (status IN ('disetujui', 'revisi')),
+    reviewer_id UUID NOT NULL,
+    reviewed_by VARCHAR,
+    atas_nama_id UUID,
+    delegasi_pembimbing_id UUID REFERENCES delegasi_pembimbing (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kehadiran_keputusan_kehadiran
+    ON kehadiran_keputusan (kehadiran_id, created_at);
+
+-- Riwayat keputusan tidak boleh diubah maupun dihapus
+CREATE OR REPLACE FUNCTION tolak_ubah_kehadiran_keputusan() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'kehadiran_keputusan bersifat immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_kehadiran_keputusan_immutable
+    BEFORE UPDATE OR DELETE ON kehadiran_keputusan
+    FOR EACH ROW EXECUTE FUNCTION tolak_ubah_kehadiran_keputusan();
@@ -22,6 +22,8 @@ const (
 	ErrorCodeConflict
 	ErrorCodeInternal
 	ErrorCodeBadRequest
+	ErrorCodeLocked
+	ErrorCodeForbidden
 )
 
 // ==========================
@@ -92,6 +94,10 @@ func (e *AppError) HTTPStatus() int {
 		return http.StatusConflict
 	case ErrorCodeBadRequest:
 		return http.StatusBadRequest
+	case ErrorCodeLocked:
+		return http.StatusLocked
+	case ErrorCodeForbidden:
+		return http.StatusForbidden
 	case ErrorCodeUnknown, ErrorCodeInternal:
 		return http.StatusInternalServerError
 	default:
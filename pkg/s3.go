@@ -18,7 +18,7 @@ func NewS3Storage(cfg *config.Config, log logging.Logger) (*minio.Client, error)
 		Secure: cfg.Minio.SSL,
 	})
 	if err != nil {
-		log.Fatal(logging.S3, logging.Startup, err.Error(), nil)
+		log.Error(logging.S3, logging.Startup, err.Error(), nil)
 		return nil, err
 	}
 
@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"context"
+	"e-klinik/config"
+	"sync"
+	"time"
+)
+
+// maksSesiLokal membatasi salinan lokal sebelum entri kedaluwarsa dibersihkan.
+const maksSesiLokal = 10000
+
+// SesiStore mencatat sesi login yang masih berlaku. Redis menjadi sumber kebenaran bersama
+// antar-instance, sedangkan salinan lokal berumur pendek menghemat round trip ke Redis pada
+// setiap request. Pencabutan sesi langsung berlaku di instance yang mencabut dan paling lambat
+// setelah CacheSesiDetik di instance lain.
+type SesiStore struct {
+	cache    *RedisCache
+	ttlLokal time.Duration
+
+	mu    sync.RWMutex
+	lokal map[string]time.Time
+}
+
+func NewSesiStore(cache *RedisCache, cfg *config.Config) *SesiStore {
+	return &SesiStore{
+		cache:    cache,
+		ttlLokal: time.Duration(cfg.Auth.CacheSesiDetik) * time.Second,
+		lokal:    make(map[string]time.Time),
+	}
+}
+
+func kunciSesi(id string) string {
+	return "sesi:" + id
+}
+
+// Simpan menandai sesi aktif selama ttl, biasanya sepanjang umur access token.
+func (s *SesiStore) Simpan(ctx context.Context, id string, ttl time.Duration) bool {
+	if !s.cache.SetWithTTL(ctx, kunciSesi(id), id, ttl) {
+		return false
+	}
+	s.simpanLokal(id)
+	return true
+}
+
+// Aktif memeriksa apakah sesi belum dicabut. Sesi kosong (token lama tanpa klaim sesi) dianggap tidak aktif.
+func (s *SesiStore) Aktif(ctx context.Context, id string) bool {
+	if id == "" {
+		return false
+	}
+
+	s.mu.RLock()
+	exp, ok := s.lokal[id]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(exp) {
+		return true
+	}
+
+	if _, err := s.cache.GetRaw(ctx, kunciSesi(id)); err != nil {
+		s.hapusLokal(id)
+		return false
+	}
+	s.simpanLokal(id)
+	return true
+}
+
+// Cabut menghapus sesi dari Redis dan salinan lokal sehingga access token sesi tersebut ditolak.
+func (s *SesiStore) Cabut(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		s.cache.Delete(ctx, kunciSesi(id))
+		s.hapusLokal(id)
+	}
+}
+
+func (s *SesiStore) simpanLokal(id string) {
+	if s.ttlLokal <= 0 {
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.lokal) >= maksSesiLokal {
+		for k, exp := range s.lokal {
+			if !now.Before(exp) {
+				delete(s.lokal, k)
+			}
+		}
+	}
+	s.lokal[id] = now.Add(s.ttlLokal)
+}
+
+func (s *SesiStore) hapusLokal(id string) {
+	s.mu.Lock()
+	delete(s.lokal, id)
+	s.mu.Unlock()
+}
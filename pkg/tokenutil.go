@@ -15,10 +15,11 @@ func CreateAccessToken(u entity.User, secret string, expiryMinutes int) (string,
 	exp := now.Add(time.Minute * time.Duration(10))
 
 	claims := &entity.JwtCustomRefreshClaims{
-		Username: u.Username,
-		Nama:     u.Nama,
-		Role:     u.Role,
-		Session:  u.Session,
+		Username:      u.Username,
+		Nama:          u.Nama,
+		Role:          u.Role,
+		Session:       u.Session,
+		GantiPassword: u.GantiPassword,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    "e-klink-track",
 			Subject:   fmt.Sprint(u.ID),
@@ -50,6 +51,9 @@ func CreateRefreshToken(u entity.User, secret string, expiryHours int) (string,
 			Subject:   fmt.Sprint(u.ID),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(exp),
+			// ID membuat setiap refresh token unik walau diterbitkan pada detik yang sama,
+			// sehingga token hasil rotasi selalu berbeda dari token sebelumnya.
+			ID: NewUlid(),
 		},
 	}
 
@@ -87,10 +91,24 @@ func IsAuthorized(tokenStr, secret string) (bool, *entity.JwtCustomRefreshClaims
 	if !token.Valid {
 		return false, claims, fmt.Errorf("token invalid")
 	}
+	if tokenQr(claims) {
+		return false, claims, fmt.Errorf("token QR absen bukan access/refresh token")
+	}
 
 	return true, claims, nil
 }
 
+// tokenQr mengenali token QR absen agar tidak pernah diterima sebagai access/refresh token,
+// walaupun ditandatangani dengan secret yang sama.
+func tokenQr(claims *entity.JwtCustomRefreshClaims) bool {
+	for _, aud := range claims.Audience {
+		if aud == audienceQrKehadiran {
+			return true
+		}
+	}
+	return false
+}
+
 func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 	claims := &entity.JwtCustomRefreshClaims{}
 
@@ -105,7 +123,7 @@ func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 		return "", err
 	}
 
-	if !token.Valid {
+	if !token.Valid || tokenQr(claims) {
 		return "", fmt.Errorf("token is invalid")
 	}
 
@@ -125,10 +143,63 @@ func ExtractClaimsFromToken(requestToken string, secret string) (*entity.JwtCust
 		return nil, err
 	}
 
-	if !token.Valid {
+	if !token.Valid || tokenQr(claims) {
 		return nil, fmt.Errorf("token is invalid")
 	}
 
 	// Return the claims object instead of just the subject.
 	return claims, nil
 }
+
+// audienceQrKehadiran membedakan token QR absen dari access/refresh token.
+const audienceQrKehadiran = "kehadiran-qr"
+
+// CreateQrKehadiranToken membuat token QR absen berumur pendek yang terikat pada ruangan dan tanggal.
+func CreateQrKehadiranToken(ruanganID, pembimbingKlinik, tgl, secret string, ttl time.Duration) (string, int64, error) {
+	now := time.Now().UTC()
+	exp := now.Add(ttl)
+
+	claims := &entity.JwtQrKehadiranClaims{
+		RuanganID:        ruanganID,
+		PembimbingKlinik: pembimbingKlinik,
+		Tgl:              tgl,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "e-klink-track",
+			Subject:   pembimbingKlinik,
+			Audience:  jwt.ClaimStrings{audienceQrKehadiran},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(exp),
+			ID:        NewUlid(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return signed, exp.Unix(), nil
+}
+
+// ParseQrKehadiranToken memverifikasi tanda tangan, masa berlaku, dan audience token QR absen.
+func ParseQrKehadiranToken(tokenStr, secret string) (*entity.JwtQrKehadiranClaims, error) {
+	claims := &entity.JwtQrKehadiranClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(audienceQrKehadiran), jwt.WithExpirationRequired())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("token is invalid")
+	}
+
+	return claims, nil
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -48,6 +49,13 @@ func DerefString(ptr *string) string {
 	return ""
 }
 
+func DerefBool(ptr *bool) bool {
+	if ptr != nil {
+		return *ptr
+	}
+	return false
+}
+
 func StrToInt64(str string) int64 {
 	num, err := strconv.ParseInt(str, 10, 64)
 	if err != nil {
@@ -332,3 +340,27 @@ func StrToListInt32(csv string) ([]int32, error) {
 func ContextWithTimeout(c *gin.Context, d time.Duration) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(c.Request.Context(), d)
 }
+
+// HaversineMeter menghitung jarak dua titik koordinat (derajat) dalam meter.
+func HaversineMeter(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371000.0
+
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	rLat1 := lat1 * math.Pi / 180
+	rLat2 := lat2 * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rLat1)*math.Cos(rLat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// ParseJam mengubah string "HH:MM" menjadi pgtype.Time untuk kolom TIME.
+func ParseJam(s string) (pgtype.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return pgtype.Time{}, err
+	}
+	return pgtype.Time{Microseconds: int64(t.Hour()*3600+t.Minute()*60) * 1_000_000, Valid: true}, nil
+}